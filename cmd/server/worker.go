@@ -0,0 +1,26 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/d3ta-go/ms-email-restapi/interface/cmd-apps/worker"
+	"github.com/spf13/cobra"
+)
+
+// workerCmd represents the delivery worker server command
+var workerCmd = &cobra.Command{
+	Use:   "worker",
+	Short: "Shows the delivery worker server command.",
+	Long:  `Shows the delivery worker server command (consumes and delivers queued/outbox messages, no HTTP API).`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if err := worker.StartDeliveryWorker(); err != nil {
+			fmt.Println("Error while running `StartDeliveryWorker()`: ")
+			panic(err)
+		}
+	},
+}
+
+func init() {
+	ServerCmd.AddCommand(workerCmd)
+}
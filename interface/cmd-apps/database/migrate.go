@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/d3ta-go/ddd-mod-email/modules/email/infrastructure/migration"
+	migDelivery "github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/migration"
+	appConfig "github.com/d3ta-go/ms-email-restapi/system/config"
 	"github.com/d3ta-go/system/system/config"
 	"github.com/d3ta-go/system/system/handler"
 	"github.com/d3ta-go/system/system/initialize"
@@ -18,6 +20,9 @@ func initConfig(h *handler.Handler) (*config.Config, error) {
 	}
 	h.SetDefaultConfig(cfg)
 	h.SetViper("config", viper)
+	if _, err := appConfig.LoadConfig(h); err != nil {
+		return nil, err
+	}
 
 	viper.OnConfigChange(func(e fsnotify.Event) {
 		// fmt.Println("config file changed:", e.Name)
@@ -27,6 +32,9 @@ func initConfig(h *handler.Handler) (*config.Config, error) {
 		}
 
 		h.SetDefaultConfig(c)
+		if _, err := appConfig.LoadConfig(h); err != nil {
+			fmt.Println(err)
+		}
 		initializeSystems(h)
 	})
 
@@ -78,5 +86,17 @@ func RunDBMigrate() error {
 		}
 	}
 
+	// local module: delivery
+	migD, err := migDelivery.NewRDBMSMigration(superHandler)
+	if err != nil {
+		return err
+	}
+
+	if err := migD.Run(); err != nil {
+		if err := migD.RollBack(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
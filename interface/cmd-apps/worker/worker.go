@@ -0,0 +1,130 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+
+	appDelivery "github.com/d3ta-go/ms-email-restapi/modules/delivery/application"
+	appConfig "github.com/d3ta-go/ms-email-restapi/system/config"
+	"github.com/d3ta-go/system/system/config"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/initialize"
+	"github.com/fsnotify/fsnotify"
+	"github.com/labstack/gommon/log"
+)
+
+func initConfig(h *handler.Handler) (*config.Config, error) {
+	//init config
+	cfg, viper, err := config.NewConfig("./")
+	if err != nil {
+		panic(err)
+	}
+	h.SetDefaultConfig(cfg)
+	h.SetViper("config", viper)
+	if _, err := appConfig.LoadConfig(h); err != nil {
+		return nil, err
+	}
+
+	viper.OnConfigChange(func(e fsnotify.Event) {
+		// fmt.Println("config file changed:", e.Name)
+		c := new(config.Config)
+		if err := viper.Unmarshal(&c); err != nil {
+			fmt.Println(err)
+		}
+
+		h.SetDefaultConfig(c)
+		if _, err := appConfig.LoadConfig(h); err != nil {
+			fmt.Println(err)
+		}
+		initializeSystems(h)
+	})
+
+	return cfg, nil
+}
+
+func initializeSystems(h *handler.Handler) error {
+
+	// initialize database
+	if err := initialize.LoadAllDatabaseConnection(h); err != nil {
+		panic(err)
+	}
+
+	// initialize cacher
+	if err := initialize.OpenAllCacheConnection(h); err != nil {
+		panic(err)
+	}
+
+	return nil
+}
+
+// StartDeliveryWorker start delivery worker: outbox/recurring schedule dispatcher and delivery queue consumers (no HTTP API)
+func StartDeliveryWorker() error {
+	// init super handler
+	superHandler := new(handler.Handler)
+
+	// init configuration
+	_, err := initConfig(superHandler)
+	if err != nil {
+		return err
+	}
+
+	// initialize Systems
+	err = initializeSystems(superHandler)
+	if err != nil {
+		return err
+	}
+	defer initialize.CloseDBConnections(superHandler)
+
+	cfg, err := appConfig.GetConfig(superHandler)
+	if err != nil {
+		return err
+	}
+
+	appDlv, err := appDelivery.NewDeliveryApp(superHandler)
+	if err != nil {
+		return err
+	}
+
+	logger := log.New("worker")
+	logger.SetLevel(log.INFO)
+
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		appDlv.RunScheduler(ctx, func(err error) {
+			logger.Errorf("Delivery scheduler: %s", err.Error())
+		})
+	}()
+
+	consumers := 0
+	if cfg.Delivery.Queue.IsEnabled() {
+		consumers = cfg.Delivery.Worker.GetConsumers()
+	}
+	for n := 0; n < consumers; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			appDlv.RunQueueConsumer(ctx, func(err error) {
+				logger.Errorf("Delivery queue consumer: %s", err.Error())
+			})
+		}()
+	}
+	logger.Infof("Delivery worker started [queue consumers: %d]", consumers)
+
+	// graceful shutdown: wait for the messages being delivered
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt)
+	<-quit
+	logger.Info("Shutting down the delivery worker")
+	stop()
+	wg.Wait()
+
+	return nil
+}
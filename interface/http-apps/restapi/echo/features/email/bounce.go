@@ -0,0 +1,35 @@
+package email
+
+import (
+	"net/http"
+
+	appDeliveryDTOBounce "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/bounce"
+	"github.com/d3ta-go/system/interface/http-apps/restapi/echo/response"
+	"github.com/labstack/echo/v4"
+)
+
+// maxBounceReportSize maximum size (bytes) of a raw bounce (or feedback) report
+const maxBounceReportSize = 10 << 20 // 10 MB
+
+// IngestEmailBounce ingest raw delivery status notification (RFC 3464) or feedback report (RFC 5965), e.g: forwarded by the MTA.
+// The request body is the raw message (Content-Type: message/rfc822).
+func (f *FEmail) IngestEmailBounce(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	req := new(appDeliveryDTOBounce.IngestBounceReqDTO)
+	req.Report = http.MaxBytesReader(c.Response(), c.Request().Body, maxBounceReportSize)
+
+	resp, err := f.appDelivery.BounceSvc.Ingest(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
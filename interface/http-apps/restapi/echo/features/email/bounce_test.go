@@ -0,0 +1,91 @@
+package email
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ht "github.com/d3ta-go/ms-email-restapi/interface/http-apps/restapi/echo/features/helper_test"
+	"github.com/d3ta-go/system/system/initialize"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmail_IngestEmailBounce(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+	testData := viper.GetStringMapString("test-data.email.email-bounce.interface-layer.features.ingest.request")
+
+	// client request (raw delivery status notification)
+	reqBody := "From: MAILER-DAEMON@mx.domain.tld\r\n" +
+		"Subject: Undelivered Mail Returned to Sender\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/report; report-type=delivery-status; boundary=\"B1\"\r\n" +
+		"\r\n" +
+		"--B1\r\n" +
+		"Content-Type: message/delivery-status\r\n" +
+		"\r\n" +
+		"Reporting-MTA: dns; mx.domain.tld\r\n" +
+		"\r\n" +
+		"Final-Recipient: rfc822; " + testData["recipient"] + "\r\n" +
+		"Action: failed\r\n" +
+		"Status: 5.1.1\r\n" +
+		"Diagnostic-Code: smtp; 550 5.1.1 User unknown\r\n" +
+		"\r\n" +
+		"--B1\r\n" +
+		"Content-Type: text/rfc822-headers\r\n" +
+		"\r\n" +
+		"To: " + testData["recipient"] + "\r\n" +
+		"Message-ID: " + testData["message-id-header"] + "\r\n" +
+		"\r\n" +
+		"--B1--\r\n"
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/email/bounces", strings.NewReader(reqBody))
+	req.Header.Set(echo.HeaderContentType, "message/rfc822")
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.IngestEmailBounce(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Contains(t, res.Body.String(), `"type":"HARD"`)
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-bounce.interface-layer.features.ingest.response.json", res.Body.String())
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.IngestEmailBounce: %s", res.Body.String())
+	}
+}
@@ -1,17 +1,32 @@
 package email
 
 import (
+	"fmt"
 	"net/http"
 
 	appEmail "github.com/d3ta-go/ddd-mod-email/modules/email/application"
 	appEmailDTO "github.com/d3ta-go/ddd-mod-email/modules/email/application/dto/email"
 	appEmailDTOET "github.com/d3ta-go/ddd-mod-email/modules/email/application/dto/email_template"
+	appDelivery "github.com/d3ta-go/ms-email-restapi/modules/delivery/application"
+	appDeliveryDTOBatch "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/batch"
+	appDeliveryDTOMessage "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/message"
+	appDeliveryDTOTA "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/template_asset"
+	appDeliveryDTOTS "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/template_setting"
+	domSchemaWebhook "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/webhook"
 	"github.com/d3ta-go/system/interface/http-apps/restapi/echo/features"
 	"github.com/d3ta-go/system/interface/http-apps/restapi/echo/response"
 	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	// HeaderIdempotencyKey Idempotency-Key request header
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed response header, set when the original response of the Idempotency-Key is returned
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
 // NewFEmail new FEmail
 func NewFEmail(h *handler.Handler) (*FEmail, error) {
 	var err error
@@ -22,6 +37,9 @@ func NewFEmail(h *handler.Handler) (*FEmail, error) {
 	if f.appEmail, err = appEmail.NewEmailApp(h); err != nil {
 		return nil, err
 	}
+	if f.appDelivery, err = appDelivery.NewDeliveryApp(h); err != nil {
+		return nil, err
+	}
 
 	return f, nil
 }
@@ -29,7 +47,8 @@ func NewFEmail(h *handler.Handler) (*FEmail, error) {
 // FEmail represent Email Feature
 type FEmail struct {
 	features.BaseFeature
-	appEmail *appEmail.EmailApp
+	appEmail    *appEmail.EmailApp
+	appDelivery *appDelivery.DeliveryApp
 }
 
 // ListAllEmailTemplate list all EmailTemplate
@@ -97,6 +116,8 @@ func (f *FEmail) CreateEmailTemplate(c echo.Context) error {
 		return f.TranslateErrorMessage(err, c)
 	}
 
+	f.publishTemplateEvent(req.Code, domSchemaWebhook.TemplateCreated, i, c)
+
 	return response.OKWithData(resp, c)
 }
 
@@ -132,6 +153,21 @@ func (f *FEmail) UpdateEmailTemplate(c echo.Context) error {
 		return f.TranslateErrorMessage(err, c)
 	}
 
+	// new template version keeps the assets (inline images) of the previous version,
+	// the template is updated (committed) already: a failure is reported as partial success
+	partialMsg := ""
+	reqTA := new(appDeliveryDTOTA.TACarryOverReqDTO)
+	reqTA.TemplateCode = code
+	if _, err := f.appDelivery.TemplateAssetSvc.CarryOver(reqTA, i); err != nil {
+		c.Logger().Errorf("Carry over assets of email template `%s`: %s", code, err.Error())
+		partialMsg = fmt.Sprintf("Email Template updated, but the assets (inline images) of the previous version were not carried over to the new version, add them again: %s", err.Error())
+	}
+
+	f.publishTemplateEvent(code, domSchemaWebhook.TemplateUpdated, i, c)
+
+	if partialMsg != "" {
+		return response.OKDetailed(resp, partialMsg, c)
+	}
 	return response.OKWithData(resp, c)
 }
 
@@ -167,6 +203,8 @@ func (f *FEmail) SetActiveEmailTemplate(c echo.Context) error {
 		return f.TranslateErrorMessage(err, c)
 	}
 
+	f.publishTemplateEvent(code, domSchemaWebhook.TemplateSetActive, i, c)
+
 	return response.OKWithData(resp, c)
 }
 
@@ -192,10 +230,143 @@ func (f *FEmail) DeleteEmailTemplate(c echo.Context) error {
 		return f.TranslateErrorMessage(err, c)
 	}
 
+	f.publishTemplateEvent(code, domSchemaWebhook.TemplateDeleted, i, c)
+
+	return response.OKWithData(resp, c)
+}
+
+// publishTemplateEvent publish template.updated webhook event,
+// the template change is committed already: a failure is logged, it does not fail the request
+func (f *FEmail) publishTemplateEvent(code string, action domSchemaWebhook.TemplateAction, i identity.Identity, c echo.Context) {
+	if err := f.appDelivery.WebhookSvc.PublishTemplateEvent(code, action, i); err != nil {
+		c.Logger().Errorf("Publish webhook event (%s) of email template `%s`: %s", action, code, err.Error())
+	}
+}
+
+// ListEmailTemplateAsset list assets (inline images) of EmailTemplate version (default: current version)
+func (f *FEmail) ListEmailTemplateAsset(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOTA.TAListReqDTO)
+	req.TemplateCode = c.Param("code")
+	req.Version = c.QueryParam("version")
+
+	resp, err := f.appDelivery.TemplateAssetSvc.List(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// AddEmailTemplateAsset add asset (inline image) to the current version of EmailTemplate
+func (f *FEmail) AddEmailTemplateAsset(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	req := new(appDeliveryDTOTA.TAAddReqDTO)
+	if err := c.Bind(req); err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	// param
+	req.TemplateCode = c.Param("code")
+
+	resp, err := f.appDelivery.TemplateAssetSvc.Add(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// DeleteEmailTemplateAsset delete asset (inline image) from the current version of EmailTemplate
+func (f *FEmail) DeleteEmailTemplateAsset(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOTA.TADeleteReqDTO)
+	req.TemplateCode = c.Param("code")
+	req.ContentID = c.Param("contentId")
+
+	resp, err := f.appDelivery.TemplateAssetSvc.Delete(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// FindEmailTemplateSetting find settings (category) of EmailTemplate
+func (f *FEmail) FindEmailTemplateSetting(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOTS.TSFindReqDTO)
+	req.TemplateCode = c.Param("code")
+
+	resp, err := f.appDelivery.TemplateSettingSvc.Find(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
 	return response.OKWithData(resp, c)
 }
 
-// SendEmail send Email
+// UpdateEmailTemplateSetting update settings (category) of EmailTemplate
+func (f *FEmail) UpdateEmailTemplateSetting(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	req := new(appDeliveryDTOTS.TSUpdateReqDTO)
+	if err := c.Bind(req); err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	// param
+	req.TemplateCode = c.Param("code")
+
+	resp, err := f.appDelivery.TemplateSettingSvc.Update(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// SendEmail send Email (application/json, or multipart/form-data with json `payload` part and attachment file parts)
 func (f *FEmail) SendEmail(c echo.Context) error {
 	// identity
 	i, err := f.SetIdentity(c)
@@ -206,12 +377,123 @@ func (f *FEmail) SendEmail(c echo.Context) error {
 		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
 	}
 
-	req := new(appEmailDTO.SendEmailReqDTO)
+	req := new(appDeliveryDTOMessage.SendMessageReqDTO)
+	if isMultipartRequest(c) {
+		// json payload + attachments
+		if err := f.bindMultipartMessage(c, req); err != nil {
+			req.RemoveSpooledAttachments()
+			return f.TranslateErrorMessage(err, c)
+		}
+	} else {
+		if err := c.Bind(req); err != nil {
+			return f.TranslateErrorMessage(err, c)
+		}
+	}
+
+	// optional: retried request with the same key returns the original response
+	req.IdempotencyKey = c.Request().Header.Get(HeaderIdempotencyKey)
+
+	resp, err := f.appDelivery.MessageSvc.Send(req, i)
+	if err != nil {
+		req.RemoveSpooledAttachments()
+		return f.TranslateErrorMessage(err, c)
+	}
+	if resp.Replayed {
+		c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// PreviewEmail render Email Template message without sending it (dry run), returns the rendered subject, body and raw MIME message
+func (f *FEmail) PreviewEmail(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	req := new(appDeliveryDTOMessage.SendMessageReqDTO)
+	if isMultipartRequest(c) {
+		// json payload + attachments
+		if err := f.bindMultipartMessage(c, req); err != nil {
+			req.RemoveSpooledAttachments()
+			return f.TranslateErrorMessage(err, c)
+		}
+	} else {
+		if err := c.Bind(req); err != nil {
+			return f.TranslateErrorMessage(err, c)
+		}
+	}
+
+	resp, err := f.appDelivery.MessageSvc.Preview(req, i)
+	if err != nil {
+		req.RemoveSpooledAttachments()
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// SendBatchEmail send Email Template to many recipients (mail merge)
+func (f *FEmail) SendBatchEmail(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	req := new(appDeliveryDTOBatch.SendBatchReqDTO)
 	if err := c.Bind(req); err != nil {
 		return f.TranslateErrorMessage(err, c)
 	}
 
-	resp, err := f.appEmail.EmailSvc.Send(req, i)
+	resp, err := f.appDelivery.BatchSvc.Send(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// SendBatchCSVEmail send Email Template to recipients from uploaded mail merge csv file (multipart/form-data)
+func (f *FEmail) SendBatchCSVEmail(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	req := new(appDeliveryDTOBatch.SendBatchCSVReqDTO)
+	req.TemplateCode = c.FormValue("templateCode")
+	req.ProcessingType = c.FormValue("processingType")
+	req.From = &appEmailDTO.MailAddressDTO{
+		Email: c.FormValue("fromEmail"),
+		Name:  c.FormValue("fromName"),
+	}
+
+	// csv file
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return f.TranslateErrorMessage(echo.NewHTTPError(http.StatusBadRequest, "Invalid `file` (csv): "+err.Error()), c)
+	}
+	file, err := fh.Open()
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	defer file.Close()
+	req.CSVFile = file
+
+	resp, err := f.appDelivery.BatchSvc.SendCSV(req, i)
 	if err != nil {
 		return f.TranslateErrorMessage(err, c)
 	}
@@ -1,13 +1,22 @@
 package email
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	ht "github.com/d3ta-go/ms-email-restapi/interface/http-apps/restapi/echo/features/helper_test"
+	domSchemaBatch "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/batch"
+	domSchemaMessage "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+	"github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/service/mailer"
 	"github.com/d3ta-go/system/system/initialize"
 	"github.com/d3ta-go/system/system/utils"
 	"github.com/labstack/echo/v4"
@@ -125,6 +134,10 @@ func TestEmail_CreateEmailTemplate(t *testing.T) {
 		viper.Set("test-data.email.email-template.interface-layer.features.delete.request.et-code", etCode)
 		viper.Set("test-data.email.email-template.interface-layer.features.set-active.request.et-code", etCode)
 
+		viper.Set("test-data.email.email-template.interface-layer.features.add-asset.request.et-code", etCode)
+		viper.Set("test-data.email.email-template.interface-layer.features.list-asset.request.et-code", etCode)
+		viper.Set("test-data.email.email-template.interface-layer.features.delete-asset.request.et-code", etCode)
+
 		if err := viper.WriteConfig(); err != nil {
 			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
 		}
@@ -190,6 +203,69 @@ func TestEmail_FindEmailTemplateByCode(t *testing.T) {
 	}
 }
 
+func TestEmail_AddEmailTemplateAsset(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+	testData := viper.GetStringMapString("test-data.email.email-template.interface-layer.features.add-asset.request")
+
+	// client request
+	reqDTO := `{
+	"contentId": "` + testData["ta-content-id"] + `",
+	"filename": "` + testData["ta-filename"] + `",
+	"contentType": "` + testData["ta-content-type"] + `",
+	"content": "` + testData["ta-content"] + `"
+}`
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/email/template/:code/assets", strings.NewReader(reqDTO))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+	c.SetParamNames("code")
+	c.SetParamValues(testData["et-code"])
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.AddEmailTemplateAsset(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-template.interface-layer.features.add-asset.response.json", res.Body.String())
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.AddEmailTemplateAsset: %s", res.Body.String())
+	}
+}
+
 func TestEmail_UpdateEmailTemplate(t *testing.T) {
 	h := ht.NewHandler()
 
@@ -256,6 +332,189 @@ func TestEmail_UpdateEmailTemplate(t *testing.T) {
 	}
 }
 
+func TestEmail_ListEmailTemplateAsset(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+	testData := viper.GetStringMapString("test-data.email.email-template.interface-layer.features.list-asset.request")
+
+	// client request
+	// --> set on context param [http method = GET]
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/email/template/:code/assets", nil)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+	c.SetParamNames("code")
+	c.SetParamValues(testData["et-code"])
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.ListEmailTemplateAsset(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-template.interface-layer.features.list-asset.response.json", res.Body.String())
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.ListEmailTemplateAsset: %s", res.Body.String())
+	}
+}
+
+func TestEmail_UpdateEmailTemplateSetting(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+	testData := viper.GetStringMapString("test-data.email.email-template.interface-layer.features.update-setting.request")
+
+	// client request
+	reqDTO := `{
+	"category": "` + testData["ts-category"] + `",
+	"trackOpens": ` + testData["ts-track-opens"] + `,
+	"trackClicks": ` + testData["ts-track-clicks"] + `,
+	"textBodyTpl": ` + strconv.Quote(testData["ts-text-body-tpl"]) + `,
+	"inlineCss": ` + testData["ts-inline-css"] + `
+}`
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/email/template/:code/settings", strings.NewReader(reqDTO))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+	c.SetParamNames("code")
+	c.SetParamValues(testData["et-code"])
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.UpdateEmailTemplateSetting(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Contains(t, res.Body.String(), `"category":"`+testData["ts-category"]+`"`)
+		assert.Contains(t, res.Body.String(), `"trackOpens":`+testData["ts-track-opens"])
+		assert.Contains(t, res.Body.String(), `"trackClicks":`+testData["ts-track-clicks"])
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-template.interface-layer.features.update-setting.response.json", res.Body.String())
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.UpdateEmailTemplateSetting: %s", res.Body.String())
+	}
+}
+
+func TestEmail_FindEmailTemplateSetting(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+	testData := viper.GetStringMapString("test-data.email.email-template.interface-layer.features.find-setting.request")
+
+	// client request
+	// --> set on context param [http method = GET]
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/email/template/:code/settings", nil)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+	c.SetParamNames("code")
+	c.SetParamValues(testData["et-code"])
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.FindEmailTemplateSetting(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-template.interface-layer.features.find-setting.response.json", res.Body.String())
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.FindEmailTemplateSetting: %s", res.Body.String())
+	}
+}
+
 func TestEmail_SetActiveEmailTemplate(t *testing.T) {
 	h := ht.NewHandler()
 
@@ -316,6 +575,64 @@ func TestEmail_SetActiveEmailTemplate(t *testing.T) {
 	}
 }
 
+func TestEmail_DeleteEmailTemplateAsset(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+	testData := viper.GetStringMapString("test-data.email.email-template.interface-layer.features.delete-asset.request")
+
+	// client request
+	// --> set on context param [http method = DELETE]
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/email/template/:code/assets/:contentId", nil)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+	c.SetParamNames("code", "contentId")
+	c.SetParamValues(testData["et-code"], testData["ta-content-id"])
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.DeleteEmailTemplateAsset(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-template.interface-layer.features.delete-asset.response.json", res.Body.String())
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.DeleteEmailTemplateAsset: %s", res.Body.String())
+	}
+}
+
 func TestEmail_DeleteEmailTemplate(t *testing.T) {
 	h := ht.NewHandler()
 
@@ -449,3 +766,374 @@ func TestEmail_SendEmail(t *testing.T) {
 		t.Logf("RESPONSE.Email.SendEmail: %s", res.Body.String())
 	}
 }
+
+func TestEmail_SendEmailWithAttachment(t *testing.T) {
+	ft, ok := newFeatureTest(t)
+	if !ok {
+		return
+	}
+	testData := ft.testData("send-attachment")
+
+	// client request: json payload + attachment (file)
+	body := new(bytes.Buffer)
+	mw := multipart.NewWriter(body)
+	mw.WriteField("payload", string(ft.sendRequest(nil)))
+	fw, err := mw.CreateFormFile("file", testData["attachment-filename"])
+	if err != nil {
+		t.Errorf("CreateFormFile: %s", err.Error())
+		return
+	}
+	fw.Write([]byte(testData["attachment-content"]))
+	mw.Close()
+
+	// the attachment is a part of the message
+	res, err := ft.serve(ft.email.PreviewEmail, ft.newRequest(http.MethodPost, "/api/v1/email/send/preview", body.Bytes(), mw.FormDataContentType()))
+	var preview domSchemaMessage.PreviewMessageResponse
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, res.Code) && ft.result(res, &preview) {
+		assert.Contains(t, preview.Raw, "Content-Disposition: attachment; filename="+testData["attachment-filename"])
+		assert.Contains(t, preview.Raw, base64.StdEncoding.EncodeToString([]byte(testData["attachment-content"])))
+	}
+
+	res, err = ft.serve(ft.email.SendEmail, ft.newRequest(http.MethodPost, "/api/v1/email/send", body.Bytes(), mw.FormDataContentType()))
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, res.Code) {
+		ft.save("send-attachment", "json", res)
+	}
+}
+
+func TestEmail_SendEmailWithBase64Attachment(t *testing.T) {
+	ft, ok := newFeatureTest(t)
+	if !ok {
+		return
+	}
+	testData := ft.testData("send-attachment")
+
+	// client request
+	reqDTO := ft.sendRequest(map[string]interface{}{
+		"attachments": []map[string]string{{
+			"filename":    testData["attachment-filename"],
+			"contentType": "text/plain",
+			"content":     base64.StdEncoding.EncodeToString([]byte(testData["attachment-content"])),
+		}},
+	})
+
+	// the attachment is a part of the message
+	res, err := ft.serve(ft.email.PreviewEmail, ft.newRequest(http.MethodPost, "/api/v1/email/send/preview", reqDTO, echo.MIMEApplicationJSON))
+	var preview domSchemaMessage.PreviewMessageResponse
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, res.Code) && ft.result(res, &preview) {
+		assert.Contains(t, preview.Raw, "Content-Disposition: attachment; filename="+testData["attachment-filename"])
+		assert.Contains(t, preview.Raw, base64.StdEncoding.EncodeToString([]byte(testData["attachment-content"])))
+	}
+
+	res, err = ft.serve(ft.email.SendEmail, ft.newRequest(http.MethodPost, "/api/v1/email/send", reqDTO, echo.MIMEApplicationJSON))
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, res.Code) {
+		ft.save("send-attachment", "json-base64", res)
+	}
+}
+
+func TestEmail_SendEmailWithReplyToHeaders(t *testing.T) {
+	ft, ok := newFeatureTest(t)
+	if !ok {
+		return
+	}
+	testData := ft.testData("send-reply-to")
+
+	// client request
+	reqDTO := ft.sendRequest(map[string]interface{}{
+		"replyTo":  map[string]string{"email": testData["reply-to-email"], "name": testData["reply-to-name"]},
+		"headers":  map[string]string{"X-Correlation-ID": testData["header-correlation-id"]},
+		"priority": testData["priority"],
+	})
+
+	// the reply-to, custom and priority headers are set on the message
+	res, err := ft.serve(ft.email.PreviewEmail, ft.newRequest(http.MethodPost, "/api/v1/email/send/preview", reqDTO, echo.MIMEApplicationJSON))
+	var preview domSchemaMessage.PreviewMessageResponse
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, res.Code) && ft.result(res, &preview) {
+		assert.Contains(t, preview.Raw, "Reply-To: ")
+		assert.Contains(t, preview.Raw, testData["reply-to-email"])
+		assert.Contains(t, preview.Raw, "X-Correlation-Id: "+testData["header-correlation-id"])
+		assert.Contains(t, preview.Raw, "X-Priority: ")
+	}
+
+	res, err = ft.serve(ft.email.SendEmail, ft.newRequest(http.MethodPost, "/api/v1/email/send", reqDTO, echo.MIMEApplicationJSON))
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, res.Code) {
+		ft.save("send-reply-to", "json", res)
+	}
+}
+
+func TestEmail_SendEmailWithCalendarEvent(t *testing.T) {
+	ft, ok := newFeatureTest(t)
+	if !ok {
+		return
+	}
+	testData := ft.testData("send-calendar")
+
+	// client request
+	reqDTO := ft.sendRequest(map[string]interface{}{
+		"calendarEvent": map[string]string{
+			"uid":      testData["calendar-uid"],
+			"summary":  testData["calendar-summary"],
+			"start":    testData["calendar-start"],
+			"end":      testData["calendar-end"],
+			"timezone": testData["calendar-timezone"],
+			"location": testData["calendar-location"],
+		},
+	})
+
+	// the invite is a text/calendar part (and attachment) of the message
+	res, err := ft.serve(ft.email.PreviewEmail, ft.newRequest(http.MethodPost, "/api/v1/email/send/preview", reqDTO, echo.MIMEApplicationJSON))
+	var preview domSchemaMessage.PreviewMessageResponse
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, res.Code) && ft.result(res, &preview) {
+		assert.Contains(t, preview.Raw, "Content-Type: text/calendar")
+		assert.Contains(t, preview.Raw, "filename="+mailer.CalendarFilename)
+		assert.Contains(t, preview.Raw, "SUMMARY:"+testData["calendar-summary"])
+	}
+
+	res, err = ft.serve(ft.email.SendEmail, ft.newRequest(http.MethodPost, "/api/v1/email/send", reqDTO, echo.MIMEApplicationJSON))
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, res.Code) {
+		ft.save("send-calendar", "json", res)
+	}
+}
+
+func TestEmail_PreviewEmail(t *testing.T) {
+	ft, ok := newFeatureTest(t)
+	if !ok {
+		return
+	}
+	testData := ft.testData("send")
+	testDataET := ft.viper.GetStringMapString(sendTestDataKey + "send.request.email-template-data")
+
+	// client request
+	reqDTO := ft.sendRequest(nil)
+
+	res, err := ft.serve(ft.email.PreviewEmail, ft.newRequest(http.MethodPost, "/api/v1/email/send/preview", reqDTO, echo.MIMEApplicationJSON))
+	var preview domSchemaMessage.PreviewMessageResponse
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, res.Code) && ft.result(res, &preview) {
+		assert.Equal(t, testData["email-template-code"], preview.TemplateCode)
+		assert.NotEmpty(t, preview.Subject)
+		assert.Contains(t, preview.Body, testDataET["body-user-account"])
+		assert.Contains(t, preview.Raw, "To: ")
+		assert.Contains(t, preview.Raw, testData["to-email"])
+		ft.save("send-preview", "json", res)
+	}
+}
+
+func TestEmail_PreviewEmailSubject(t *testing.T) {
+	ft, ok := newFeatureTest(t)
+	if !ok {
+		return
+	}
+	testData := ft.testData("send-preview-subject")
+	testDataET := ft.viper.GetStringMapString(sendTestDataKey + "send.request.email-template-data")
+
+	unique := utils.GenerateUUID()
+	etCode := fmt.Sprintf(testData["et-code"], unique)
+
+	// email template with a subject template variable
+	reqET, _ := json.Marshal(map[string]interface{}{
+		"code":        etCode,
+		"name":        fmt.Sprintf(testData["et-name"], unique),
+		"isActive":    true,
+		"emailFormat": "HTML",
+		"template": map[string]string{
+			"subjectTpl": testData["et-tpl-subject"],
+			"bodyTpl":    testData["et-tpl-body"],
+		},
+	})
+	res, err := ft.serve(ft.email.CreateEmailTemplate, ft.newRequest(http.MethodPost, "/api/v1/email/template", reqET, echo.MIMEApplicationJSON))
+	if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, res.Code) {
+		return
+	}
+
+	// client request
+	reqDTO := ft.sendRequest(map[string]interface{}{"templateCode": etCode})
+
+	res, err = ft.serve(ft.email.PreviewEmail, ft.newRequest(http.MethodPost, "/api/v1/email/send/preview", reqDTO, echo.MIMEApplicationJSON))
+	var preview domSchemaMessage.PreviewMessageResponse
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, res.Code) && ft.result(res, &preview) {
+		// the subject is rendered with the template data
+		assert.Equal(t, "Welcome "+testDataET["header-name"], preview.Subject)
+		ft.save("send-preview-subject", "json", res)
+	}
+
+	// clean up
+	_, err = ft.serve(ft.email.DeleteEmailTemplate, ft.newRequest(http.MethodDelete, "/api/v1/email/template/:code", nil, ""), "code", etCode)
+	assert.NoError(t, err)
+}
+
+func TestEmail_SendScheduledEmail(t *testing.T) {
+	ft, ok := newFeatureTest(t)
+	if !ok {
+		return
+	}
+	testData := ft.testData("send-scheduled")
+
+	// client request
+	sendAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+	reqDTO := ft.sendRequest(map[string]interface{}{
+		"processingType": testData["processing-type"],
+		"sendAt":         sendAt,
+	})
+
+	res, err := ft.serve(ft.email.SendEmail, ft.newRequest(http.MethodPost, "/api/v1/email/send", reqDTO, echo.MIMEApplicationJSON))
+	var sent domSchemaMessage.SendMessageResponse
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, res.Code) && ft.result(res, &sent) {
+		// the message is recorded now, sent later
+		assert.Equal(t, "SCHEDULED", sent.Status)
+		assert.NotEmpty(t, sent.MessageID)
+		assert.NotEmpty(t, sent.ScheduleID)
+		assert.Equal(t, sendAt, sent.SendAt)
+
+		// message id for next tests
+		ft.viper.Set("test-data.email.email-message.interface-layer.features.find.request.message-id", sent.MessageID)
+		ft.save("send-scheduled", "json", res)
+	}
+}
+
+func TestEmail_SendEmailIdempotent(t *testing.T) {
+	ft, ok := newFeatureTest(t)
+	if !ok {
+		return
+	}
+	testData := ft.testData("send-idempotent")
+
+	testDataET := ft.viper.GetStringMapString(sendTestDataKey + "send.request.email-template-data")
+
+	// client request
+	idemKey := utils.GenerateUUID()
+	send := func(userAccount string) (*httptest.ResponseRecorder, *domSchemaMessage.SendMessageResponse) {
+		reqDTO := ft.sendRequest(map[string]interface{}{
+			"templateData": map[string]interface{}{
+				"Header.Name":        testDataET["header-name"],
+				"Body.UserAccount":   userAccount,
+				"Body.ActivationURL": testDataET["body-activation-url"],
+				"Footer.Name":        testDataET["footer-name"],
+			},
+			"processingType": testData["processing-type"],
+		})
+		req := ft.newRequest(http.MethodPost, "/api/v1/email/send", reqDTO, echo.MIMEApplicationJSON)
+		req.Header.Set(HeaderIdempotencyKey, idemKey)
+
+		res, err := ft.serve(ft.email.SendEmail, req)
+		assert.NoError(t, err)
+
+		sent := new(domSchemaMessage.SendMessageResponse)
+		if res.Code == http.StatusOK {
+			ft.result(res, sent)
+		}
+		return res, sent
+	}
+
+	// first request
+	res, sent := send(testDataET["body-user-account"])
+	if !assert.Equal(t, http.StatusOK, res.Code) || !assert.NotEmpty(t, sent.MessageID) {
+		return
+	}
+
+	// retry: original response (same message), not sent again
+	resRetry, sentRetry := send(testDataET["body-user-account"])
+	if assert.Equal(t, http.StatusOK, resRetry.Code) {
+		assert.Equal(t, "true", resRetry.Header().Get(HeaderIdempotentReplayed))
+		assert.Equal(t, sent.MessageID, sentRetry.MessageID)
+	}
+
+	// same key, different payload
+	resConflict, _ := send("jane.doe")
+	assert.Equal(t, http.StatusConflict, resConflict.Code)
+
+	ft.save("send-idempotent", "json", res)
+}
+
+func TestEmail_SendBatchEmail(t *testing.T) {
+	ft, ok := newFeatureTest(t)
+	if !ok {
+		return
+	}
+	testData := ft.testData("send")
+	testDataBatch := ft.testData("send-batch")
+	testDataET := ft.viper.GetStringMapString(sendTestDataKey + "send.request.email-template-data")
+
+	recipient := func(n string) map[string]interface{} {
+		return map[string]interface{}{
+			"to": map[string]string{"email": testDataBatch["to-email-"+n], "name": testDataBatch["to-name-"+n]},
+			"templateData": map[string]interface{}{
+				"Header.Name":        testDataBatch["to-name-"+n],
+				"Body.UserAccount":   testDataET["body-user-account"],
+				"Body.ActivationURL": testDataET["body-activation-url"],
+				"Footer.Name":        testDataET["footer-name"],
+			},
+		}
+	}
+
+	// client request
+	recipient01 := recipient("01")
+	recipient01["cc"] = []map[string]string{{"email": testDataBatch["cc-email-01"], "name": testDataBatch["cc-name-01"]}}
+	reqDTO, _ := json.Marshal(map[string]interface{}{
+		"templateCode":   testData["email-template-code"],
+		"from":           map[string]string{"email": testData["from-email"], "name": testData["from-name"]},
+		"recipients":     []map[string]interface{}{recipient01, recipient("02")},
+		"processingType": testDataBatch["processing-type"],
+	})
+
+	res, err := ft.serve(ft.email.SendBatchEmail, ft.newRequest(http.MethodPost, "/api/v1/email/send/batch", reqDTO, echo.MIMEApplicationJSON))
+	var batch domSchemaBatch.SendBatchResponse
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, res.Code) && ft.result(res, &batch) {
+		assertBatchSent(t, &batch, testDataBatch["to-email-01"], testDataBatch["to-email-02"])
+		ft.save("send-batch", "json", res)
+	}
+}
+
+func TestEmail_SendBatchCSVEmail(t *testing.T) {
+	ft, ok := newFeatureTest(t)
+	if !ok {
+		return
+	}
+	testData := ft.testData("send")
+	testDataCSV := ft.testData("send-batch-csv")
+	testDataET := ft.viper.GetStringMapString(sendTestDataKey + "send.request.email-template-data")
+
+	// client request
+	csvContent := "email,name,Header.Name,Body.UserAccount,Body.ActivationURL,Footer.Name\n"
+	for _, n := range []string{"01", "02"} {
+		csvContent += strings.Join([]string{
+			testDataCSV["to-email-"+n], testDataCSV["to-name-"+n], testDataCSV["to-name-"+n],
+			testDataCSV["to-email-"+n], testDataET["body-activation-url"], testDataET["footer-name"],
+		}, ",") + "\n"
+	}
+
+	body := new(bytes.Buffer)
+	mw := multipart.NewWriter(body)
+	mw.WriteField("templateCode", testData["email-template-code"])
+	mw.WriteField("fromEmail", testData["from-email"])
+	mw.WriteField("fromName", testData["from-name"])
+	mw.WriteField("processingType", testDataCSV["processing-type"])
+	fw, err := mw.CreateFormFile("file", "recipients.csv")
+	if err != nil {
+		t.Errorf("CreateFormFile: %s", err.Error())
+		return
+	}
+	fw.Write([]byte(csvContent))
+	mw.Close()
+
+	res, err := ft.serve(ft.email.SendBatchCSVEmail, ft.newRequest(http.MethodPost, "/api/v1/email/send/batch/csv", body.Bytes(), mw.FormDataContentType()))
+	var batch domSchemaBatch.SendBatchResponse
+	if assert.NoError(t, err) && assert.Equal(t, http.StatusOK, res.Code) && ft.result(res, &batch) {
+		assertBatchSent(t, &batch, testDataCSV["to-email-01"], testDataCSV["to-email-02"])
+		ft.save("send-batch-csv", "json", res)
+	}
+}
+
+// assertBatchSent assert every recipient (to) of the batch is sent (queued), in order
+func assertBatchSent(t *testing.T, batch *domSchemaBatch.SendBatchResponse, to ...string) {
+	assert.Equal(t, len(to), batch.Total)
+	assert.Equal(t, len(to), batch.Succeeded)
+	assert.Equal(t, 0, batch.Suppressed)
+	assert.Equal(t, 0, batch.Failed)
+	if assert.Len(t, batch.Results, len(to)) {
+		for i, r := range batch.Results {
+			assert.Equal(t, i, r.Index)
+			assert.Equal(t, to[i], r.To)
+			assert.NotEmpty(t, r.MessageID)
+			assert.Empty(t, r.Error)
+		}
+	}
+}
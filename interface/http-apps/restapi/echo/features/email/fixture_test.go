@@ -0,0 +1,147 @@
+package email
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ht "github.com/d3ta-go/ms-email-restapi/interface/http-apps/restapi/echo/features/helper_test"
+	"github.com/d3ta-go/system/system/identity"
+	"github.com/d3ta-go/system/system/initialize"
+	"github.com/labstack/echo/v4"
+	"github.com/spf13/viper"
+)
+
+// sendTestDataKey test-data key of the send features
+const sendTestDataKey = "test-data.email.email.interface-layer.features."
+
+// featureTest represent the shared fixture of the send feature tests:
+// test data, feature (connected to the databases) and test user identity
+type featureTest struct {
+	t      *testing.T
+	viper  *viper.Viper
+	email  *FEmail
+	echo   *echo.Echo
+	token  string
+	claims *identity.JWTCustomClaims
+}
+
+// newFeatureTest new feature test fixture, the test is failed when the fixture can not be set up
+func newFeatureTest(t *testing.T) (*featureTest, bool) {
+	h := ht.NewHandler()
+
+	v, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+		return nil, false
+	}
+	if err := initialize.LoadAllDatabaseConnection(h); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return nil, false
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(h, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return nil, false
+	}
+
+	email, err := NewFEmail(h)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return nil, false
+	}
+
+	return &featureTest{t: t, viper: v, email: email, echo: echo.New(), token: token, claims: claims}, true
+}
+
+// testData request test data of the send feature
+func (ft *featureTest) testData(feature string) map[string]string {
+	return ft.viper.GetStringMapString(sendTestDataKey + feature + ".request")
+}
+
+// sendRequest send request (JSON) of the `send` test data, fields are set over it
+func (ft *featureTest) sendRequest(fields map[string]interface{}) []byte {
+	testData := ft.testData("send")
+	testDataET := ft.viper.GetStringMapString(sendTestDataKey + "send.request.email-template-data")
+
+	req := map[string]interface{}{
+		"templateCode": testData["email-template-code"],
+		"from":         map[string]string{"email": testData["from-email"], "name": testData["from-name"]},
+		"to":           map[string]string{"email": testData["to-email"], "name": testData["to-name"]},
+		"templateData": map[string]interface{}{
+			"Header.Name":        testDataET["header-name"],
+			"Body.UserAccount":   testDataET["body-user-account"],
+			"Body.ActivationURL": testDataET["body-activation-url"],
+			"Footer.Name":        testDataET["footer-name"],
+		},
+		"processingType": testData["processing-type"],
+	}
+	for k, v := range fields {
+		req[k] = v
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		ft.t.Errorf("json.Marshal: %s", err.Error())
+	}
+	return body
+}
+
+// newRequest new client request
+func (ft *featureTest) newRequest(method, target string, body []byte, contentType string) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	if contentType != "" {
+		req.Header.Set(echo.HeaderContentType, contentType)
+	}
+	return req
+}
+
+// serve serve the request with the feature handler as the test user, params are the path param name/value pairs
+func (ft *featureTest) serve(handle echo.HandlerFunc, req *http.Request, params ...string) (*httptest.ResponseRecorder, error) {
+	res := httptest.NewRecorder()
+
+	c := ft.echo.NewContext(req, res)
+	if len(params) > 0 {
+		var names, values []string
+		for i := 0; i+1 < len(params); i += 2 {
+			names, values = append(names, params[i]), append(values, params[i+1])
+		}
+		c.SetParamNames(names...)
+		c.SetParamValues(values...)
+	}
+	c.Set("identity.token.jwt", ft.token)
+	c.Set("identity.token.jwt.claims", ft.claims)
+
+	return res, handle(c)
+}
+
+// result decode the result of the (OK) response
+func (ft *featureTest) result(res *httptest.ResponseRecorder, v interface{}) bool {
+	var resJSON struct {
+		Response struct {
+			Result json.RawMessage `json:"result"`
+		} `json:"response"`
+	}
+	if err := json.Unmarshal(res.Body.Bytes(), &resJSON); err != nil {
+		ft.t.Errorf("json.Unmarshal: %s", err.Error())
+		return false
+	}
+	if err := json.Unmarshal(resJSON.Response.Result, v); err != nil {
+		ft.t.Errorf("json.Unmarshal: %s", err.Error())
+		return false
+	}
+	return true
+}
+
+// save save the response of the send feature to test-data (result for next test)
+func (ft *featureTest) save(feature, key string, res *httptest.ResponseRecorder) {
+	ft.viper.Set(sendTestDataKey+feature+".response."+key, res.Body.String())
+	if err := ft.viper.WriteConfig(); err != nil {
+		ft.t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+	}
+	ft.t.Logf("RESPONSE.Email.%s: %s", feature, res.Body.String())
+}
@@ -0,0 +1,90 @@
+package email
+
+import (
+	"net/http"
+	"strconv"
+
+	appDeliveryDTOMessage "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/message"
+	"github.com/d3ta-go/system/interface/http-apps/restapi/echo/response"
+	"github.com/labstack/echo/v4"
+)
+
+// FindEmailMessage find email message record (delivery status, with status transitions)
+func (f *FEmail) FindEmailMessage(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOMessage.FindMessageReqDTO)
+	req.ID = c.Param("id")
+
+	resp, err := f.appDelivery.MessageSvc.FindMessage(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// ListEmailMessage list email message records (summary rows, with cursor pagination)
+func (f *FEmail) ListEmailMessage(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOMessage.ListMessageReqDTO)
+	req.Recipient = c.QueryParam("recipient")
+	req.TemplateCode = c.QueryParam("templateCode")
+	req.Status = c.QueryParam("status")
+	req.SentBy = c.QueryParam("sentBy")
+	req.Since = c.QueryParam("since")
+	req.Until = c.QueryParam("until")
+	req.Cursor = c.QueryParam("cursor")
+	if limit := c.QueryParam("limit"); limit != "" {
+		if req.Limit, err = strconv.Atoi(limit); err != nil {
+			return response.FailWithMessageWithCode(http.StatusBadRequest, "Invalid limit", c)
+		}
+	}
+
+	resp, err := f.appDelivery.MessageSvc.ListMessage(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// FindEmailMessageBody find rendered subject and body of email message record
+func (f *FEmail) FindEmailMessageBody(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOMessage.FindMessageBodyReqDTO)
+	req.ID = c.Param("id")
+
+	resp, err := f.appDelivery.MessageSvc.FindMessageBody(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
@@ -0,0 +1,189 @@
+package email
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	ht "github.com/d3ta-go/ms-email-restapi/interface/http-apps/restapi/echo/features/helper_test"
+	"github.com/d3ta-go/system/system/initialize"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmail_FindEmailMessage(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+	testData := viper.GetStringMapString("test-data.email.email-message.interface-layer.features.find.request")
+
+	// client request
+	// --> set on context param [http method = GET]
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/email/messages/:id", nil)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+	c.SetParamNames("id")
+	c.SetParamValues(testData["message-id"])
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.FindEmailMessage(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-message.interface-layer.features.find.response.json", res.Body.String())
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.FindEmailMessage: %s", res.Body.String())
+	}
+}
+
+func TestEmail_FindEmailMessageBody(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+	testData := viper.GetStringMapString("test-data.email.email-message.interface-layer.features.find.request")
+
+	// client request
+	// --> set on context param [http method = GET]
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/email/messages/:id/body", nil)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+	c.SetParamNames("id")
+	c.SetParamValues(testData["message-id"])
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.FindEmailMessageBody(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-message.interface-layer.features.find-body.response.json", res.Body.String())
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.FindEmailMessageBody: %s", res.Body.String())
+	}
+}
+
+func TestEmail_ListEmailMessage(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+	testData := viper.GetStringMapString("test-data.email.email-message.interface-layer.features.list.request")
+
+	// client request
+	q := make(url.Values)
+	q.Set("templateCode", testData["template-code"])
+	q.Set("status", testData["status"])
+	q.Set("limit", "10")
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/email/messages?"+q.Encode(), nil)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.ListEmailMessage(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.NotContains(t, res.Body.String(), `"body"`)
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-message.interface-layer.features.list.response.json", res.Body.String())
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.ListEmailMessage: %s", res.Body.String())
+	}
+}
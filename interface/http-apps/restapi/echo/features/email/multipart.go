@@ -0,0 +1,160 @@
+package email
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	appDeliveryDTOMessage "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/message"
+	appConfig "github.com/d3ta-go/ms-email-restapi/system/config"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// multipartPayloadField represent the form field name of the JSON payload part
+	multipartPayloadField = "payload"
+	// maxPayloadSize represent maximum size of the JSON payload part
+	maxPayloadSize int64 = 1 << 20
+)
+
+// isMultipartRequest check whether the request is multipart/form-data
+func isMultipartRequest(c echo.Context) bool {
+	mt, _, err := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	return err == nil && mt == echo.MIMEMultipartForm
+}
+
+// bindMultipartMessage bind multipart/form-data request: a JSON `payload` part plus any number of file parts (attachments).
+// Files bigger than spoolThreshold are spooled to dirLocations.temp, the caller owns (and must remove) the spooled files.
+func (f *FEmail) bindMultipartMessage(c echo.Context, req *appDeliveryDTOMessage.SendMessageReqDTO) error {
+	cfg, err := appConfig.GetConfig(f.GetHandler())
+	if err != nil {
+		return err
+	}
+	limits := cfg.Delivery.Attachments
+
+	mr, err := c.Request().MultipartReader()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid multipart/form-data request: "+err.Error())
+	}
+
+	hasPayload := false
+	var total int64
+
+	// uploaded files are added after the attachments (if any) of the json payload,
+	// also on error, so the caller can remove the spooled files
+	var files []*appDeliveryDTOMessage.AttachmentDTO
+	defer func() {
+		req.Attachments = append(req.Attachments, files...)
+	}()
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid multipart/form-data request: "+err.Error())
+		}
+
+		if part.FileName() == "" {
+			if part.FormName() == multipartPayloadField {
+				if err := json.NewDecoder(io.LimitReader(part, maxPayloadSize)).Decode(req); err != nil {
+					part.Close()
+					return echo.NewHTTPError(http.StatusBadRequest, "Invalid `payload` (json): "+err.Error())
+				}
+				hasPayload = true
+			}
+			part.Close()
+			continue
+		}
+
+		att, err := f.readAttachmentPart(part, &limits, cfg.DirLocations.Temp)
+		part.Close()
+		if att != nil {
+			files = append(files, att)
+		}
+		if err != nil {
+			return err
+		}
+
+		total += att.Size
+		if total > limits.GetMaxTotalSize() {
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge,
+				fmt.Sprintf("Total attachments size exceeds the maximum of %d bytes", limits.GetMaxTotalSize()))
+		}
+	}
+
+	if !hasPayload {
+		return echo.NewHTTPError(http.StatusBadRequest, "Missing `payload` (json) part")
+	}
+
+	return nil
+}
+
+// readAttachmentPart read one file part, small files are kept in memory and bigger files are spooled into tempDir
+func (f *FEmail) readAttachmentPart(part *multipart.Part, limits *appConfig.Attachments, tempDir string) (*appDeliveryDTOMessage.AttachmentDTO, error) {
+	att := &appDeliveryDTOMessage.AttachmentDTO{
+		Filename:    filepath.Base(part.FileName()),
+		ContentType: attachmentContentType(part.Header.Get("Content-Type"), part.FileName()),
+	}
+
+	maxFileSize := limits.GetMaxFileSize()
+	tooLarge := echo.NewHTTPError(http.StatusRequestEntityTooLarge,
+		fmt.Sprintf("Attachment `%s` exceeds the maximum size of %d bytes", att.Filename, maxFileSize))
+
+	// keep in memory up to spoolThreshold
+	buf := new(bytes.Buffer)
+	n, err := io.CopyN(buf, part, limits.GetSpoolThreshold()+1)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if n > maxFileSize {
+		return nil, tooLarge
+	}
+	if n <= limits.GetSpoolThreshold() {
+		att.Data = buf.Bytes()
+		att.Size = n
+		return att, nil
+	}
+
+	// spool to temp dir
+	if err := os.MkdirAll(tempDir, 0755); err != nil {
+		return nil, err
+	}
+	tmp, err := ioutil.TempFile(tempDir, "attachment-*")
+	if err != nil {
+		return nil, err
+	}
+	defer tmp.Close()
+	att.FilePath = tmp.Name()
+
+	written, err := io.Copy(tmp, io.MultiReader(buf, io.LimitReader(part, maxFileSize-n+1)))
+	if err != nil {
+		return att, err
+	}
+	att.Size = written
+	if written > maxFileSize {
+		return att, tooLarge
+	}
+
+	return att, nil
+}
+
+// attachmentContentType get attachment media type (without parameters), detected from file extension when it is not specific
+func attachmentContentType(ct, filename string) string {
+	mt, _, err := mime.ParseMediaType(ct)
+	if err != nil || mt == "" || mt == "application/octet-stream" {
+		if byExt, _, err := mime.ParseMediaType(mime.TypeByExtension(strings.ToLower(filepath.Ext(filename)))); err == nil {
+			return byExt
+		}
+		return "application/octet-stream"
+	}
+	return mt
+}
@@ -0,0 +1,143 @@
+package email
+
+import (
+	"net/http"
+	"strconv"
+
+	appDeliveryDTOSchedule "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/schedule"
+	domSchemaSchedule "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/schedule"
+	"github.com/d3ta-go/system/interface/http-apps/restapi/echo/response"
+	"github.com/labstack/echo/v4"
+)
+
+// ListEmailSchedule list recurring email Schedules
+func (f *FEmail) ListEmailSchedule(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOSchedule.RSListReqDTO)
+	req.Status = c.QueryParam("status")
+	req.TemplateCode = c.QueryParam("templateCode")
+
+	resp, err := f.appDelivery.ScheduleSvc.List(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// CreateEmailSchedule create recurring email Schedule
+func (f *FEmail) CreateEmailSchedule(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	req := new(appDeliveryDTOSchedule.RSCreateReqDTO)
+	if err := c.Bind(req); err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	resp, err := f.appDelivery.ScheduleSvc.Create(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// PauseEmailSchedule pause recurring email Schedule
+func (f *FEmail) PauseEmailSchedule(c echo.Context) error {
+	return f.setEmailScheduleStatus(c, domSchemaSchedule.PausedStatus)
+}
+
+// ResumeEmailSchedule resume (paused) recurring email Schedule
+func (f *FEmail) ResumeEmailSchedule(c echo.Context) error {
+	return f.setEmailScheduleStatus(c, domSchemaSchedule.ActiveStatus)
+}
+
+func (f *FEmail) setEmailScheduleStatus(c echo.Context, status domSchemaSchedule.Status) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOSchedule.RSSetStatusReqDTO)
+	req.ID = c.Param("id")
+	req.Status = string(status)
+
+	resp, err := f.appDelivery.ScheduleSvc.SetStatus(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// DeleteEmailSchedule delete recurring email Schedule
+func (f *FEmail) DeleteEmailSchedule(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOSchedule.RSDeleteReqDTO)
+	req.ID = c.Param("id")
+
+	resp, err := f.appDelivery.ScheduleSvc.Delete(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// ListEmailScheduleRun list run history of recurring email Schedule
+func (f *FEmail) ListEmailScheduleRun(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOSchedule.RSListRunReqDTO)
+	req.ID = c.Param("id")
+	if limit := c.QueryParam("limit"); limit != "" {
+		if req.Limit, err = strconv.Atoi(limit); err != nil {
+			return response.FailWithMessageWithCode(http.StatusBadRequest, "Invalid limit", c)
+		}
+	}
+
+	resp, err := f.appDelivery.ScheduleSvc.ListRun(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
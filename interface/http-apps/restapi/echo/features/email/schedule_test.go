@@ -0,0 +1,387 @@
+package email
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ht "github.com/d3ta-go/ms-email-restapi/interface/http-apps/restapi/echo/features/helper_test"
+	"github.com/d3ta-go/system/system/initialize"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmail_CreateEmailSchedule(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+	testData := viper.GetStringMapString("test-data.email.email-schedule.interface-layer.features.create.request")
+	testDataET := viper.GetStringMapString("test-data.email.email-schedule.interface-layer.features.create.request.email-template-data")
+
+	// client request
+	reqDTO := `{
+    "name": "` + testData["name"] + `",
+    "cronExpression": "` + testData["cron-expression"] + `",
+    "timezone": "` + testData["timezone"] + `",
+    "templateCode": "` + testData["email-template-code"] + `",
+    "from": { "email": "` + testData["from-email"] + `", "name": "` + testData["from-name"] + `" },
+    "to": { "email": "` + testData["to-email"] + `", "name": "` + testData["to-name"] + `" },
+    "templateData": {
+		"Header.Name": "` + testDataET["header-name"] + `",
+		"Body.UserAccount": "` + testDataET["body-user-account"] + `",
+		"Body.Activation/api/v1/email/schedules": "` + testDataET["body-activation-url"] + `",
+        "Footer.Name": "` + testDataET["footer-name"] + `"
+	}
+}`
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/email/schedules", strings.NewReader(reqDTO))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.CreateEmailSchedule(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-schedule.interface-layer.features.create.response.json", res.Body.String())
+
+		// schedule id for next tests
+		var resJSON struct {
+			Response struct {
+				Result struct {
+					ID string `json:"id"`
+				} `json:"result"`
+			} `json:"response"`
+		}
+		if err := json.Unmarshal(res.Body.Bytes(), &resJSON); err != nil {
+			t.Errorf("json.Unmarshal: %s", err.Error())
+		}
+		for _, key := range []string{"pause", "resume", "list-run", "delete"} {
+			viper.Set(fmt.Sprintf("test-data.email.email-schedule.interface-layer.features.%s.request.rs-id", key), resJSON.Response.Result.ID)
+		}
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.CreateEmailSchedule: %s", res.Body.String())
+	}
+}
+
+func TestEmail_ListEmailSchedule(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+
+	// client request
+	// --> set on context param [http method = GET]
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/email/schedules", nil)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.ListEmailSchedule(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-schedule.interface-layer.features.list.response.json", res.Body.String())
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.ListEmailSchedule: %s", res.Body.String())
+	}
+}
+
+func TestEmail_PauseEmailSchedule(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+	testData := viper.GetStringMapString("test-data.email.email-schedule.interface-layer.features.pause.request")
+
+	// client request
+	// --> set on context param [http method = PUT]
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/email/schedules/:id/pause", nil)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+	c.SetParamNames("id")
+	c.SetParamValues(testData["rs-id"])
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.PauseEmailSchedule(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-schedule.interface-layer.features.pause.response.json", res.Body.String())
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.PauseEmailSchedule: %s", res.Body.String())
+	}
+}
+
+func TestEmail_ResumeEmailSchedule(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+	testData := viper.GetStringMapString("test-data.email.email-schedule.interface-layer.features.resume.request")
+
+	// client request
+	// --> set on context param [http method = PUT]
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/email/schedules/:id/resume", nil)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+	c.SetParamNames("id")
+	c.SetParamValues(testData["rs-id"])
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.ResumeEmailSchedule(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-schedule.interface-layer.features.resume.response.json", res.Body.String())
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.ResumeEmailSchedule: %s", res.Body.String())
+	}
+}
+
+func TestEmail_ListEmailScheduleRun(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+	testData := viper.GetStringMapString("test-data.email.email-schedule.interface-layer.features.list-run.request")
+
+	// client request
+	// --> set on context param [http method = GET]
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/email/schedules/:id/runs", nil)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+	c.SetParamNames("id")
+	c.SetParamValues(testData["rs-id"])
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.ListEmailScheduleRun(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-schedule.interface-layer.features.list-run.response.json", res.Body.String())
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.ListEmailScheduleRun: %s", res.Body.String())
+	}
+}
+
+func TestEmail_DeleteEmailSchedule(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+	testData := viper.GetStringMapString("test-data.email.email-schedule.interface-layer.features.delete.request")
+
+	// client request
+	// --> set on context param [http method = DELETE]
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/email/schedules/:id", nil)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+	c.SetParamNames("id")
+	c.SetParamValues(testData["rs-id"])
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.DeleteEmailSchedule(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-schedule.interface-layer.features.delete.response.json", res.Body.String())
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.DeleteEmailSchedule: %s", res.Body.String())
+	}
+}
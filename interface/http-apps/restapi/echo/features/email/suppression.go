@@ -0,0 +1,120 @@
+package email
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	appDeliveryDTOSuppression "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/suppression"
+	"github.com/d3ta-go/system/interface/http-apps/restapi/echo/response"
+	"github.com/labstack/echo/v4"
+)
+
+// ListEmailSuppression list suppressed addresses (with cursor pagination)
+func (f *FEmail) ListEmailSuppression(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOSuppression.SPListReqDTO)
+	req.Email = c.QueryParam("email")
+	req.Reason = c.QueryParam("reason")
+	req.Cursor = c.QueryParam("cursor")
+	if limit := c.QueryParam("limit"); limit != "" {
+		if req.Limit, err = strconv.Atoi(limit); err != nil {
+			return response.FailWithMessageWithCode(http.StatusBadRequest, "Invalid limit", c)
+		}
+	}
+
+	resp, err := f.appDelivery.SuppressionSvc.List(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// AddEmailSuppression add address to the suppression list
+func (f *FEmail) AddEmailSuppression(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	req := new(appDeliveryDTOSuppression.SPAddReqDTO)
+	if err := c.Bind(req); err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	resp, err := f.appDelivery.SuppressionSvc.Add(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// CheckEmailSuppression check whether an address is suppressed
+func (f *FEmail) CheckEmailSuppression(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOSuppression.SPCheckReqDTO)
+	req.Email = f.emailParam(c)
+
+	resp, err := f.appDelivery.SuppressionSvc.Check(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// RemoveEmailSuppression remove address from the suppression list
+func (f *FEmail) RemoveEmailSuppression(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOSuppression.SPRemoveReqDTO)
+	req.Email = f.emailParam(c)
+
+	resp, err := f.appDelivery.SuppressionSvc.Remove(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// emailParam get (percent-decoded) email address path param
+func (f *FEmail) emailParam(c echo.Context) string {
+	email := c.Param("email")
+	if v, err := url.PathUnescape(email); err == nil {
+		return v
+	}
+	return email
+}
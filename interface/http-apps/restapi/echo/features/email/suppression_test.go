@@ -0,0 +1,245 @@
+package email
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ht "github.com/d3ta-go/ms-email-restapi/interface/http-apps/restapi/echo/features/helper_test"
+	"github.com/d3ta-go/system/system/initialize"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmail_AddEmailSuppression(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+	testData := viper.GetStringMapString("test-data.email.email-suppression.interface-layer.features.add.request")
+
+	// client request
+	reqDTO := `{
+    "email": "` + testData["email"] + `",
+    "note": "` + testData["note"] + `"
+}`
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/email/suppressions", strings.NewReader(reqDTO))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.AddEmailSuppression(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Contains(t, res.Body.String(), `"reason":"MANUAL"`)
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-suppression.interface-layer.features.add.response.json", res.Body.String())
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.AddEmailSuppression: %s", res.Body.String())
+	}
+}
+
+func TestEmail_CheckEmailSuppression(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+	testData := viper.GetStringMapString("test-data.email.email-suppression.interface-layer.features.check.request")
+
+	// client request
+	// --> set on context param [http method = GET]
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/email/suppressions/:email", nil)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+	c.SetParamNames("email")
+	c.SetParamValues(testData["email"])
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.CheckEmailSuppression(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Contains(t, res.Body.String(), `"suppressed":true`)
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-suppression.interface-layer.features.check.response.json", res.Body.String())
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.CheckEmailSuppression: %s", res.Body.String())
+	}
+}
+
+func TestEmail_ListEmailSuppression(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+
+	// client request
+	// --> set on context param [http method = GET]
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/email/suppressions", nil)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.ListEmailSuppression(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-suppression.interface-layer.features.list.response.json", res.Body.String())
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.ListEmailSuppression: %s", res.Body.String())
+	}
+}
+
+func TestEmail_RemoveEmailSuppression(t *testing.T) {
+	h := ht.NewHandler()
+
+	viper, err := h.GetViper("test-data")
+	if err != nil {
+		t.Errorf("GetViper: %s", err.Error())
+	}
+	testData := viper.GetStringMapString("test-data.email.email-suppression.interface-layer.features.remove.request")
+
+	// client request
+	// --> set on context param [http method = DELETE]
+
+	// setup echo
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/email/suppressions/:email", nil)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	c := e.NewContext(req, res)
+	c.SetParamNames("email")
+	c.SetParamValues(testData["email"])
+
+	// handler
+	handler := ht.NewHandler()
+	if err := initialize.LoadAllDatabaseConnection(handler); err != nil {
+		t.Errorf("initialize.LoadAllDatabaseConnection: %s", err.Error())
+		return
+	}
+
+	// set identity (test only)
+	token, claims, err := ht.GenerateUserTestToken(handler, t)
+	if err != nil {
+		t.Errorf("generateUserTestToken: %s", err.Error())
+		return
+	}
+	c.Set("identity.token.jwt", token)
+	c.Set("identity.token.jwt.claims", claims)
+
+	// test feature
+	email, err := NewFEmail(handler)
+	if err != nil {
+		t.Errorf("NewFEmail: %s", err.Error())
+		return
+	}
+
+	if assert.NoError(t, email.RemoveEmailSuppression(c)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		// save to test-data
+		// save result for next test
+		viper.Set("test-data.email.email-suppression.interface-layer.features.remove.response.json", res.Body.String())
+		if err := viper.WriteConfig(); err != nil {
+			t.Errorf("Error: viper.WriteConfig(), %s", err.Error())
+		}
+		t.Logf("RESPONSE.Email.RemoveEmailSuppression: %s", res.Body.String())
+	}
+}
@@ -0,0 +1,106 @@
+package email
+
+import (
+	"net/http"
+
+	appDeliveryDTOTracking "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/tracking"
+	"github.com/d3ta-go/system/interface/http-apps/restapi/echo/response"
+	"github.com/labstack/echo/v4"
+)
+
+// transparentGIF 1x1 transparent gif (open tracking pixel)
+var transparentGIF = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// EmailOpen record an open of a message and serve the open tracking pixel (public)
+func (f *FEmail) EmailOpen(c echo.Context) error {
+	// identity (anonymous)
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	// params
+	req := new(appDeliveryDTOTracking.RecordOpenReqDTO)
+	req.Token = c.Param("token")
+
+	if _, err := f.appDelivery.TrackingSvc.RecordOpen(req, i); err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	// every open is recorded: not cached by mail clients and proxies
+	c.Response().Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	return c.Blob(http.StatusOK, "image/gif", transparentGIF)
+}
+
+// EmailClick record a click of a tracked link and redirect to the original url (public)
+func (f *FEmail) EmailClick(c echo.Context) error {
+	// identity (anonymous)
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	// params
+	req := new(appDeliveryDTOTracking.RecordClickReqDTO)
+	req.Token = c.Param("token")
+
+	resp, err := f.appDelivery.TrackingSvc.RecordClick(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	return c.Redirect(http.StatusFound, resp.URL)
+}
+
+// FindEmailMessageClicks find click counts (per link) of a message
+func (f *FEmail) FindEmailMessageClicks(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOTracking.MessageClicksReqDTO)
+	req.MessageID = c.Param("id")
+
+	resp, err := f.appDelivery.TrackingSvc.FindMessageClicks(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// FindEmailTemplateClicks find click counts (per link) of the messages of a template
+func (f *FEmail) FindEmailTemplateClicks(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOTracking.TemplateClicksReqDTO)
+	req.TemplateCode = c.Param("code")
+	req.Since = c.QueryParam("since")
+	req.Until = c.QueryParam("until")
+
+	resp, err := f.appDelivery.TrackingSvc.FindTemplateClicks(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
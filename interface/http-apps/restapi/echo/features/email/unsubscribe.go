@@ -0,0 +1,82 @@
+package email
+
+import (
+	"net/http"
+
+	appDeliveryDTOUnsubscribe "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/unsubscribe"
+	domSchemaUnsubscribe "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/unsubscribe"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/labstack/echo/v4"
+)
+
+// ConfirmEmailUnsubscribe render the unsubscribe confirmation page of a signed unsubscribe link (public)
+func (f *FEmail) ConfirmEmailUnsubscribe(c echo.Context) error {
+	// identity (anonymous)
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	// params
+	req := new(appDeliveryDTOUnsubscribe.UnsubscribeReqDTO)
+	req.Token = c.Param("token")
+
+	resp, err := f.appDelivery.UnsubscribeSvc.Find(req, i)
+	if err != nil {
+		return f.renderUnsubscribeError(err, c)
+	}
+
+	data := map[string]interface{}{
+		"email":        resp.Email,
+		"unsubscribed": resp.Unsubscribed,
+		"message":      "Unsubscribe " + resp.Email + " from our marketing emails?",
+	}
+	if resp.Unsubscribed {
+		data["message"] = resp.Email + " is already unsubscribed from our marketing emails."
+	}
+	return c.Render(http.StatusOK, "email/unsubscribe", data)
+}
+
+// EmailUnsubscribe record the opt-out of a signed unsubscribe link (public).
+// Accept both the confirmation page form and the RFC 8058 one-click POST (`List-Unsubscribe=One-Click`) sent by mail clients.
+func (f *FEmail) EmailUnsubscribe(c echo.Context) error {
+	// identity (anonymous)
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	// params
+	req := new(appDeliveryDTOUnsubscribe.UnsubscribeReqDTO)
+	req.Token = c.Param("token")
+	req.Method = string(domSchemaUnsubscribe.LinkMethod)
+	if c.FormValue("List-Unsubscribe") == "One-Click" {
+		req.Method = string(domSchemaUnsubscribe.OneClickMethod)
+	}
+
+	resp, err := f.appDelivery.UnsubscribeSvc.Unsubscribe(req, i)
+	if err != nil {
+		return f.renderUnsubscribeError(err, c)
+	}
+
+	data := map[string]interface{}{
+		"email":        resp.Email,
+		"unsubscribed": resp.Unsubscribed,
+		"message":      resp.Email + " has been unsubscribed from our marketing emails.",
+	}
+	return c.Render(http.StatusOK, "email/unsubscribe", data)
+}
+
+// renderUnsubscribeError render the unsubscribe page with the error message
+func (f *FEmail) renderUnsubscribeError(err error, c echo.Context) error {
+	sysErr, ok := err.(*sysError.SystemError)
+	if !ok || sysErr.StatusCode >= http.StatusInternalServerError {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	data := map[string]interface{}{
+		"unsubscribed": false,
+		"message":      sysErr.Err.Error(),
+	}
+	return c.Render(sysErr.StatusCode, "email/unsubscribe", data)
+}
@@ -0,0 +1,133 @@
+package email
+
+import (
+	"net/http"
+	"strconv"
+
+	appDeliveryDTOWebhook "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/webhook"
+	"github.com/d3ta-go/system/interface/http-apps/restapi/echo/response"
+	"github.com/labstack/echo/v4"
+)
+
+// ListEmailWebhook list webhook endpoints
+func (f *FEmail) ListEmailWebhook(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOWebhook.WHListReqDTO)
+	req.Event = c.QueryParam("event")
+
+	resp, err := f.appDelivery.WebhookSvc.List(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// CreateEmailWebhook register webhook endpoint
+func (f *FEmail) CreateEmailWebhook(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	req := new(appDeliveryDTOWebhook.WHCreateReqDTO)
+	if err := c.Bind(req); err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	resp, err := f.appDelivery.WebhookSvc.Create(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// DeleteEmailWebhook delete webhook endpoint
+func (f *FEmail) DeleteEmailWebhook(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOWebhook.WHDeleteReqDTO)
+	req.ID = c.Param("id")
+
+	resp, err := f.appDelivery.WebhookSvc.Delete(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// ListEmailWebhookDelivery list (latest) deliveries of webhook endpoint
+func (f *FEmail) ListEmailWebhookDelivery(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOWebhook.WHListDeliveryReqDTO)
+	req.ID = c.Param("id")
+	req.Status = c.QueryParam("status")
+	if limit := c.QueryParam("limit"); limit != "" {
+		if req.Limit, err = strconv.Atoi(limit); err != nil {
+			return response.FailWithMessageWithCode(http.StatusBadRequest, "Invalid limit", c)
+		}
+	}
+
+	resp, err := f.appDelivery.WebhookSvc.ListDelivery(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
+
+// ReplayEmailWebhookDelivery deliver the event of webhook delivery again
+func (f *FEmail) ReplayEmailWebhookDelivery(c echo.Context) error {
+	// identity
+	i, err := f.SetIdentity(c)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+	if !i.IsLogin || i.IsAnonymous {
+		return response.FailWithMessageWithCode(http.StatusForbidden, "Forbidden Access", c)
+	}
+
+	// params
+	req := new(appDeliveryDTOWebhook.WHReplayReqDTO)
+	req.ID = c.Param("id")
+	req.DeliveryID = c.Param("deliveryId")
+
+	resp, err := f.appDelivery.WebhookSvc.Replay(req, i)
+	if err != nil {
+		return f.TranslateErrorMessage(err, c)
+	}
+
+	return response.OKWithData(resp, c)
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	appConfig "github.com/d3ta-go/ms-email-restapi/system/config"
 	"github.com/d3ta-go/system/system/config"
 	"github.com/d3ta-go/system/system/handler"
 	"github.com/d3ta-go/system/system/identity"
@@ -29,6 +30,13 @@ func NewConfig(h *handler.Handler) (*config.Config, *viper.Viper, error) {
 
 	h.SetDefaultConfig(c)
 	h.SetViper("config", v)
+	appCfg, err := appConfig.LoadConfig(h)
+	if err != nil {
+		panic(err)
+	}
+	if appCfg.Delivery.Addresses.DisposableDomainsFile != "" {
+		appCfg.Delivery.Addresses.DisposableDomainsFile = "../../../../../../conf/disposable-domains.txt"
+	}
 
 	// viper for test-data
 	viperTest := viper.New()
@@ -13,6 +13,9 @@ func SetEmail(eg *echo.Group, f *email.FEmail) {
 	gc.Use(internalMiddleware.JWTVerifier(f.GetHandler()))
 
 	gc.POST("/send", f.SendEmail)
+	gc.POST("/send/preview", f.PreviewEmail)
+	gc.POST("/send/batch", f.SendBatchEmail)
+	gc.POST("/send/batch/csv", f.SendBatchCSVEmail)
 
 	gc.GET("/templates/list-all", f.ListAllEmailTemplate)
 	gc.GET("/template/:code", f.FindEmailTemplateByCode)
@@ -20,4 +23,45 @@ func SetEmail(eg *echo.Group, f *email.FEmail) {
 	gc.PUT("/template/update/:code", f.UpdateEmailTemplate)
 	gc.PUT("/template/set-active/:code", f.SetActiveEmailTemplate)
 	gc.DELETE("/template/:code", f.DeleteEmailTemplate)
+
+	gc.GET("/template/:code/assets", f.ListEmailTemplateAsset)
+	gc.POST("/template/:code/assets", f.AddEmailTemplateAsset)
+	gc.DELETE("/template/:code/assets/:contentId", f.DeleteEmailTemplateAsset)
+
+	gc.GET("/template/:code/settings", f.FindEmailTemplateSetting)
+	gc.PUT("/template/:code/settings", f.UpdateEmailTemplateSetting)
+	gc.GET("/template/:code/clicks", f.FindEmailTemplateClicks)
+
+	gc.GET("/schedules", f.ListEmailSchedule)
+	gc.POST("/schedules", f.CreateEmailSchedule)
+	gc.PUT("/schedules/:id/pause", f.PauseEmailSchedule)
+	gc.PUT("/schedules/:id/resume", f.ResumeEmailSchedule)
+	gc.DELETE("/schedules/:id", f.DeleteEmailSchedule)
+	gc.GET("/schedules/:id/runs", f.ListEmailScheduleRun)
+
+	gc.GET("/webhooks", f.ListEmailWebhook)
+	gc.POST("/webhooks", f.CreateEmailWebhook)
+	gc.DELETE("/webhooks/:id", f.DeleteEmailWebhook)
+	gc.GET("/webhooks/:id/deliveries", f.ListEmailWebhookDelivery)
+	gc.POST("/webhooks/:id/deliveries/:deliveryId/replay", f.ReplayEmailWebhookDelivery)
+
+	gc.POST("/bounces", f.IngestEmailBounce)
+
+	gc.GET("/suppressions", f.ListEmailSuppression)
+	gc.POST("/suppressions", f.AddEmailSuppression)
+	gc.GET("/suppressions/:email", f.CheckEmailSuppression)
+	gc.DELETE("/suppressions/:email", f.RemoveEmailSuppression)
+
+	gc.GET("/messages", f.ListEmailMessage)
+	gc.GET("/messages/:id", f.FindEmailMessage)
+	gc.GET("/messages/:id/body", f.FindEmailMessageBody)
+	gc.GET("/messages/:id/clicks", f.FindEmailMessageClicks)
+
+	// public (authorized by signed links, without JWT)
+	gp := eg.Group("/public/email")
+
+	gp.GET("/unsubscribe/:token", f.ConfirmEmailUnsubscribe)
+	gp.POST("/unsubscribe/:token", f.EmailUnsubscribe)
+	gp.GET("/open/:token", f.EmailOpen)
+	gp.GET("/click/:token", f.EmailClick)
 }
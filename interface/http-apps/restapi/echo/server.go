@@ -11,6 +11,8 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/gommon/log"
 
+	appDelivery "github.com/d3ta-go/ms-email-restapi/modules/delivery/application"
+	appConfig "github.com/d3ta-go/ms-email-restapi/system/config"
 	"github.com/d3ta-go/system/system/config"
 	"github.com/d3ta-go/system/system/handler"
 	"github.com/d3ta-go/system/system/initialize"
@@ -23,6 +25,10 @@ func initConfig(h *handler.Handler) (*config.Config, error) {
 		panic(err)
 	}
 	h.SetDefaultConfig(cfg)
+	h.SetViper("config", viper)
+	if _, err := appConfig.LoadConfig(h); err != nil {
+		return nil, err
+	}
 
 	viper.OnConfigChange(func(e fsnotify.Event) {
 		// fmt.Println("config file changed:", e.Name)
@@ -32,6 +38,9 @@ func initConfig(h *handler.Handler) (*config.Config, error) {
 		}
 
 		h.SetDefaultConfig(c)
+		if _, err := appConfig.LoadConfig(h); err != nil {
+			fmt.Println(err)
+		}
 		initializeSystems(h)
 	})
 
@@ -53,6 +62,31 @@ func initializeSystems(h *handler.Handler) error {
 	return nil
 }
 
+func startBackgroundWorkers(ctx context.Context, e *echo.Echo, h *handler.Handler) error {
+	cfg, err := appConfig.GetConfig(h)
+	if err != nil {
+		return err
+	}
+	// delivery runs in `server worker` processes only
+	if cfg.Delivery.Worker.Standalone {
+		return nil
+	}
+
+	// delivery: outbox (ASYNC and sendAt), delivery queue and recurring schedules (cron)
+	appDlv, err := appDelivery.NewDeliveryApp(h)
+	if err != nil {
+		return err
+	}
+	go appDlv.RunScheduler(ctx, func(err error) {
+		e.Logger.Errorf("Delivery scheduler: %s", err.Error())
+	})
+	go appDlv.RunQueueConsumer(ctx, func(err error) {
+		e.Logger.Errorf("Delivery queue consumer: %s", err.Error())
+	})
+
+	return nil
+}
+
 // StartRestAPIServer start RESTAPI Server
 func StartRestAPIServer() error {
 
@@ -88,6 +122,13 @@ func StartRestAPIServer() error {
 	// Set routers
 	SetRouters(e, superHandler)
 
+	// Start background workers (e.g: scheduled delivery)
+	ctxWorkers, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	if err := startBackgroundWorkers(ctxWorkers, e, superHandler); err != nil {
+		return err
+	}
+
 	// Start server with Graceful shutdown
 	httpPort := fmt.Sprintf(":%s", cfg.Applications.Servers.RestAPI.Options.Listener.Port)
 	go func() {
@@ -99,6 +140,7 @@ func StartRestAPIServer() error {
 	quit := make(chan os.Signal)
 	signal.Notify(quit, os.Interrupt)
 	<-quit
+	stopWorkers()
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	if err := e.Shutdown(ctx); err != nil {
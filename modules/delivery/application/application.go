@@ -0,0 +1,62 @@
+package application
+
+import (
+	appSvc "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/service"
+	"github.com/d3ta-go/system/system/handler"
+)
+
+// NewDeliveryApp new DeliveryApp
+func NewDeliveryApp(h *handler.Handler) (*DeliveryApp, error) {
+	var err error
+
+	app := new(DeliveryApp)
+	app.handler = h
+
+	if app.MessageSvc, err = appSvc.NewMessageService(h); err != nil {
+		return nil, err
+	}
+	if app.BatchSvc, err = appSvc.NewBatchService(h); err != nil {
+		return nil, err
+	}
+	if app.TemplateAssetSvc, err = appSvc.NewTemplateAssetService(h); err != nil {
+		return nil, err
+	}
+	if app.ScheduleSvc, err = appSvc.NewScheduleService(h); err != nil {
+		return nil, err
+	}
+	if app.WebhookSvc, err = appSvc.NewWebhookService(h); err != nil {
+		return nil, err
+	}
+	if app.BounceSvc, err = appSvc.NewBounceService(h); err != nil {
+		return nil, err
+	}
+	if app.SuppressionSvc, err = appSvc.NewSuppressionService(h); err != nil {
+		return nil, err
+	}
+	if app.TemplateSettingSvc, err = appSvc.NewTemplateSettingService(h); err != nil {
+		return nil, err
+	}
+	if app.UnsubscribeSvc, err = appSvc.NewUnsubscribeService(h); err != nil {
+		return nil, err
+	}
+	if app.TrackingSvc, err = appSvc.NewTrackingService(h); err != nil {
+		return nil, err
+	}
+
+	return app, nil
+}
+
+// DeliveryApp represent DDD Module: Delivery (Application Layer)
+type DeliveryApp struct {
+	handler            *handler.Handler
+	MessageSvc         *appSvc.MessageService
+	BatchSvc           *appSvc.BatchService
+	TemplateAssetSvc   *appSvc.TemplateAssetService
+	ScheduleSvc        *appSvc.ScheduleService
+	WebhookSvc         *appSvc.WebhookService
+	BounceSvc          *appSvc.BounceService
+	SuppressionSvc     *appSvc.SuppressionService
+	TemplateSettingSvc *appSvc.TemplateSettingService
+	UnsubscribeSvc     *appSvc.UnsubscribeService
+	TrackingSvc        *appSvc.TrackingService
+}
@@ -0,0 +1,94 @@
+package batch
+
+import (
+	"encoding/json"
+	"io"
+
+	appEmailDTO "github.com/d3ta-go/ddd-mod-email/modules/email/application/dto/email"
+	domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/batch"
+)
+
+// SendBatchReqDTO type
+type SendBatchReqDTO struct {
+	TemplateCode   string                      `json:"templateCode"`
+	From           *appEmailDTO.MailAddressDTO `json:"from"`
+	Recipients     []*BatchRecipientDTO        `json:"recipients"`
+	ProcessingType string                      `json:"processingType"`
+}
+
+// BatchRecipientDTO type
+type BatchRecipientDTO struct {
+	To           *appEmailDTO.MailAddressDTO   `json:"to"`
+	CC           []*appEmailDTO.MailAddressDTO `json:"cc"`
+	BCC          []*appEmailDTO.MailAddressDTO `json:"bcc"`
+	TemplateData map[string]interface{}        `json:"templateData"`
+}
+
+// ConvertFrom2Domain convert to domSchema
+func (r *SendBatchReqDTO) ConvertFrom2Domain() *domSchemaEmail.MailAddress {
+	if r.From == nil {
+		return nil
+	}
+	return &domSchemaEmail.MailAddress{Email: r.From.Email, Name: r.From.Name}
+}
+
+// ConvertRecipients2Domain convert to domSchema
+func (r *SendBatchReqDTO) ConvertRecipients2Domain() []*domSchema.BatchRecipient {
+	var rs []*domSchema.BatchRecipient
+	for _, v := range r.Recipients {
+		if v == nil {
+			// keep the position, so results still match the request index
+			rs = append(rs, nil)
+			continue
+		}
+		rcpt := &domSchema.BatchRecipient{TemplateData: v.TemplateData}
+		if v.To != nil {
+			rcpt.To = &domSchemaEmail.MailAddress{Email: v.To.Email, Name: v.To.Name}
+		}
+		for _, cc := range v.CC {
+			if cc == nil {
+				continue
+			}
+			rcpt.CC = append(rcpt.CC, &domSchemaEmail.MailAddress{Email: cc.Email, Name: cc.Name})
+		}
+		for _, bcc := range v.BCC {
+			if bcc == nil {
+				continue
+			}
+			rcpt.BCC = append(rcpt.BCC, &domSchemaEmail.MailAddress{Email: bcc.Email, Name: bcc.Name})
+		}
+		rs = append(rs, rcpt)
+	}
+	return rs
+}
+
+// SendBatchCSVReqDTO type
+type SendBatchCSVReqDTO struct {
+	TemplateCode   string                      `json:"templateCode"`
+	From           *appEmailDTO.MailAddressDTO `json:"from"`
+	ProcessingType string                      `json:"processingType"`
+	CSVFile        io.Reader                   `json:"-"`
+}
+
+// ConvertFrom2Domain convert to domSchema
+func (r *SendBatchCSVReqDTO) ConvertFrom2Domain() *domSchemaEmail.MailAddress {
+	if r.From == nil {
+		return nil
+	}
+	return &domSchemaEmail.MailAddress{Email: r.From.Email, Name: r.From.Name}
+}
+
+// SendBatchResDTO type
+type SendBatchResDTO struct {
+	domSchema.SendBatchResponse
+}
+
+// ToJSON covert to JSON
+func (r *SendBatchReqDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,27 @@
+package bounce
+
+import (
+	"encoding/json"
+	"io"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/bounce"
+)
+
+// IngestBounceReqDTO type
+type IngestBounceReqDTO struct {
+	Report io.Reader `json:"-"` // raw (RFC 5322) multipart/report message
+}
+
+// IngestBounceResDTO type
+type IngestBounceResDTO struct {
+	domSchema.IngestBounceResponse
+}
+
+// ToJSON covert to JSON
+func (r *IngestBounceResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
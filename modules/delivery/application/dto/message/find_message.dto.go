@@ -0,0 +1,26 @@
+package message
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+)
+
+// FindMessageReqDTO type
+type FindMessageReqDTO struct {
+	domSchema.FindMessageRequest
+}
+
+// FindMessageResDTO type
+type FindMessageResDTO struct {
+	domSchema.FindMessageResponse
+}
+
+// ToJSON covert to JSON
+func (r *FindMessageResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,26 @@
+package message
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+)
+
+// FindMessageBodyReqDTO type
+type FindMessageBodyReqDTO struct {
+	domSchema.FindMessageBodyRequest
+}
+
+// FindMessageBodyResDTO type
+type FindMessageBodyResDTO struct {
+	domSchema.FindMessageBodyResponse
+}
+
+// ToJSON covert to JSON
+func (r *FindMessageBodyResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
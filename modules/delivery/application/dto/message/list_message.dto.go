@@ -0,0 +1,26 @@
+package message
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+)
+
+// ListMessageReqDTO type
+type ListMessageReqDTO struct {
+	domSchema.ListMessageRequest
+}
+
+// ListMessageResDTO type
+type ListMessageResDTO struct {
+	domSchema.ListMessageResponse
+}
+
+// ToJSON covert to JSON
+func (r *ListMessageResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,21 @@
+package message
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+)
+
+// PreviewMessageResDTO type
+type PreviewMessageResDTO struct {
+	domSchema.PreviewMessageResponse
+}
+
+// ToJSON covert to JSON
+func (r *PreviewMessageResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
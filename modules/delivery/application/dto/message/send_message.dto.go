@@ -0,0 +1,167 @@
+package message
+
+import (
+	"encoding/json"
+	"os"
+
+	appEmailDTO "github.com/d3ta-go/ddd-mod-email/modules/email/application/dto/email"
+	domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+)
+
+// SendMessageReqDTO type
+type SendMessageReqDTO struct {
+	TemplateCode   string                        `json:"templateCode"`
+	From           *appEmailDTO.MailAddressDTO   `json:"from"`
+	To             *appEmailDTO.MailAddressDTO   `json:"to"`
+	CC             []*appEmailDTO.MailAddressDTO `json:"cc"`
+	BCC            []*appEmailDTO.MailAddressDTO `json:"bcc"`
+	ReplyTo        *appEmailDTO.MailAddressDTO   `json:"replyTo"`
+	Headers        map[string]string             `json:"headers"`
+	Priority       string                        `json:"priority"`
+	TemplateData   map[string]interface{}        `json:"templateData"`
+	ProcessingType string                        `json:"processingType"`
+	Attachments    []*AttachmentDTO              `json:"attachments"`
+	SendAt         string                        `json:"sendAt"`
+	TrackOpens     *bool                         `json:"trackOpens"`
+	CalendarEvent  *CalendarEventDTO             `json:"calendarEvent"`
+
+	// IdempotencyKey Idempotency-Key header (optional)
+	IdempotencyKey string `json:"-"`
+}
+
+// AttachmentDTO type
+type AttachmentDTO struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Content     string `json:"content"` // base64 encoded content
+	ContentID   string `json:"contentId,omitempty"`
+
+	// uploaded file (multipart/form-data), held in memory (Data) or spooled into a temporary file (FilePath)
+	Size     int64  `json:"-"`
+	Data     []byte `json:"-"`
+	FilePath string `json:"-"`
+}
+
+// CalendarEventDTO type
+type CalendarEventDTO struct {
+	UID         string                        `json:"uid"`
+	Method      string                        `json:"method"`
+	Sequence    int                           `json:"sequence"`
+	Summary     string                        `json:"summary"`
+	Description string                        `json:"description"`
+	Start       string                        `json:"start"`
+	End         string                        `json:"end"`
+	Timezone    string                        `json:"timezone"`
+	Location    string                        `json:"location"`
+	Organizer   *appEmailDTO.MailAddressDTO   `json:"organizer"`
+	Attendees   []*appEmailDTO.MailAddressDTO `json:"attendees"`
+}
+
+// ConvertFrom2Domain convert to domSchema
+func (r *SendMessageReqDTO) ConvertFrom2Domain() *domSchemaEmail.MailAddress {
+	return r.convertAddress2Domain(r.From)
+}
+
+// ConvertTo2Domain convert to domSchema
+func (r *SendMessageReqDTO) ConvertTo2Domain() *domSchemaEmail.MailAddress {
+	return r.convertAddress2Domain(r.To)
+}
+
+// ConvertReplyTo2Domain convert to domSchema
+func (r *SendMessageReqDTO) ConvertReplyTo2Domain() *domSchemaEmail.MailAddress {
+	return r.convertAddress2Domain(r.ReplyTo)
+}
+
+// ConvertCC2Domain convert to domSchema
+func (r *SendMessageReqDTO) ConvertCC2Domain() []*domSchemaEmail.MailAddress {
+	return r.convertAddresses2Domain(r.CC)
+}
+
+// ConvertBCC2Domain convert to domSchema
+func (r *SendMessageReqDTO) ConvertBCC2Domain() []*domSchemaEmail.MailAddress {
+	return r.convertAddresses2Domain(r.BCC)
+}
+
+// ConvertAttachments2Domain convert to domSchema
+func (r *SendMessageReqDTO) ConvertAttachments2Domain() []*domSchema.Attachment {
+	var as []*domSchema.Attachment
+	for _, v := range r.Attachments {
+		if v == nil {
+			continue
+		}
+		as = append(as, &domSchema.Attachment{
+			Filename:       v.Filename,
+			ContentType:    v.ContentType,
+			ContentID:      v.ContentID,
+			EncodedContent: v.Content,
+			Content:        v.Data,
+			FilePath:       v.FilePath,
+		})
+	}
+	return as
+}
+
+// ConvertCalendarEvent2Domain convert to domSchema
+func (r *SendMessageReqDTO) ConvertCalendarEvent2Domain() *domSchema.CalendarEvent {
+	if r.CalendarEvent == nil {
+		return nil
+	}
+	return &domSchema.CalendarEvent{
+		UID:         r.CalendarEvent.UID,
+		Method:      r.CalendarEvent.Method,
+		Sequence:    r.CalendarEvent.Sequence,
+		Summary:     r.CalendarEvent.Summary,
+		Description: r.CalendarEvent.Description,
+		Start:       r.CalendarEvent.Start,
+		End:         r.CalendarEvent.End,
+		Timezone:    r.CalendarEvent.Timezone,
+		Location:    r.CalendarEvent.Location,
+		Organizer:   r.convertAddress2Domain(r.CalendarEvent.Organizer),
+		Attendees:   r.convertAddresses2Domain(r.CalendarEvent.Attendees),
+	}
+}
+
+// RemoveSpooledAttachments remove spooled attachment files (if any)
+func (r *SendMessageReqDTO) RemoveSpooledAttachments() {
+	for _, v := range r.Attachments {
+		if v != nil && v.FilePath != "" {
+			os.Remove(v.FilePath)
+		}
+	}
+}
+
+func (r *SendMessageReqDTO) convertAddress2Domain(m *appEmailDTO.MailAddressDTO) *domSchemaEmail.MailAddress {
+	if m == nil {
+		return nil
+	}
+	return &domSchemaEmail.MailAddress{Email: m.Email, Name: m.Name}
+}
+
+func (r *SendMessageReqDTO) convertAddresses2Domain(ms []*appEmailDTO.MailAddressDTO) []*domSchemaEmail.MailAddress {
+	var ds []*domSchemaEmail.MailAddress
+	for _, m := range ms {
+		if m == nil {
+			continue
+		}
+		ds = append(ds, r.convertAddress2Domain(m))
+	}
+	return ds
+}
+
+// ToJSON covert to JSON
+func (r *SendMessageReqDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
+
+// SendMessageResDTO type
+type SendMessageResDTO struct {
+	domSchema.SendMessageResponse
+
+	// Replayed the original response of the Idempotency-Key is returned (the message is not sent again)
+	Replayed bool `json:"-"`
+}
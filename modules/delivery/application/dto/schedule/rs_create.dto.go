@@ -0,0 +1,26 @@
+package schedule
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/schedule"
+)
+
+// RSCreateReqDTO type
+type RSCreateReqDTO struct {
+	domSchema.RSCreateRequest
+}
+
+// RSCreateResDTO type
+type RSCreateResDTO struct {
+	domSchema.RSCreateResponse
+}
+
+// ToJSON covert to JSON
+func (r *RSCreateResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
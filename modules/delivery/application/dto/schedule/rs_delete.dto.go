@@ -0,0 +1,26 @@
+package schedule
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/schedule"
+)
+
+// RSDeleteReqDTO type
+type RSDeleteReqDTO struct {
+	domSchema.RSDeleteRequest
+}
+
+// RSDeleteResDTO type
+type RSDeleteResDTO struct {
+	domSchema.RSDeleteResponse
+}
+
+// ToJSON covert to JSON
+func (r *RSDeleteResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,26 @@
+package schedule
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/schedule"
+)
+
+// RSListReqDTO type
+type RSListReqDTO struct {
+	domSchema.RSListRequest
+}
+
+// RSListResDTO type
+type RSListResDTO struct {
+	domSchema.RSListResponse
+}
+
+// ToJSON covert to JSON
+func (r *RSListResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,26 @@
+package schedule
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/schedule"
+)
+
+// RSListRunReqDTO type
+type RSListRunReqDTO struct {
+	domSchema.RSListRunRequest
+}
+
+// RSListRunResDTO type
+type RSListRunResDTO struct {
+	domSchema.RSListRunResponse
+}
+
+// ToJSON covert to JSON
+func (r *RSListRunResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
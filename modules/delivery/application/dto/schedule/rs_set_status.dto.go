@@ -0,0 +1,26 @@
+package schedule
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/schedule"
+)
+
+// RSSetStatusReqDTO type
+type RSSetStatusReqDTO struct {
+	domSchema.RSSetStatusRequest
+}
+
+// RSSetStatusResDTO type
+type RSSetStatusResDTO struct {
+	domSchema.RSSetStatusResponse
+}
+
+// ToJSON covert to JSON
+func (r *RSSetStatusResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,26 @@
+package suppression
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/suppression"
+)
+
+// SPAddReqDTO type
+type SPAddReqDTO struct {
+	domSchema.SPAddRequest
+}
+
+// SPAddResDTO type
+type SPAddResDTO struct {
+	domSchema.SPAddResponse
+}
+
+// ToJSON covert to JSON
+func (r *SPAddResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,26 @@
+package suppression
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/suppression"
+)
+
+// SPCheckReqDTO type
+type SPCheckReqDTO struct {
+	domSchema.SPCheckRequest
+}
+
+// SPCheckResDTO type
+type SPCheckResDTO struct {
+	domSchema.SPCheckResponse
+}
+
+// ToJSON covert to JSON
+func (r *SPCheckResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,26 @@
+package suppression
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/suppression"
+)
+
+// SPListReqDTO type
+type SPListReqDTO struct {
+	domSchema.SPListRequest
+}
+
+// SPListResDTO type
+type SPListResDTO struct {
+	domSchema.SPListResponse
+}
+
+// ToJSON covert to JSON
+func (r *SPListResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,26 @@
+package suppression
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/suppression"
+)
+
+// SPRemoveReqDTO type
+type SPRemoveReqDTO struct {
+	domSchema.SPRemoveRequest
+}
+
+// SPRemoveResDTO type
+type SPRemoveResDTO struct {
+	domSchema.SPRemoveResponse
+}
+
+// ToJSON covert to JSON
+func (r *SPRemoveResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
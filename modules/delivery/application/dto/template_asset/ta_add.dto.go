@@ -0,0 +1,26 @@
+package templateasset
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/template_asset"
+)
+
+// TAAddReqDTO type
+type TAAddReqDTO struct {
+	domSchema.TAAddRequest
+}
+
+// TAAddResDTO type
+type TAAddResDTO struct {
+	domSchema.TAAddResponse
+}
+
+// ToJSON covert to JSON
+func (r *TAAddResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
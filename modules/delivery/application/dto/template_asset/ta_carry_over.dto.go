@@ -0,0 +1,26 @@
+package templateasset
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/template_asset"
+)
+
+// TACarryOverReqDTO type
+type TACarryOverReqDTO struct {
+	domSchema.TACarryOverRequest
+}
+
+// TACarryOverResDTO type
+type TACarryOverResDTO struct {
+	domSchema.TAListResponse
+}
+
+// ToJSON covert to JSON
+func (r *TACarryOverResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
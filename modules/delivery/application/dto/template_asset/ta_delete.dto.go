@@ -0,0 +1,26 @@
+package templateasset
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/template_asset"
+)
+
+// TADeleteReqDTO type
+type TADeleteReqDTO struct {
+	domSchema.TADeleteRequest
+}
+
+// TADeleteResDTO type
+type TADeleteResDTO struct {
+	domSchema.TADeleteResponse
+}
+
+// ToJSON covert to JSON
+func (r *TADeleteResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
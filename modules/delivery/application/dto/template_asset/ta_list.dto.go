@@ -0,0 +1,26 @@
+package templateasset
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/template_asset"
+)
+
+// TAListReqDTO type
+type TAListReqDTO struct {
+	domSchema.TAListRequest
+}
+
+// TAListResDTO type
+type TAListResDTO struct {
+	domSchema.TAListResponse
+}
+
+// ToJSON covert to JSON
+func (r *TAListResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
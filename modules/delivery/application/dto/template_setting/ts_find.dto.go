@@ -0,0 +1,26 @@
+package templatesetting
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/template_setting"
+)
+
+// TSFindReqDTO type
+type TSFindReqDTO struct {
+	domSchema.TSFindRequest
+}
+
+// TSFindResDTO type
+type TSFindResDTO struct {
+	domSchema.TSFindResponse
+}
+
+// ToJSON covert to JSON
+func (r *TSFindResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,26 @@
+package templatesetting
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/template_setting"
+)
+
+// TSUpdateReqDTO type
+type TSUpdateReqDTO struct {
+	domSchema.TSUpdateRequest
+}
+
+// TSUpdateResDTO type
+type TSUpdateResDTO struct {
+	domSchema.TSUpdateResponse
+}
+
+// ToJSON covert to JSON
+func (r *TSUpdateResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,26 @@
+package tracking
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/tracking"
+)
+
+// MessageClicksReqDTO type
+type MessageClicksReqDTO struct {
+	domSchema.MessageClicksRequest
+}
+
+// MessageClicksResDTO type
+type MessageClicksResDTO struct {
+	domSchema.MessageClicksResponse
+}
+
+// ToJSON covert to JSON
+func (r *MessageClicksResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,26 @@
+package tracking
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/tracking"
+)
+
+// RecordClickReqDTO type
+type RecordClickReqDTO struct {
+	domSchema.RecordClickRequest
+}
+
+// RecordClickResDTO type
+type RecordClickResDTO struct {
+	domSchema.RecordClickResponse
+}
+
+// ToJSON covert to JSON
+func (r *RecordClickResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,26 @@
+package tracking
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/tracking"
+)
+
+// RecordOpenReqDTO type
+type RecordOpenReqDTO struct {
+	domSchema.RecordOpenRequest
+}
+
+// RecordOpenResDTO type
+type RecordOpenResDTO struct {
+	domSchema.RecordOpenResponse
+}
+
+// ToJSON covert to JSON
+func (r *RecordOpenResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
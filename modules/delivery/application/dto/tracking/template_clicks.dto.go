@@ -0,0 +1,26 @@
+package tracking
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/tracking"
+)
+
+// TemplateClicksReqDTO type
+type TemplateClicksReqDTO struct {
+	domSchema.TemplateClicksRequest
+}
+
+// TemplateClicksResDTO type
+type TemplateClicksResDTO struct {
+	domSchema.TemplateClicksResponse
+}
+
+// ToJSON covert to JSON
+func (r *TemplateClicksResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,26 @@
+package unsubscribe
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/unsubscribe"
+)
+
+// UnsubscribeReqDTO type
+type UnsubscribeReqDTO struct {
+	domSchema.UnsubscribeRequest
+}
+
+// UnsubscribeResDTO type
+type UnsubscribeResDTO struct {
+	domSchema.UnsubscribeResponse
+}
+
+// ToJSON covert to JSON
+func (r *UnsubscribeResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,26 @@
+package webhook
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/webhook"
+)
+
+// WHCreateReqDTO type
+type WHCreateReqDTO struct {
+	domSchema.WHCreateRequest
+}
+
+// WHCreateResDTO type
+type WHCreateResDTO struct {
+	domSchema.WHCreateResponse
+}
+
+// ToJSON covert to JSON
+func (r *WHCreateResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
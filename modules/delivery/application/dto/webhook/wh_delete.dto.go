@@ -0,0 +1,26 @@
+package webhook
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/webhook"
+)
+
+// WHDeleteReqDTO type
+type WHDeleteReqDTO struct {
+	domSchema.WHDeleteRequest
+}
+
+// WHDeleteResDTO type
+type WHDeleteResDTO struct {
+	domSchema.WHDeleteResponse
+}
+
+// ToJSON covert to JSON
+func (r *WHDeleteResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
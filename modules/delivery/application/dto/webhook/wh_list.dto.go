@@ -0,0 +1,26 @@
+package webhook
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/webhook"
+)
+
+// WHListReqDTO type
+type WHListReqDTO struct {
+	domSchema.WHListRequest
+}
+
+// WHListResDTO type
+type WHListResDTO struct {
+	domSchema.WHListResponse
+}
+
+// ToJSON covert to JSON
+func (r *WHListResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
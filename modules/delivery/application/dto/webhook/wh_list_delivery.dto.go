@@ -0,0 +1,26 @@
+package webhook
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/webhook"
+)
+
+// WHListDeliveryReqDTO type
+type WHListDeliveryReqDTO struct {
+	domSchema.WHListDeliveryRequest
+}
+
+// WHListDeliveryResDTO type
+type WHListDeliveryResDTO struct {
+	domSchema.WHListDeliveryResponse
+}
+
+// ToJSON covert to JSON
+func (r *WHListDeliveryResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,26 @@
+package webhook
+
+import (
+	"encoding/json"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/webhook"
+)
+
+// WHReplayReqDTO type
+type WHReplayReqDTO struct {
+	domSchema.WHReplayRequest
+}
+
+// WHReplayResDTO type
+type WHReplayResDTO struct {
+	domSchema.WHReplayResponse
+}
+
+// ToJSON covert to JSON
+func (r *WHReplayResDTO) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
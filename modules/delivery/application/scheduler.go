@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	appSvc "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/service"
+	appConfig "github.com/d3ta-go/ms-email-restapi/system/config"
+)
+
+// RunScheduler run outbox (ASYNC and sendAt), recurring schedule (cron) and webhook delivery dispatcher until the context is done.
+// Outbox messages and schedules are persisted, so pending messages are dispatched again after a restart.
+// Webhook deliveries (HTTP calls, up to the webhook timeout each) are dispatched in their own loop, they do not hold up the outbox.
+func (a *DeliveryApp) RunScheduler(ctx context.Context, onError func(err error)) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		a.runWebhookDispatcher(ctx, onError)
+	}()
+	defer wg.Wait()
+
+	for {
+		if _, err := a.MessageSvc.DispatchOutbox(); err != nil && onError != nil {
+			onError(err)
+		}
+		if _, err := a.ScheduleSvc.DispatchRecurring(); err != nil && onError != nil {
+			onError(err)
+		}
+		if _, err := a.MessageSvc.PurgeIdempotencyKeys(); err != nil && onError != nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(a.pollInterval()):
+		case <-appSvc.OutboxWakeUp():
+		}
+	}
+}
+
+// runWebhookDispatcher run webhook delivery dispatcher until the context is done
+func (a *DeliveryApp) runWebhookDispatcher(ctx context.Context, onError func(err error)) {
+	for {
+		if _, err := a.WebhookSvc.DispatchDeliveries(); err != nil && onError != nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(a.pollInterval()):
+		}
+	}
+}
+
+// pollInterval get scheduler poll interval (configuration)
+func (a *DeliveryApp) pollInterval() time.Duration {
+	if cfg, err := appConfig.GetConfig(a.handler); err == nil {
+		return cfg.Delivery.Scheduler.GetPollInterval()
+	}
+	return new(appConfig.Scheduler).GetPollInterval()
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"fmt"
+
+	appEmailDTO "github.com/d3ta-go/ddd-mod-email/modules/email/application/dto/email"
+	domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+	appDTO "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/batch"
+	appDTOMessage "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/message"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/batch"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// NewBatchService new BatchService
+func NewBatchService(h *handler.Handler) (*BatchService, error) {
+	var err error
+
+	svc := new(BatchService)
+	svc.handler = h
+	if err := svc.initBaseService(); err != nil {
+		return nil, err
+	}
+
+	if svc.messageSvc, err = NewMessageService(h); err != nil {
+		return nil, err
+	}
+
+	return svc, nil
+}
+
+// BatchService type
+type BatchService struct {
+	BaseService
+	messageSvc *MessageService
+}
+
+// Send send one Email Template to many recipients, each with its own template data
+func (s *BatchService) Send(req *appDTO.SendBatchReqDTO, i identity.Identity) (*appDTO.SendBatchResDTO, error) {
+	// authorization
+	if (i.CanAccessCurrentRequest() == false) && (i.CanAccess("", "system.module.delivery.batch.send", "EXECUTE", nil) == false) {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := domSchema.SendBatchRequest{
+		TemplateCode:   req.TemplateCode,
+		From:           req.ConvertFrom2Domain(),
+		Recipients:     req.ConvertRecipients2Domain(),
+		ProcessingType: req.ProcessingType,
+	}
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	// retrieve and parse the email template (default version) once, for all recipients
+	tpl, err := s.messageSvc.resolveTemplate(reqDom.TemplateCode)
+	if err != nil {
+		return nil, err
+	}
+
+	res := s.sendBatch(&reqDom, tpl, i)
+
+	// response dto
+	resDTO := new(appDTO.SendBatchResDTO)
+	resDTO.SendBatchResponse = *res
+
+	return resDTO, nil
+}
+
+// SendCSV send one Email Template to many recipients from mail merge csv file
+func (s *BatchService) SendCSV(req *appDTO.SendBatchCSVReqDTO, i identity.Identity) (*appDTO.SendBatchResDTO, error) {
+	// authorization
+	if (i.CanAccessCurrentRequest() == false) && (i.CanAccess("", "system.module.delivery.batch.send", "EXECUTE", nil) == false) {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := domSchema.SendBatchCSVRequest{
+		TemplateCode:   req.TemplateCode,
+		From:           req.ConvertFrom2Domain(),
+		ProcessingType: req.ProcessingType,
+	}
+	if req.CSVFile == nil {
+		return nil, validation.Errors{"file": fmt.Errorf("cannot be blank")}
+	}
+	if err := reqDom.ParseCSV(req.CSVFile); err != nil {
+		return nil, validation.Errors{"file": err}
+	}
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	// retrieve and parse the email template (default version) once, for all recipients
+	tpl, err := s.messageSvc.resolveTemplate(reqDom.TemplateCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// make sure the csv columns cover all email template variables, before sending anything
+	tplVer := tpl.template.DefaultTemplateVersion
+	tplVars, err := domSchema.TemplateVariables(tplVer.SubjectTpl, tplVer.BodyTpl, tpl.setting.GetTextBodyTpl(tplVer.ID))
+	if err != nil {
+		return nil, err
+	}
+	if err := reqDom.ValidateTemplateVariables(tplVars, tpl.setting.ProvidedTemplateData()); err != nil {
+		return nil, err
+	}
+
+	res := s.sendBatch(&domSchema.SendBatchRequest{
+		TemplateCode:   reqDom.TemplateCode,
+		From:           reqDom.From,
+		Recipients:     reqDom.Recipients,
+		ProcessingType: reqDom.ProcessingType,
+	}, tpl, i)
+
+	// response dto
+	resDTO := new(appDTO.SendBatchResDTO)
+	resDTO.SendBatchResponse = *res
+
+	return resDTO, nil
+}
+
+// sendBatch send to each recipient, one failure does not fail the whole batch
+func (s *BatchService) sendBatch(req *domSchema.SendBatchRequest, tpl *sendTemplate, i identity.Identity) *domSchema.SendBatchResponse {
+	res := &domSchema.SendBatchResponse{
+		TemplateCode: req.TemplateCode,
+		Total:        len(req.Recipients),
+	}
+	for idx, rcpt := range req.Recipients {
+		result := &domSchema.SendBatchResult{Index: idx}
+		if rcpt != nil && rcpt.To != nil {
+			result.To = rcpt.To.Email
+		}
+
+		resSend, err := s.sendRecipient(req, rcpt, tpl, i)
+		if err != nil {
+			result.Status = domSchema.FailedStatus
+			result.Error = err.Error()
+		} else {
+			result.MessageID = resSend.MessageID
+			result.Status = resSend.Status
+			result.Suppressed = resSend.Suppressed
+		}
+		res.Add(result)
+	}
+	return res
+}
+
+// sendRecipient send to one recipient (the batch is authorized, and its email template resolved, once)
+func (s *BatchService) sendRecipient(req *domSchema.SendBatchRequest, rcpt *domSchema.BatchRecipient, tpl *sendTemplate, i identity.Identity) (*appDTOMessage.SendMessageResDTO, error) {
+	if rcpt == nil {
+		return nil, fmt.Errorf("Empty recipient")
+	}
+	if err := rcpt.Validate(); err != nil {
+		return nil, err
+	}
+	return s.messageSvc.sendWith(s.toSendMessageReqDTO(req, rcpt), tpl, i)
+}
+
+func (s *BatchService) toSendMessageReqDTO(req *domSchema.SendBatchRequest, rcpt *domSchema.BatchRecipient) *appDTOMessage.SendMessageReqDTO {
+	r := &appDTOMessage.SendMessageReqDTO{
+		TemplateCode:   req.TemplateCode,
+		From:           s.toMailAddressDTO(req.From),
+		To:             s.toMailAddressDTO(rcpt.To),
+		CC:             s.toMailAddressDTOs(rcpt.CC),
+		BCC:            s.toMailAddressDTOs(rcpt.BCC),
+		TemplateData:   rcpt.TemplateData,
+		ProcessingType: req.ProcessingType,
+	}
+	return r
+}
+
+func (s *BatchService) toMailAddressDTO(m *domSchemaEmail.MailAddress) *appEmailDTO.MailAddressDTO {
+	if m == nil {
+		return nil
+	}
+	return &appEmailDTO.MailAddressDTO{Email: m.Email, Name: m.Name}
+}
+
+func (s *BatchService) toMailAddressDTOs(ms []*domSchemaEmail.MailAddress) []*appEmailDTO.MailAddressDTO {
+	var ds []*appEmailDTO.MailAddressDTO
+	for _, m := range ms {
+		ds = append(ds, s.toMailAddressDTO(m))
+	}
+	return ds
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"fmt"
+
+	appDTO "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/bounce"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/bounce"
+	infRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/repository"
+	"github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/service/dsn"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// NewBounceService new BounceService
+func NewBounceService(h *handler.Handler) (*BounceService, error) {
+	var err error
+
+	svc := new(BounceService)
+	svc.handler = h
+	if err := svc.initBaseService(); err != nil {
+		return nil, err
+	}
+
+	if svc.repo, err = infRepo.NewBounceRepo(h); err != nil {
+		return nil, err
+	}
+
+	return svc, nil
+}
+
+// BounceService type
+type BounceService struct {
+	BaseService
+	repo domRepo.IBounceRepo
+}
+
+// Ingest parse and record delivery status notification (RFC 3464) or feedback report (RFC 5965)
+func (s *BounceService) Ingest(req *appDTO.IngestBounceReqDTO, i identity.Identity) (*appDTO.IngestBounceResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	if req.Report == nil {
+		return nil, validation.Errors{"report": fmt.Errorf("cannot be blank")}
+	}
+	report, err := dsn.Parse(req.Report)
+	if err != nil {
+		return nil, validation.Errors{"report": err}
+	}
+	reqDom := s.toIngestBounceRequest(report)
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.Ingest(reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.IngestBounceResDTO)
+	resDTO.IngestBounceResponse = *res
+
+	return resDTO, nil
+}
+
+// toIngestBounceRequest convert parsed report: failed recipients are hard bounces, delayed recipients soft bounces,
+// and all recipients of a feedback report complaints
+func (s *BounceService) toIngestBounceRequest(report *dsn.Report) *domSchema.IngestBounceRequest {
+	req := &domSchema.IngestBounceRequest{
+		MessageIDHeader: report.OriginalMessageID,
+		FeedbackType:    report.FeedbackType,
+		Bounces:         []*domSchema.Bounce{},
+	}
+
+	switch report.Type {
+	case dsn.DeliveryStatusReport:
+		req.ReportType = domSchema.DeliveryStatusReport
+		for _, v := range report.Recipients {
+			if !v.IsFailure() {
+				continue
+			}
+			b := &domSchema.Bounce{
+				Recipient:      v.Email,
+				Type:           domSchema.SoftBounce,
+				Action:         v.Action,
+				Status:         v.Status,
+				DiagnosticCode: v.DiagnosticCode,
+			}
+			if v.IsPermanent() {
+				b.Type = domSchema.HardBounce
+			}
+			req.Bounces = append(req.Bounces, b)
+		}
+	case dsn.FeedbackReport:
+		req.ReportType = domSchema.FeedbackReport
+		for _, v := range report.Recipients {
+			req.Bounces = append(req.Bounces, &domSchema.Bounce{
+				Recipient: v.Email,
+				Type:      domSchema.Complaint,
+			})
+		}
+	}
+
+	return req
+}
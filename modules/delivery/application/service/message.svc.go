@@ -0,0 +1,571 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	domRepoEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/repository"
+	domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+	domSchemaET "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email_template"
+	infRepoEmail "github.com/d3ta-go/ddd-mod-email/modules/email/infrastructure/repository"
+	appDTO "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/message"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+	domSchemaSuppression "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/suppression"
+	domSchemaTS "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/template_setting"
+	infRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/repository"
+	"github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/service/blocklist"
+	appConfig "github.com/d3ta-go/ms-email-restapi/system/config"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+	"github.com/golang/glog"
+)
+
+// NewMessageService new MessageService
+func NewMessageService(h *handler.Handler) (*MessageService, error) {
+	var err error
+
+	svc := new(MessageService)
+	svc.handler = h
+	if err := svc.initBaseService(); err != nil {
+		return nil, err
+	}
+
+	if svc.repoEmailTpl, err = infRepoEmail.NewEmailTemplateRepo(h); err != nil {
+		return nil, err
+	}
+	if svc.repoMessage, err = infRepo.NewMessageRepo(h); err != nil {
+		return nil, err
+	}
+	if svc.repoOutbox, err = infRepo.NewOutboxRepo(h); err != nil {
+		return nil, err
+	}
+	if svc.repoIdemKey, err = infRepo.NewIdempotencyKeyRepo(h); err != nil {
+		return nil, err
+	}
+	if svc.repoSuppression, err = infRepo.NewSuppressionRepo(h); err != nil {
+		return nil, err
+	}
+	if svc.repoUnsubscribe, err = infRepo.NewUnsubscribeRepo(h); err != nil {
+		return nil, err
+	}
+	if svc.repoTplSetting, err = infRepo.NewTemplateSettingRepo(h); err != nil {
+		return nil, err
+	}
+
+	cfg, err := appConfig.GetConfig(h)
+	if err != nil {
+		return nil, err
+	}
+	if svc.disposableDomains, err = blocklist.LoadDomainsFile(cfg.Delivery.Addresses.DisposableDomainsFile); err != nil {
+		return nil, err
+	}
+
+	return svc, nil
+}
+
+// MessageService type
+type MessageService struct {
+	BaseService
+	repoMessage     domRepo.IMessageRepo
+	repoOutbox      domRepo.IOutboxRepo
+	repoIdemKey     domRepo.IIdempotencyKeyRepo
+	repoSuppression domRepo.ISuppressionRepo
+	repoUnsubscribe domRepo.IUnsubscribeRepo
+	repoTplSetting  domRepo.ITemplateSettingRepo
+	repoEmailTpl    domRepoEmail.IEmailTemplateRepo
+
+	disposableDomains map[string]bool
+}
+
+// Send send Email Template message (with attachments)
+func (s *MessageService) Send(req *appDTO.SendMessageReqDTO, i identity.Identity) (*appDTO.SendMessageResDTO, error) {
+	// authorization
+	if (i.CanAccessCurrentRequest() == false) && (i.CanAccess("", "system.module.delivery.message.send", "EXECUTE", nil) == false) {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	return s.sendWith(req, nil, i)
+}
+
+// sendWith send (authorized) request, with the resolved email template (nil: resolved by the request template code)
+func (s *MessageService) sendWith(req *appDTO.SendMessageReqDTO, tpl *sendTemplate, i identity.Identity) (*appDTO.SendMessageResDTO, error) {
+	reqDom, cfg, err := s.sendRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// idempotency: the same Idempotency-Key (per identity) returns the original response
+	// -->
+	var idemKey *domSchema.IdempotencyKey
+	if req.IdempotencyKey != "" {
+		idemKey = &domSchema.IdempotencyKey{
+			Username: i.Claims.Username,
+			Key:      req.IdempotencyKey,
+		}
+		if err := idemKey.Validate(); err != nil {
+			return nil, err
+		}
+		if idemKey.Fingerprint, err = reqDom.Fingerprint(); err != nil {
+			return nil, err
+		}
+
+		orig, err := s.repoIdemKey.Begin(idemKey, cfg.Delivery.Idempotency.GetWindow(), cfg.Delivery.Idempotency.GetProcessingTimeout())
+		if err != nil {
+			return nil, err
+		}
+		if orig != nil {
+			reqDom.RemoveSpooledAttachments()
+
+			resDTO := new(appDTO.SendMessageResDTO)
+			resDTO.SendMessageResponse = *orig
+			resDTO.Replayed = true
+			return resDTO, nil
+		}
+	}
+	// <--
+
+	res, err := s.send(reqDom, tpl, cfg, i)
+	if err != nil {
+		if idemKey != nil {
+			s.repoIdemKey.Release(idemKey)
+		}
+		return nil, err
+	}
+	if idemKey != nil {
+		// the message is sent already: a failure to store the response is logged, not reported as a failed send
+		// (the key stays PROCESSING until the processing timeout)
+		if err := s.repoIdemKey.Complete(idemKey, res); err != nil {
+			glog.Errorf("Idempotency-Key `%s` (%s): response not stored: %s", idemKey.Key, idemKey.Username, err.Error())
+		}
+	}
+
+	// response - dto
+	resDTO := new(appDTO.SendMessageResDTO)
+	resDTO.SendMessageResponse = *res
+
+	return resDTO, nil
+}
+
+// Preview render Email Template message of a send request without sending it (dry run)
+func (s *MessageService) Preview(req *appDTO.SendMessageReqDTO, i identity.Identity) (*appDTO.PreviewMessageResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	reqDom, _, err := s.sendRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.assignTemplate(reqDom); err != nil {
+		return nil, err
+	}
+
+	// suppressed cc and bcc addresses are skipped (as on send), the message is rendered even when the recipient (to) is suppressed
+	suppressed, _, err := s.suppress(reqDom)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.repoMessage.Preview(reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+	res.Suppressed = suppressed
+
+	// response - dto
+	resDTO := new(appDTO.PreviewMessageResDTO)
+	resDTO.PreviewMessageResponse = *res
+
+	return resDTO, nil
+}
+
+// sendRequest request domain of the send request, validated (with the decoded attachments)
+func (s *MessageService) sendRequest(req *appDTO.SendMessageReqDTO) (*domSchema.SendMessageRequest, *appConfig.Config, error) {
+	// request domain
+	reqDom := domSchema.SendMessageRequest{
+		TemplateCode:   req.TemplateCode,
+		From:           req.ConvertFrom2Domain(),
+		To:             req.ConvertTo2Domain(),
+		CC:             req.ConvertCC2Domain(),
+		BCC:            req.ConvertBCC2Domain(),
+		ReplyTo:        req.ConvertReplyTo2Domain(),
+		Headers:        req.Headers,
+		Priority:       req.Priority,
+		TemplateData:   req.TemplateData,
+		ProcessingType: req.ProcessingType,
+		Attachments:    req.ConvertAttachments2Domain(),
+		SendAt:         req.SendAt,
+		TrackOpens:     req.TrackOpens,
+		CalendarEvent:  req.ConvertCalendarEvent2Domain(),
+	}
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := reqDom.ValidateAddresses(s.disposableDomains); err != nil {
+		return nil, nil, err
+	}
+	if err := reqDom.DecodeAttachments(); err != nil {
+		return nil, nil, err
+	}
+
+	cfg, err := appConfig.GetConfig(s.handler)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := reqDom.ValidateAttachments(domSchema.AttachmentLimits{
+		MaxFileSize:         cfg.Delivery.Attachments.GetMaxFileSize(),
+		MaxTotalSize:        cfg.Delivery.Attachments.GetMaxTotalSize(),
+		AllowedContentTypes: cfg.Delivery.Attachments.AllowedContentTypes,
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return &reqDom, cfg, nil
+}
+
+func (s *MessageService) send(reqDom *domSchema.SendMessageRequest, tpl *sendTemplate, cfg *appConfig.Config, i identity.Identity) (*domSchema.SendMessageResponse, error) {
+	if tpl == nil {
+		var err error
+		if tpl, err = s.resolveTemplate(reqDom.TemplateCode); err != nil {
+			return nil, err
+		}
+	}
+	tpl.assign(reqDom)
+
+	// suppressed addresses are skipped, nothing is sent when the recipient (to) is suppressed
+	// -->
+	suppressed, toSuppressed, err := s.suppress(reqDom)
+	if err != nil {
+		return nil, err
+	}
+	if toSuppressed {
+		reqDom.RemoveSpooledAttachments()
+		return &domSchema.SendMessageResponse{
+			TemplateCode: reqDom.TemplateCode,
+			Status:       domSchema.SuppressedSendStatus,
+			Suppressed:   suppressed,
+		}, nil
+	}
+	// <--
+
+	if reqDom.SendAt != "" || domSchemaEmail.ProcessingType(reqDom.ProcessingType) == domSchemaEmail.ASYNCProcess {
+		// scheduled/ASYNC delivery: durable outbox
+		msg, err := s.repoMessage.Create(reqDom, i)
+		if err != nil {
+			return nil, err
+		}
+		res, err := s.repoOutbox.Enqueue(reqDom, cfg.Delivery.Outbox.GetMaxAttempts(), i)
+		if err != nil {
+			s.failMessage(reqDom, err)
+			return nil, err
+		}
+		res.MessageID = msg.ID
+		res.MessageIDHeader = msg.MessageIDHeader
+
+		res.Suppressed = suppressed
+
+		if reqDom.SendAt == "" {
+			WakeUpOutbox()
+		}
+		return res, nil
+	}
+
+	res, err := s.repoMessage.Send(reqDom, i)
+	if err != nil {
+		s.failMessage(reqDom, err)
+		return nil, err
+	}
+	res.Suppressed = suppressed
+	return res, nil
+}
+
+// sendTemplate email template (default version, parsed) and its delivery settings, resolved once for many sends (e.g: batch)
+type sendTemplate struct {
+	template *domSchemaET.ETFindByCodeData
+	setting  *domSchemaTS.TemplateSetting
+	compiled *domSchema.CompiledTemplate
+}
+
+func (t *sendTemplate) assign(reqDom *domSchema.SendMessageRequest) {
+	reqDom.Template = t.template
+	reqDom.Setting = t.setting
+	reqDom.Compiled = t.compiled
+}
+
+// resolveTemplate retrieve and parse email template (default version), with its delivery settings
+func (s *MessageService) resolveTemplate(templateCode string) (*sendTemplate, error) {
+	reqET := domSchemaET.ETFindByCodeRequest{
+		Code: templateCode,
+	}
+	tpl, err := s.repoEmailTpl.FindByCode(&reqET, s.systemIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &sendTemplate{template: &tpl.Data}
+	if t.setting, err = s.repoTplSetting.FindByCode(templateCode); err != nil {
+		return nil, err
+	}
+	if t.compiled, err = domSchema.CompileTemplate(t.template); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// assignTemplate retrieve and assign email template (default version) and its delivery settings
+func (s *MessageService) assignTemplate(reqDom *domSchema.SendMessageRequest) error {
+	tpl, err := s.resolveTemplate(reqDom.TemplateCode)
+	if err != nil {
+		return err
+	}
+	tpl.assign(reqDom)
+	return nil
+}
+
+// suppress skip suppressed (and, on marketing templates, unsubscribed) cc and bcc addresses of the request,
+// returns the skipped addresses and whether the recipient (to) is skipped
+func (s *MessageService) suppress(reqDom *domSchema.SendMessageRequest) ([]string, bool, error) {
+	emails := reqDom.Recipients()
+	sps, err := s.repoSuppression.FindSuppressed(emails)
+	if err != nil {
+		return nil, false, err
+	}
+	unsubscribed := map[string]bool{}
+	if reqDom.Setting.IsMarketing() {
+		if unsubscribed, err = s.repoUnsubscribe.FindUnsubscribed(emails); err != nil {
+			return nil, false, err
+		}
+	}
+	if len(sps) == 0 && len(unsubscribed) == 0 {
+		return nil, false, nil
+	}
+	suppressed, toSuppressed := reqDom.Suppress(func(email string) bool {
+		email = domSchemaSuppression.NormalizeEmail(email)
+		return sps[email] != nil || unsubscribed[email]
+	})
+	return suppressed, toSuppressed, nil
+}
+
+// FindMessage find message record (delivery status, with status transitions)
+func (s *MessageService) FindMessage(req *appDTO.FindMessageReqDTO, i identity.Identity) (*appDTO.FindMessageResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.FindMessageRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repoMessage.FindByID(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.FindMessageResDTO)
+	resDTO.FindMessageResponse = *res
+
+	return resDTO, nil
+}
+
+// FindMessageBody find rendered subject and body of message record
+func (s *MessageService) FindMessageBody(req *appDTO.FindMessageBodyReqDTO, i identity.Identity) (*appDTO.FindMessageBodyResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.FindMessageBodyRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repoMessage.FindBodyByID(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.FindMessageBodyResDTO)
+	resDTO.FindMessageBodyResponse = *res
+
+	return resDTO, nil
+}
+
+// ListMessage list message records (summary rows)
+func (s *MessageService) ListMessage(req *appDTO.ListMessageReqDTO, i identity.Identity) (*appDTO.ListMessageResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.ListMessageRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repoMessage.List(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.ListMessageResDTO)
+	resDTO.ListMessageResponse = *res
+
+	return resDTO, nil
+}
+
+// failMessage set message record of a failed (final) send to FAILED
+func (s *MessageService) failMessage(reqDom *domSchema.SendMessageRequest, sendErr error) error {
+	if reqDom.MessageID == "" {
+		return nil
+	}
+	return s.repoMessage.SetStatus(reqDom.MessageID, domSchema.FailedStatus, sendErr.Error())
+}
+
+// PurgeIdempotencyKeys delete expired Idempotency-Keys, returns number of deleted keys
+func (s *MessageService) PurgeIdempotencyKeys() (int64, error) {
+	return s.repoIdemKey.PurgeExpired()
+}
+
+// outboxWakeUp signal the outbox dispatcher that new messages were enqueued
+var outboxWakeUp = make(chan struct{}, 1)
+
+// WakeUpOutbox notify the outbox dispatcher (non-blocking)
+func WakeUpOutbox() {
+	select {
+	case outboxWakeUp <- struct{}{}:
+	default:
+	}
+}
+
+// OutboxWakeUp get outbox dispatcher wake-up channel
+func OutboxWakeUp() <-chan struct{} {
+	return outboxWakeUp
+}
+
+// DispatchOutbox deliver due outbox messages (ASYNC and scheduled sends), returns number of dispatched messages.
+// Failed deliveries are retried with exponential backoff, until they are sent or dead-lettered (FAILED).
+func (s *MessageService) DispatchOutbox() (int, error) {
+	cfg, err := appConfig.GetConfig(s.handler)
+	if err != nil {
+		return 0, err
+	}
+
+	oms, err := s.repoOutbox.ClaimDue(cfg.Delivery.Scheduler.GetBatchSize(), cfg.Delivery.Scheduler.GetLeaseTimeout())
+	if err != nil {
+		return 0, err
+	}
+
+	for _, om := range oms {
+		err := s.deliverOutbox(om)
+		if err := s.completeOutbox(om, err, cfg); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(oms), nil
+}
+
+// DispatchQueued wait for a queued outbox message (ASYNC send, delivery queue) and deliver it,
+// returns number of dispatched messages (0: the queue stays empty)
+func (s *MessageService) DispatchQueued(ctx context.Context) (int, error) {
+	cfg, err := appConfig.GetConfig(s.handler)
+	if err != nil {
+		return 0, err
+	}
+
+	om, err := s.repoOutbox.ClaimNext(ctx, cfg.Delivery.Queue.GetBlockTimeout(), cfg.Delivery.Scheduler.GetLeaseTimeout())
+	if err != nil || om == nil {
+		return 0, err
+	}
+
+	err = s.deliverOutbox(om)
+	if err := s.completeOutbox(om, err, cfg); err != nil {
+		return 0, err
+	}
+
+	return 1, nil
+}
+
+// completeOutbox complete outbox message, and set its message record to QUEUED (retry) or FAILED (SENT is recorded on send)
+func (s *MessageService) completeOutbox(om *domSchema.OutboxMessage, sendErr error, cfg *appConfig.Config) error {
+	status, err := s.repoOutbox.Complete(om, sendErr, s.retryPolicy(cfg))
+	if err != nil {
+		return err
+	}
+	if sendErr == nil || om.Request.MessageID == "" {
+		return nil
+	}
+	return s.repoMessage.SetStatus(om.Request.MessageID, status, sendErr.Error())
+}
+
+func (s *MessageService) retryPolicy(cfg *appConfig.Config) domSchema.RetryPolicy {
+	return domSchema.RetryPolicy{
+		MaxAttempts:    cfg.Delivery.Outbox.GetMaxAttempts(),
+		InitialBackoff: cfg.Delivery.Outbox.GetInitialBackoff(),
+		MaxBackoff:     cfg.Delivery.Outbox.GetMaxBackoff(),
+	}
+}
+
+func (s *MessageService) deliverOutbox(om *domSchema.OutboxMessage) error {
+	if err := om.Request.DecodeAttachments(); err != nil {
+		return err
+	}
+	return s.deliverOnBehalfOf(om.Request, om.ScheduledBy, om.IPAddress)
+}
+
+// deliverOnBehalfOf send a persisted (outbox/recurring) message now, on behalf of the requester,
+// the template (default version) is resolved at delivery time
+func (s *MessageService) deliverOnBehalfOf(reqDom *domSchema.SendMessageRequest, username, ipAddress string) error {
+	if err := s.assignTemplate(reqDom); err != nil {
+		return err
+	}
+
+	// the address may be suppressed (or unsubscribed) after the message was queued (or the schedule created)
+	_, toSuppressed, err := s.suppress(reqDom)
+	if err != nil {
+		return err
+	}
+	if toSuppressed {
+		return fmt.Errorf("%w: %s", domSchema.ErrRecipientSuppressed, reqDom.To.Email)
+	}
+
+	reqDom.SendAt = ""
+
+	_, err = s.repoMessage.Send(reqDom, s.onBehalfOf(username, ipAddress))
+	return err
+}
+
+// onBehalfOf system identity acting on behalf of a user (for background processing)
+func (s *MessageService) onBehalfOf(username, ipAddress string) identity.Identity {
+	i := s.systemIdentity
+	claims := *i.Claims
+	claims.Username = username
+	i.Claims = &claims
+	i.ClientDevices.IPAddress = ipAddress
+	return i
+}
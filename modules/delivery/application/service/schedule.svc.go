@@ -0,0 +1,219 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	domSchemaET "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email_template"
+	appDTO "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/schedule"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	infRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/repository"
+	appConfig "github.com/d3ta-go/ms-email-restapi/system/config"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+)
+
+// NewScheduleService new ScheduleService
+func NewScheduleService(h *handler.Handler) (*ScheduleService, error) {
+	var err error
+
+	svc := new(ScheduleService)
+	svc.handler = h
+	if err := svc.initBaseService(); err != nil {
+		return nil, err
+	}
+
+	if svc.repo, err = infRepo.NewRecurringScheduleRepo(h); err != nil {
+		return nil, err
+	}
+	if svc.messageSvc, err = NewMessageService(h); err != nil {
+		return nil, err
+	}
+
+	return svc, nil
+}
+
+// ScheduleService type
+type ScheduleService struct {
+	BaseService
+	repo       domRepo.IRecurringScheduleRepo
+	messageSvc *MessageService
+}
+
+// Create create recurring Schedule (cron expression)
+func (s *ScheduleService) Create(req *appDTO.RSCreateReqDTO, i identity.Identity) (*appDTO.RSCreateResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.RSCreateRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+	if err := reqDom.ValidateAddresses(s.messageSvc.disposableDomains); err != nil {
+		return nil, err
+	}
+
+	// make sure the email template exist (and active)
+	reqET := domSchemaET.ETFindByCodeRequest{
+		Code: reqDom.TemplateCode,
+	}
+	if _, err := s.messageSvc.repoEmailTpl.FindByCode(&reqET, s.systemIdentity); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.Create(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.RSCreateResDTO)
+	resDTO.RSCreateResponse = *res
+
+	return resDTO, nil
+}
+
+// List list recurring Schedules
+func (s *ScheduleService) List(req *appDTO.RSListReqDTO, i identity.Identity) (*appDTO.RSListResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.RSListRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.List(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.RSListResDTO)
+	resDTO.RSListResponse = *res
+
+	return resDTO, nil
+}
+
+// SetStatus pause or resume recurring Schedule
+func (s *ScheduleService) SetStatus(req *appDTO.RSSetStatusReqDTO, i identity.Identity) (*appDTO.RSSetStatusResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.RSSetStatusRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.SetStatus(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.RSSetStatusResDTO)
+	resDTO.RSSetStatusResponse = *res
+
+	return resDTO, nil
+}
+
+// Delete delete recurring Schedule
+func (s *ScheduleService) Delete(req *appDTO.RSDeleteReqDTO, i identity.Identity) (*appDTO.RSDeleteResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.RSDeleteRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.Delete(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.RSDeleteResDTO)
+	resDTO.RSDeleteResponse = *res
+
+	return resDTO, nil
+}
+
+// ListRun list run history of recurring Schedule
+func (s *ScheduleService) ListRun(req *appDTO.RSListRunReqDTO, i identity.Identity) (*appDTO.RSListRunResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.RSListRunRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.ListRun(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.RSListRunResDTO)
+	resDTO.RSListRunResponse = *res
+
+	return resDTO, nil
+}
+
+// DispatchRecurring send due recurring Schedules and record their runs, returns number of runs
+func (s *ScheduleService) DispatchRecurring() (int, error) {
+	cfg, err := appConfig.GetConfig(s.handler)
+	if err != nil {
+		return 0, err
+	}
+
+	dss, err := s.repo.ClaimDue(cfg.Delivery.Scheduler.GetBatchSize(), s.messageSvc.disposableDomains)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, ds := range dss {
+		startedAt := time.Now()
+		err := s.messageSvc.deliverOnBehalfOf(ds.Request, ds.CreatedBy, ds.IPAddress)
+		if err != nil {
+			s.messageSvc.failMessage(ds.Request, err)
+		}
+		if err := s.repo.RecordRun(ds.ID, ds.ScheduledFor, startedAt, err); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(dss), nil
+}
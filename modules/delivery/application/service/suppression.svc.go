@@ -0,0 +1,147 @@
+package service
+
+import (
+	"fmt"
+
+	appDTO "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/suppression"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	infRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/repository"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+)
+
+// NewSuppressionService new SuppressionService
+func NewSuppressionService(h *handler.Handler) (*SuppressionService, error) {
+	var err error
+
+	svc := new(SuppressionService)
+	svc.handler = h
+	if err := svc.initBaseService(); err != nil {
+		return nil, err
+	}
+
+	if svc.repo, err = infRepo.NewSuppressionRepo(h); err != nil {
+		return nil, err
+	}
+
+	return svc, nil
+}
+
+// SuppressionService type
+type SuppressionService struct {
+	BaseService
+	repo domRepo.ISuppressionRepo
+}
+
+// List list suppressed addresses
+func (s *SuppressionService) List(req *appDTO.SPListReqDTO, i identity.Identity) (*appDTO.SPListResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.SPListRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.List(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.SPListResDTO)
+	resDTO.SPListResponse = *res
+
+	return resDTO, nil
+}
+
+// Add add address to the suppression list
+func (s *SuppressionService) Add(req *appDTO.SPAddReqDTO, i identity.Identity) (*appDTO.SPAddResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.SPAddRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.Add(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.SPAddResDTO)
+	resDTO.SPAddResponse = *res
+
+	return resDTO, nil
+}
+
+// Remove remove address from the suppression list
+func (s *SuppressionService) Remove(req *appDTO.SPRemoveReqDTO, i identity.Identity) (*appDTO.SPRemoveResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.SPRemoveRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.Remove(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.SPRemoveResDTO)
+	resDTO.SPRemoveResponse = *res
+
+	return resDTO, nil
+}
+
+// Check check whether an address is suppressed
+func (s *SuppressionService) Check(req *appDTO.SPCheckReqDTO, i identity.Identity) (*appDTO.SPCheckResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.SPCheckRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.Check(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.SPCheckResDTO)
+	resDTO.SPCheckResponse = *res
+
+	return resDTO, nil
+}
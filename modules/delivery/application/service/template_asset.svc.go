@@ -0,0 +1,150 @@
+package service
+
+import (
+	"fmt"
+
+	appDTO "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/template_asset"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/template_asset"
+	infRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/repository"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+)
+
+// NewTemplateAssetService new TemplateAssetService
+func NewTemplateAssetService(h *handler.Handler) (*TemplateAssetService, error) {
+	var err error
+
+	svc := new(TemplateAssetService)
+	svc.handler = h
+	if err := svc.initBaseService(); err != nil {
+		return nil, err
+	}
+
+	if svc.repo, err = infRepo.NewTemplateAssetRepo(h); err != nil {
+		return nil, err
+	}
+
+	return svc, nil
+}
+
+// TemplateAssetService type
+type TemplateAssetService struct {
+	BaseService
+	repo domRepo.ITemplateAssetRepo
+}
+
+// Add add asset (inline image) to the current version of Email Template
+func (s *TemplateAssetService) Add(req *appDTO.TAAddReqDTO, i identity.Identity) (*appDTO.TAAddResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.TAAddRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.Add(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.TAAddResDTO)
+	resDTO.TAAddResponse = *res
+
+	return resDTO, nil
+}
+
+// List list assets of Email Template version
+func (s *TemplateAssetService) List(req *appDTO.TAListReqDTO, i identity.Identity) (*appDTO.TAListResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.TAListRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.List(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.TAListResDTO)
+	resDTO.TAListResponse = *res
+
+	return resDTO, nil
+}
+
+// Delete delete asset from the current version of Email Template
+func (s *TemplateAssetService) Delete(req *appDTO.TADeleteReqDTO, i identity.Identity) (*appDTO.TADeleteResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.TADeleteRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.Delete(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.TADeleteResDTO)
+	resDTO.TADeleteResponse = *res
+
+	return resDTO, nil
+}
+
+// CarryOver copy assets of the previous Email Template version into the current version (after template update)
+func (s *TemplateAssetService) CarryOver(req *appDTO.TACarryOverReqDTO, i identity.Identity) (*appDTO.TACarryOverResDTO, error) {
+	// authorization
+	if (i.CanAccessCurrentRequest() == false) && (i.CanAccess("", "system.module.delivery.template.asset.carryover", "EXECUTE", nil) == false) {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := domSchema.TACarryOverRequest{
+		TemplateCode: req.TemplateCode,
+	}
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.CarryOver(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.TACarryOverResDTO)
+	resDTO.TAListResponse = *res
+
+	return resDTO, nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"fmt"
+
+	appDTO "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/template_setting"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	infRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/repository"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+)
+
+// NewTemplateSettingService new TemplateSettingService
+func NewTemplateSettingService(h *handler.Handler) (*TemplateSettingService, error) {
+	var err error
+
+	svc := new(TemplateSettingService)
+	svc.handler = h
+	if err := svc.initBaseService(); err != nil {
+		return nil, err
+	}
+
+	if svc.repo, err = infRepo.NewTemplateSettingRepo(h); err != nil {
+		return nil, err
+	}
+
+	return svc, nil
+}
+
+// TemplateSettingService type
+type TemplateSettingService struct {
+	BaseService
+	repo domRepo.ITemplateSettingRepo
+}
+
+// Find find delivery settings (category, tracking) of Email Template
+func (s *TemplateSettingService) Find(req *appDTO.TSFindReqDTO, i identity.Identity) (*appDTO.TSFindResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.TSFindRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.Find(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.TSFindResDTO)
+	resDTO.TSFindResponse = *res
+
+	return resDTO, nil
+}
+
+// Update set delivery settings (category, tracking) of Email Template
+func (s *TemplateSettingService) Update(req *appDTO.TSUpdateReqDTO, i identity.Identity) (*appDTO.TSUpdateResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.TSUpdateRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.Update(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.TSUpdateResDTO)
+	resDTO.TSUpdateResponse = *res
+
+	return resDTO, nil
+}
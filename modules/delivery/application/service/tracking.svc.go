@@ -0,0 +1,164 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	appDTO "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/tracking"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/tracking"
+	infRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/repository"
+	"github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/service/links"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+)
+
+// NewTrackingService new TrackingService
+func NewTrackingService(h *handler.Handler) (*TrackingService, error) {
+	var err error
+
+	svc := new(TrackingService)
+	svc.handler = h
+	if err := svc.initBaseService(); err != nil {
+		return nil, err
+	}
+
+	if svc.repo, err = infRepo.NewTrackingRepo(h); err != nil {
+		return nil, err
+	}
+	if svc.links, err = links.NewLinks(h); err != nil {
+		return nil, err
+	}
+
+	return svc, nil
+}
+
+// TrackingService type (opens and clicks are recorded by public requests, authorized by the signed tracking links)
+type TrackingService struct {
+	BaseService
+	repo  domRepo.ITrackingRepo
+	links *links.Links
+}
+
+// RecordOpen record an open of a message (open tracking pixel)
+func (s *TrackingService) RecordOpen(req *appDTO.RecordOpenReqDTO, i identity.Identity) (*appDTO.RecordOpenResDTO, error) {
+	// request domain
+	reqDom := req.RecordOpenRequest
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	token := new(domSchema.OpenToken)
+	if err := s.verify(reqDom.Token, token); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.RecordOpen(token, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.RecordOpenResDTO)
+	resDTO.RecordOpenResponse = *res
+
+	return resDTO, nil
+}
+
+// RecordClick record a click of a tracked link (click redirect), returns the url to redirect to
+func (s *TrackingService) RecordClick(req *appDTO.RecordClickReqDTO, i identity.Identity) (*appDTO.RecordClickResDTO, error) {
+	// request domain
+	reqDom := req.RecordClickRequest
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	token := new(domSchema.ClickToken)
+	if err := s.verify(reqDom.Token, token); err != nil {
+		return nil, err
+	}
+	// signed links only have http(s) urls, never redirect anywhere else
+	if url := strings.ToLower(token.URL); !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil, &sysError.SystemError{StatusCode: http.StatusNotFound, Err: links.ErrInvalidToken}
+	}
+
+	res, err := s.repo.RecordClick(token, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.RecordClickResDTO)
+	resDTO.RecordClickResponse = *res
+
+	return resDTO, nil
+}
+
+// FindMessageClicks find click counts (per link) of a message
+func (s *TrackingService) FindMessageClicks(req *appDTO.MessageClicksReqDTO, i identity.Identity) (*appDTO.MessageClicksResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.MessageClicksRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.FindMessageClicks(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.MessageClicksResDTO)
+	resDTO.MessageClicksResponse = *res
+
+	return resDTO, nil
+}
+
+// FindTemplateClicks find click counts (per link) of the messages of a template
+func (s *TrackingService) FindTemplateClicks(req *appDTO.TemplateClicksReqDTO, i identity.Identity) (*appDTO.TemplateClicksResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.TemplateClicksRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.FindTemplateClicks(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.TemplateClicksResDTO)
+	resDTO.TemplateClicksResponse = *res
+
+	return resDTO, nil
+}
+
+// verify verify the signed tracking link token and decode its payload
+func (s *TrackingService) verify(token string, payload interface{}) error {
+	if err := s.links.Verify(token, payload); err != nil {
+		if err == links.ErrInvalidToken {
+			return &sysError.SystemError{StatusCode: http.StatusNotFound, Err: err}
+		}
+		return &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	return nil
+}
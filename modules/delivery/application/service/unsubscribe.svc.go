@@ -0,0 +1,104 @@
+package service
+
+import (
+	"net/http"
+
+	appDTO "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/unsubscribe"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/unsubscribe"
+	infRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/repository"
+	"github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/service/links"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+)
+
+// NewUnsubscribeService new UnsubscribeService
+func NewUnsubscribeService(h *handler.Handler) (*UnsubscribeService, error) {
+	var err error
+
+	svc := new(UnsubscribeService)
+	svc.handler = h
+	if err := svc.initBaseService(); err != nil {
+		return nil, err
+	}
+
+	if svc.repo, err = infRepo.NewUnsubscribeRepo(h); err != nil {
+		return nil, err
+	}
+	if svc.links, err = links.NewLinks(h); err != nil {
+		return nil, err
+	}
+
+	return svc, nil
+}
+
+// UnsubscribeService type (public: authorized by the signed unsubscribe link)
+type UnsubscribeService struct {
+	BaseService
+	repo  domRepo.IUnsubscribeRepo
+	links *links.Links
+}
+
+// Find find unsubscribe status of the recipient of an unsubscribe link
+func (s *UnsubscribeService) Find(req *appDTO.UnsubscribeReqDTO, i identity.Identity) (*appDTO.UnsubscribeResDTO, error) {
+	// request domain
+	reqDom := req.UnsubscribeRequest
+
+	token, err := s.verify(&reqDom)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.Find(token, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.UnsubscribeResDTO)
+	resDTO.UnsubscribeResponse = *res
+
+	return resDTO, nil
+}
+
+// Unsubscribe record the opt-out (of marketing emails) of the recipient of an unsubscribe link
+func (s *UnsubscribeService) Unsubscribe(req *appDTO.UnsubscribeReqDTO, i identity.Identity) (*appDTO.UnsubscribeResDTO, error) {
+	// request domain
+	reqDom := req.UnsubscribeRequest
+	if reqDom.Method == "" {
+		reqDom.Method = string(domSchema.LinkMethod)
+	}
+
+	token, err := s.verify(&reqDom)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.Unsubscribe(token, domSchema.Method(reqDom.Method), i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.UnsubscribeResDTO)
+	resDTO.UnsubscribeResponse = *res
+
+	return resDTO, nil
+}
+
+// verify validate request and verify the signed unsubscribe link token
+func (s *UnsubscribeService) verify(reqDom *domSchema.UnsubscribeRequest) (*domSchema.Token, error) {
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	token := new(domSchema.Token)
+	if err := s.links.Verify(reqDom.Token, token); err != nil {
+		if err == links.ErrInvalidToken {
+			return nil, &sysError.SystemError{StatusCode: http.StatusNotFound, Err: err}
+		}
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	return token, nil
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"fmt"
+
+	appDTO "github.com/d3ta-go/ms-email-restapi/modules/delivery/application/dto/webhook"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	domSchemaMessage "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/webhook"
+	infRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/repository"
+	"github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/service/webhook"
+	appConfig "github.com/d3ta-go/ms-email-restapi/system/config"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+)
+
+// NewWebhookService new WebhookService
+func NewWebhookService(h *handler.Handler) (*WebhookService, error) {
+	var err error
+
+	svc := new(WebhookService)
+	svc.handler = h
+	if err := svc.initBaseService(); err != nil {
+		return nil, err
+	}
+
+	if svc.repo, err = infRepo.NewWebhookRepo(h); err != nil {
+		return nil, err
+	}
+
+	return svc, nil
+}
+
+// WebhookService type
+type WebhookService struct {
+	BaseService
+	repo domRepo.IWebhookRepo
+}
+
+// Create register webhook endpoint (the signing secret is only returned here)
+func (s *WebhookService) Create(req *appDTO.WHCreateReqDTO, i identity.Identity) (*appDTO.WHCreateResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.WHCreateRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.Create(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.WHCreateResDTO)
+	resDTO.WHCreateResponse = *res
+
+	return resDTO, nil
+}
+
+// List list webhook endpoints
+func (s *WebhookService) List(req *appDTO.WHListReqDTO, i identity.Identity) (*appDTO.WHListResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.WHListRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.List(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.WHListResDTO)
+	resDTO.WHListResponse = *res
+
+	return resDTO, nil
+}
+
+// Delete delete webhook endpoint
+func (s *WebhookService) Delete(req *appDTO.WHDeleteReqDTO, i identity.Identity) (*appDTO.WHDeleteResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.WHDeleteRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.Delete(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.WHDeleteResDTO)
+	resDTO.WHDeleteResponse = *res
+
+	return resDTO, nil
+}
+
+// ListDelivery list (latest) deliveries of webhook endpoint
+func (s *WebhookService) ListDelivery(req *appDTO.WHListDeliveryReqDTO, i identity.Identity) (*appDTO.WHListDeliveryResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.WHListDeliveryRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.ListDelivery(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.WHListDeliveryResDTO)
+	resDTO.WHListDeliveryResponse = *res
+
+	return resDTO, nil
+}
+
+// Replay deliver the event of a webhook delivery again
+func (s *WebhookService) Replay(req *appDTO.WHReplayReqDTO, i identity.Identity) (*appDTO.WHReplayResDTO, error) {
+	// authorization
+	if i.CanAccessCurrentRequest() == false {
+		errMsg := fmt.Sprintf("You are not authorized to access [`%s.%s`]",
+			i.RequestInfo.RequestObject, i.RequestInfo.RequestAction)
+		return nil, sysError.CustomForbiddenAccess(errMsg)
+	}
+
+	// request domain
+	reqDom := req.WHReplayRequest
+
+	if err := reqDom.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.Replay(&reqDom, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// response dto
+	resDTO := new(appDTO.WHReplayResDTO)
+	resDTO.WHReplayResponse = *res
+
+	return resDTO, nil
+}
+
+// PublishTemplateEvent publish template.updated event of a changed email template.
+// The caller has already been authorized to change the template.
+func (s *WebhookService) PublishTemplateEvent(templateCode string, action domSchema.TemplateAction, i identity.Identity) error {
+	return s.repo.Publish(domSchema.TemplateUpdatedEvent, domSchema.TemplateEventData{
+		TemplateCode: templateCode,
+		Action:       action,
+		UpdatedBy:    i.Claims.Username,
+	})
+}
+
+// DispatchDeliveries post due webhook deliveries (signed), returns number of dispatched deliveries
+func (s *WebhookService) DispatchDeliveries() (int, error) {
+	cfg, err := appConfig.GetConfig(s.handler)
+	if err != nil {
+		return 0, err
+	}
+
+	dds, err := s.repo.ClaimDue(cfg.Delivery.Scheduler.GetBatchSize(), cfg.Delivery.Scheduler.GetLeaseTimeout())
+	if err != nil {
+		return 0, err
+	}
+
+	sender := webhook.NewSender(cfg.Delivery.Webhook.GetTimeout())
+	policy := domSchemaMessage.RetryPolicy{
+		MaxAttempts:    cfg.Delivery.Webhook.GetMaxAttempts(),
+		InitialBackoff: cfg.Delivery.Webhook.GetInitialBackoff(),
+		MaxBackoff:     cfg.Delivery.Webhook.GetMaxBackoff(),
+	}
+	for _, dd := range dds {
+		var result *domSchema.DeliveryResult
+		res, sendErr := sender.Post(dd.URL, dd.Secret, dd.Event, dd.ID, dd.Payload)
+		if res != nil {
+			result = &domSchema.DeliveryResult{StatusCode: res.StatusCode}
+		}
+		if err := s.repo.Complete(dd, result, sendErr, policy); err != nil {
+			return len(dds), err
+		}
+	}
+
+	return len(dds), nil
+}
@@ -0,0 +1,46 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	appConfig "github.com/d3ta-go/ms-email-restapi/system/config"
+)
+
+// RunQueueConsumer consume the delivery queue (ASYNC sends) until the context is done.
+// It returns immediately when the delivery queue is not enabled (the outbox is dispatched by RunScheduler only).
+func (a *DeliveryApp) RunQueueConsumer(ctx context.Context, onError func(err error)) {
+	cfg, err := appConfig.GetConfig(a.handler)
+	if err != nil {
+		if onError != nil {
+			onError(err)
+		}
+		return
+	}
+	if !cfg.Delivery.Queue.IsEnabled() {
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		if _, err := a.MessageSvc.DispatchQueued(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if onError != nil {
+				onError(err)
+			}
+			// e.g: queue engine is down, do not spin
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// EmailBounceEntity represent EmailBounce Entity (ingested bounce or complaint of one recipient)
+type EmailBounceEntity struct {
+	ID uint64 `json:"ID" gorm:"primary_key;column:id"`
+
+	UUID            string    `json:"uuid" gorm:"column:uuid;size:255;unique;not null"`
+	MessageID       uint64    `json:"messageID" gorm:"column:message_id;index"` // 0: original message not found
+	MessageIDHeader string    `json:"messageIdHeader" gorm:"column:message_id_header;size:255;index"`
+	Recipient       string    `json:"recipient" gorm:"column:recipient;size:255;index;not null"`
+	ReportType      string    `json:"reportType" gorm:"column:report_type;size:50;not null"`
+	Type            string    `json:"type" gorm:"column:type;size:50;not null"`
+	Action          string    `json:"action" gorm:"column:action;size:50"`
+	Status          string    `json:"status" gorm:"column:status;size:50"`
+	DiagnosticCode  string    `json:"diagnosticCode" gorm:"column:diagnostic_code;type:text"`
+	FeedbackType    string    `json:"feedbackType" gorm:"column:feedback_type;size:100"`
+	ReceivedAt      time.Time `json:"receivedAt" gorm:"column:received_at;not null"`
+
+	BaseEntity
+}
+
+// TableName get real database table name
+func (t *EmailBounceEntity) TableName() string {
+	return "eml_bounces"
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// EmailClickEntity represent EmailClick Entity (click of a tracked link in a sent message)
+type EmailClickEntity struct {
+	ID uint64 `json:"ID" gorm:"primary_key;column:id"`
+
+	MessageID    uint64    `json:"messageID" gorm:"column:message_id;index;not null"`
+	TemplateCode string    `json:"templateCode" gorm:"column:template_code;size:100;index"`
+	LinkIndex    int       `json:"linkIndex" gorm:"column:link_index;not null"`
+	URL          string    `json:"url" gorm:"column:url;type:text"`
+	ClickedAt    time.Time `json:"clickedAt" gorm:"column:clicked_at;index;not null"`
+	IPAddress    string    `json:"ipAddress" gorm:"column:ip_address;size:100"`
+	UserAgent    string    `json:"userAgent" gorm:"column:user_agent;size:512"`
+
+	BaseEntity
+}
+
+// TableName get real database table name
+func (t *EmailClickEntity) TableName() string {
+	return "eml_clicks"
+}
@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// IdempotencyKeyStatus represent IdempotencyKey status
+type IdempotencyKeyStatus string
+
+const (
+	// ProcessingIdempotencyStatus the first request with the key is being processed
+	ProcessingIdempotencyStatus IdempotencyKeyStatus = "PROCESSING"
+	// CompletedIdempotencyStatus the first request with the key is completed, the response is replayed
+	CompletedIdempotencyStatus IdempotencyKeyStatus = "COMPLETED"
+)
+
+// EmailIdempotencyKeyEntity represent EmailIdempotencyKey Entity (Idempotency-Key of send request, per identity)
+type EmailIdempotencyKeyEntity struct {
+	ID uint64 `json:"ID" gorm:"primary_key;column:id"`
+
+	Username    string    `json:"username" gorm:"column:username;size:255;uniqueIndex:idx_idempotency_key;not null"`
+	Key         string    `json:"key" gorm:"column:idempotency_key;size:255;uniqueIndex:idx_idempotency_key;not null"`
+	Fingerprint string    `json:"fingerprint" gorm:"column:fingerprint;size:64;not null"`
+	Status      string    `json:"status" gorm:"column:status;size:50;not null"`
+	Response    string    `json:"-" gorm:"column:response;type:text"`
+	ExpiresAt   time.Time `json:"expiresAt" gorm:"column:expires_at;index;not null"`
+
+	BaseEntity
+}
+
+// TableName get real database table name
+func (t *EmailIdempotencyKeyEntity) TableName() string {
+	return "eml_idempotency_keys"
+}
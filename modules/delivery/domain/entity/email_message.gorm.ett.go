@@ -0,0 +1,58 @@
+package entity
+
+import "time"
+
+// EmailMessageEntity represent EmailMessage Entity (message record of a send request, with delivery status)
+type EmailMessageEntity struct {
+	ID uint64 `json:"ID" gorm:"primary_key;column:id"`
+
+	UUID             string     `json:"uuid" gorm:"column:uuid;size:255;unique;not null"`
+	MessageIDHeader  string     `json:"messageIdHeader" gorm:"column:message_id_header;size:255;unique;not null"`
+	TemplateCode     string     `json:"templateCode" gorm:"column:template_code;size:255;index;not null"`
+	FromEmail        string     `json:"fromEmail" gorm:"column:from_email;size:255"`
+	FromName         string     `json:"fromName" gorm:"column:from_name;size:255"`
+	ToEmail          string     `json:"toEmail" gorm:"column:to_email;size:255;index"`
+	ToName           string     `json:"toName" gorm:"column:to_name;size:255"`
+	CC               string     `json:"cc" gorm:"column:cc;type:text"`
+	BCC              string     `json:"bcc" gorm:"column:bcc;type:text"`
+	Subject          string     `json:"subject" gorm:"column:subject;type:text"`
+	Body             string     `json:"-" gorm:"column:body;type:longtext"`
+	ProcessingType   string     `json:"processingType" gorm:"column:processing_type;size:50"`
+	Status           string     `json:"status" gorm:"column:status;size:50;index;not null"`
+	Attempts         int        `json:"attempts" gorm:"column:attempts"`
+	LastSMTPResponse string     `json:"lastSmtpResponse" gorm:"column:last_smtp_response;type:text"`
+	SendAt           *time.Time `json:"sendAt" gorm:"column:send_at"`
+	QueuedAt         *time.Time `json:"queuedAt" gorm:"column:queued_at"`
+	SendingAt        *time.Time `json:"sendingAt" gorm:"column:sending_at"`
+	SentAt           *time.Time `json:"sentAt" gorm:"column:sent_at"`
+	FailedAt         *time.Time `json:"failedAt" gorm:"column:failed_at"`
+	BouncedAt        *time.Time `json:"bouncedAt" gorm:"column:bounced_at"`
+	FirstOpenedAt    *time.Time `json:"firstOpenedAt" gorm:"column:first_opened_at"`
+	OpenCount        int        `json:"openCount" gorm:"column:open_count;not null;default:0"`
+	SentBy           string     `json:"sentBy" gorm:"column:sent_by;size:255;index"`
+	IPAddress        string     `json:"ipAddress" gorm:"column:ip_address;size:100"`
+
+	BaseEntity
+}
+
+// TableName get real database table name
+func (t *EmailMessageEntity) TableName() string {
+	return "eml_messages"
+}
+
+// EmailMessageEventEntity represent EmailMessageEvent Entity (status transition of a message record)
+type EmailMessageEventEntity struct {
+	ID uint64 `json:"ID" gorm:"primary_key;column:id"`
+
+	MessageID    uint64    `json:"messageID" gorm:"column:message_id;index;not null"`
+	Status       string    `json:"status" gorm:"column:status;size:50;not null"`
+	SMTPResponse string    `json:"smtpResponse" gorm:"column:smtp_response;type:text"`
+	OccurredAt   time.Time `json:"occurredAt" gorm:"column:occurred_at;not null"`
+
+	BaseEntity
+}
+
+// TableName get real database table name
+func (t *EmailMessageEventEntity) TableName() string {
+	return "eml_message_events"
+}
@@ -0,0 +1,54 @@
+package entity
+
+import "time"
+
+// OutboxMessageStatus represent OutboxMessage status
+type OutboxMessageStatus string
+
+const (
+	// PendingStatus waiting to be delivered at NextAttemptAt
+	PendingStatus OutboxMessageStatus = "PENDING"
+	// SendingStatus claimed by a dispatcher
+	SendingStatus OutboxMessageStatus = "SENDING"
+	// SentStatus delivered to SMTP Server (final)
+	SentStatus OutboxMessageStatus = "SENT"
+	// FailedStatus dead-letter, delivery failed after the maximum attempts or with a permanent error (final)
+	FailedStatus OutboxMessageStatus = "FAILED"
+)
+
+// OutboxMessageKind represent OutboxMessage kind
+type OutboxMessageKind string
+
+const (
+	// AsyncKind ASYNC processing type send
+	AsyncKind OutboxMessageKind = "ASYNC"
+	// ScheduledKind scheduled (sendAt) send
+	ScheduledKind OutboxMessageKind = "SCHEDULED"
+)
+
+// EmailOutboxMessageEntity represent EmailOutboxMessage Entity (persisted send request, delivered by the dispatcher)
+type EmailOutboxMessageEntity struct {
+	ID uint64 `json:"ID" gorm:"primary_key;column:id"`
+
+	UUID          string     `json:"uuid" gorm:"column:uuid;size:255;unique;not null"`
+	Kind          string     `json:"kind" gorm:"column:kind;size:50"`
+	TemplateCode  string     `json:"templateCode" gorm:"column:template_code;size:255;not null"`
+	SendAt        time.Time  `json:"sendAt" gorm:"column:send_at;not null"`
+	NextAttemptAt time.Time  `json:"nextAttemptAt" gorm:"column:next_attempt_at;index:idx_next_attempt"`
+	Status        string     `json:"status" gorm:"column:status;size:50;index:idx_next_attempt;not null"`
+	Payload       string     `json:"-" gorm:"column:payload;type:longtext"`
+	Attempts      int        `json:"attempts" gorm:"column:attempts"`
+	MaxAttempts   int        `json:"maxAttempts" gorm:"column:max_attempts"`
+	ClaimedAt     *time.Time `json:"claimedAt" gorm:"column:claimed_at"`
+	ProcessedAt   *time.Time `json:"processedAt" gorm:"column:processed_at"`
+	LastError     string     `json:"lastError" gorm:"column:last_error;type:text"`
+	ScheduledBy   string     `json:"scheduledBy" gorm:"column:scheduled_by;size:255"`
+	IPAddress     string     `json:"ipAddress" gorm:"column:ip_address;size:100"`
+
+	BaseEntity
+}
+
+// TableName get real database table name
+func (t *EmailOutboxMessageEntity) TableName() string {
+	return "eml_outbox_messages"
+}
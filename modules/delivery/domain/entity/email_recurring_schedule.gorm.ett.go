@@ -0,0 +1,47 @@
+package entity
+
+import "time"
+
+// EmailRecurringScheduleEntity represent EmailRecurringSchedule Entity (recurring send of an email template, cron expression)
+type EmailRecurringScheduleEntity struct {
+	ID uint64 `json:"ID" gorm:"primary_key;column:id"`
+
+	UUID           string     `json:"uuid" gorm:"column:uuid;size:255;unique;not null"`
+	Name           string     `json:"name" gorm:"column:name;size:255;not null"`
+	CronExpression string     `json:"cronExpression" gorm:"column:cron_expression;size:100;not null"`
+	Timezone       string     `json:"timezone" gorm:"column:timezone;size:100"`
+	TemplateCode   string     `json:"templateCode" gorm:"column:template_code;size:255;index;not null"`
+	Payload        string     `json:"-" gorm:"column:payload;type:longtext"`
+	Status         string     `json:"status" gorm:"column:status;size:50;index:idx_due;not null"`
+	NextRunAt      *time.Time `json:"nextRunAt" gorm:"column:next_run_at;index:idx_due"`
+	LastRunAt      *time.Time `json:"lastRunAt" gorm:"column:last_run_at"`
+	IPAddress      string     `json:"ipAddress" gorm:"column:ip_address;size:100"`
+	OwnedBy        string     `json:"ownedBy" gorm:"column:owned_by;size:255"`
+
+	BaseEntity
+}
+
+// TableName get real database table name
+func (t *EmailRecurringScheduleEntity) TableName() string {
+	return "eml_recurring_schedules"
+}
+
+// EmailRecurringScheduleRunEntity represent EmailRecurringScheduleRun Entity (run history of a recurring schedule)
+type EmailRecurringScheduleRunEntity struct {
+	ID uint64 `json:"ID" gorm:"primary_key;column:id"`
+
+	UUID         string     `json:"uuid" gorm:"column:uuid;size:255;unique;not null"`
+	ScheduleID   uint64     `json:"scheduleID" gorm:"column:schedule_id;index;not null"`
+	ScheduledFor time.Time  `json:"scheduledFor" gorm:"column:scheduled_for;not null"`
+	StartedAt    *time.Time `json:"startedAt" gorm:"column:started_at"`
+	FinishedAt   *time.Time `json:"finishedAt" gorm:"column:finished_at"`
+	Status       string     `json:"status" gorm:"column:status;size:50;not null"`
+	Error        string     `json:"error" gorm:"column:error;type:text"`
+
+	BaseEntity
+}
+
+// TableName get real database table name
+func (t *EmailRecurringScheduleRunEntity) TableName() string {
+	return "eml_recurring_schedule_runs"
+}
@@ -0,0 +1,19 @@
+package entity
+
+// EmailSuppressionEntity represent EmailSuppression Entity (suppressed recipient address, not sent to)
+type EmailSuppressionEntity struct {
+	ID uint64 `json:"ID" gorm:"primary_key;column:id"`
+
+	UUID   string `json:"uuid" gorm:"column:uuid;size:255;unique;not null"`
+	Email  string `json:"email" gorm:"column:email;size:255;unique;not null"` // lower case
+	Reason string `json:"reason" gorm:"column:reason;size:50;index;not null"`
+	Source string `json:"source" gorm:"column:source;size:255"` // bounce ID (automatic) or username (manual)
+	Note   string `json:"note" gorm:"column:note;type:text"`
+
+	BaseEntity
+}
+
+// TableName get real database table name
+func (t *EmailSuppressionEntity) TableName() string {
+	return "eml_suppressions"
+}
@@ -0,0 +1,21 @@
+package entity
+
+// EmailTemplateAssetEntity represent EmailTemplateAsset Entity (inline image of an email template version)
+type EmailTemplateAssetEntity struct {
+	ID uint64 `json:"ID" gorm:"primary_key;column:id"`
+
+	UUID              string `json:"uuid" gorm:"column:uuid;size:255;unique;not null"`
+	TemplateVersionID uint64 `json:"templateVersionID" gorm:"column:template_version_id;index:idx_asset,unique;not null"`
+	ContentID         string `json:"contentId" gorm:"column:content_id;size:250;index:idx_asset,unique;not null"`
+	Filename          string `json:"filename" gorm:"column:filename;size:255;not null"`
+	ContentType       string `json:"contentType" gorm:"column:content_type;size:100;not null"`
+	Size              int64  `json:"size" gorm:"column:size"`
+	Content           []byte `json:"-" gorm:"column:content"`
+
+	BaseEntity
+}
+
+// TableName get real database table name
+func (t *EmailTemplateAssetEntity) TableName() string {
+	return "eml_email_template_assets"
+}
@@ -0,0 +1,21 @@
+package entity
+
+// EmailTemplateSettingEntity represent EmailTemplateSetting Entity (delivery settings of an email template, by template code)
+type EmailTemplateSettingEntity struct {
+	ID uint64 `json:"ID" gorm:"primary_key;column:id"`
+
+	TemplateCode      string `json:"templateCode" gorm:"column:template_code;size:100;unique;not null"`
+	Category          string `json:"category" gorm:"column:category;size:50;not null"`
+	TrackOpens        bool   `json:"trackOpens" gorm:"column:track_opens"`
+	TrackClicks       bool   `json:"trackClicks" gorm:"column:track_clicks"`
+	TextBodyTpl       string `json:"textBodyTpl" gorm:"column:text_body_tpl;type:text"`
+	TextBodyVersionID uint64 `json:"textBodyVersionID" gorm:"column:text_body_version_id"` // template version the text body template was set for
+	InlineCSS         bool   `json:"inlineCss" gorm:"column:inline_css"`
+
+	BaseEntity
+}
+
+// TableName get real database table name
+func (t *EmailTemplateSettingEntity) TableName() string {
+	return "eml_email_template_settings"
+}
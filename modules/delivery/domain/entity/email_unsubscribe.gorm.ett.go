@@ -0,0 +1,19 @@
+package entity
+
+// EmailUnsubscribeEntity represent EmailUnsubscribe Entity (recipient opted out of marketing emails)
+type EmailUnsubscribeEntity struct {
+	ID uint64 `json:"ID" gorm:"primary_key;column:id"`
+
+	UUID         string `json:"uuid" gorm:"column:uuid;size:255;unique;not null"`
+	Email        string `json:"email" gorm:"column:email;size:255;unique;not null"` // lower case
+	MessageID    uint64 `json:"messageID" gorm:"column:message_id;index"`           // message the unsubscribe link was sent with
+	TemplateCode string `json:"templateCode" gorm:"column:template_code;size:100"`
+	Method       string `json:"method" gorm:"column:method;size:50;not null"`
+
+	BaseEntity
+}
+
+// TableName get real database table name
+func (t *EmailUnsubscribeEntity) TableName() string {
+	return "eml_unsubscribes"
+}
@@ -0,0 +1,74 @@
+package entity
+
+import "time"
+
+// WebhookDeliveryStatus represent WebhookDelivery status
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookPendingStatus waiting to be delivered at NextAttemptAt
+	WebhookPendingStatus WebhookDeliveryStatus = "PENDING"
+	// WebhookDeliveringStatus claimed by a dispatcher
+	WebhookDeliveringStatus WebhookDeliveryStatus = "DELIVERING"
+	// WebhookDeliveredStatus accepted (2xx) by the webhook endpoint (final)
+	WebhookDeliveredStatus WebhookDeliveryStatus = "DELIVERED"
+	// WebhookFailedStatus delivery failed after the maximum attempts (final, can be replayed)
+	WebhookFailedStatus WebhookDeliveryStatus = "FAILED"
+)
+
+// EmailWebhookEntity represent EmailWebhook Entity (registered webhook endpoint)
+type EmailWebhookEntity struct {
+	ID uint64 `json:"ID" gorm:"primary_key;column:id"`
+
+	UUID    string `json:"uuid" gorm:"column:uuid;size:255;unique;not null"`
+	Name    string `json:"name" gorm:"column:name;size:255;not null"`
+	URL     string `json:"url" gorm:"column:url;size:2048;not null"`
+	Events  string `json:"events" gorm:"column:events;size:1000;not null"` // comma separated event types (subscriptions: EmailWebhookEventEntity)
+	Secret  string `json:"-" gorm:"column:secret;size:255;not null"`
+	OwnedBy string `json:"ownedBy" gorm:"column:owned_by;size:255"`
+
+	BaseEntity
+}
+
+// TableName get real database table name
+func (t *EmailWebhookEntity) TableName() string {
+	return "eml_webhooks"
+}
+
+// EmailWebhookEventEntity represent EmailWebhookEvent Entity (event type subscribed by a webhook endpoint)
+type EmailWebhookEventEntity struct {
+	ID uint64 `json:"ID" gorm:"primary_key;column:id"`
+
+	WebhookID uint64 `json:"webhookID" gorm:"column:webhook_id;uniqueIndex:idx_webhook_event;not null"`
+	Event     string `json:"event" gorm:"column:event;size:100;uniqueIndex:idx_webhook_event;index;not null"`
+}
+
+// TableName get real database table name
+func (t *EmailWebhookEventEntity) TableName() string {
+	return "eml_webhook_events"
+}
+
+// EmailWebhookDeliveryEntity represent EmailWebhookDelivery Entity (one event delivery to a webhook endpoint)
+type EmailWebhookDeliveryEntity struct {
+	ID uint64 `json:"ID" gorm:"primary_key;column:id"`
+
+	UUID           string     `json:"uuid" gorm:"column:uuid;size:255;unique;not null"`
+	WebhookID      uint64     `json:"webhookID" gorm:"column:webhook_id;index;not null"`
+	Event          string     `json:"event" gorm:"column:event;size:100;not null"`
+	Payload        string     `json:"-" gorm:"column:payload;type:longtext"`
+	Status         string     `json:"status" gorm:"column:status;size:50;index:idx_next_attempt;not null"`
+	NextAttemptAt  time.Time  `json:"nextAttemptAt" gorm:"column:next_attempt_at;index:idx_next_attempt"`
+	Attempts       int        `json:"attempts" gorm:"column:attempts"`
+	ClaimedAt      *time.Time `json:"claimedAt" gorm:"column:claimed_at"`
+	DeliveredAt    *time.Time `json:"deliveredAt" gorm:"column:delivered_at"`
+	ResponseStatus int        `json:"responseStatus" gorm:"column:response_status"`
+	LastError      string     `json:"lastError" gorm:"column:last_error;type:text"`
+	ReplayOf       string     `json:"replayOf" gorm:"column:replay_of;size:255"`
+
+	BaseEntity
+}
+
+// TableName get real database table name
+func (t *EmailWebhookDeliveryEntity) TableName() string {
+	return "eml_webhook_deliveries"
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/bounce"
+	"github.com/d3ta-go/system/system/identity"
+)
+
+// IBounceRepo represent BounceRepo interface
+type IBounceRepo interface {
+	Ingest(req *domSchema.IngestBounceRequest, i identity.Identity) (*domSchema.IngestBounceResponse, error)
+}
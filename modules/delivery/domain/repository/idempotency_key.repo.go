@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"time"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+)
+
+// IIdempotencyKeyRepo represent IdempotencyKeyRepo interface
+type IIdempotencyKeyRepo interface {
+	Begin(key *domSchema.IdempotencyKey, window, processingTimeout time.Duration) (*domSchema.SendMessageResponse, error)
+	Complete(key *domSchema.IdempotencyKey, resp *domSchema.SendMessageResponse) error
+	Release(key *domSchema.IdempotencyKey) error
+	PurgeExpired() (int64, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+	"github.com/d3ta-go/system/system/identity"
+)
+
+// IMessageRepo represent MessageRepo interface
+type IMessageRepo interface {
+	Send(req *domSchema.SendMessageRequest, i identity.Identity) (*domSchema.SendMessageResponse, error)
+	Preview(req *domSchema.SendMessageRequest, i identity.Identity) (*domSchema.PreviewMessageResponse, error)
+	Create(req *domSchema.SendMessageRequest, i identity.Identity) (*domSchema.Message, error)
+	SetStatus(id string, status domSchema.MessageStatus, smtpResponse string) error
+	FindByID(req *domSchema.FindMessageRequest, i identity.Identity) (*domSchema.FindMessageResponse, error)
+	FindBodyByID(req *domSchema.FindMessageBodyRequest, i identity.Identity) (*domSchema.FindMessageBodyResponse, error)
+	List(req *domSchema.ListMessageRequest, i identity.Identity) (*domSchema.ListMessageResponse, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+	"github.com/d3ta-go/system/system/identity"
+)
+
+// IOutboxRepo represent OutboxRepo interface
+type IOutboxRepo interface {
+	Enqueue(req *domSchema.SendMessageRequest, maxAttempts int, i identity.Identity) (*domSchema.SendMessageResponse, error)
+	ClaimDue(limit int, leaseTimeout time.Duration) ([]*domSchema.OutboxMessage, error)
+	ClaimNext(ctx context.Context, timeout, leaseTimeout time.Duration) (*domSchema.OutboxMessage, error)
+	Complete(msg *domSchema.OutboxMessage, sendErr error, policy domSchema.RetryPolicy) (domSchema.MessageStatus, error)
+}
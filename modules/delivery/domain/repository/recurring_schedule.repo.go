@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"time"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/schedule"
+	"github.com/d3ta-go/system/system/identity"
+)
+
+// IRecurringScheduleRepo represent RecurringScheduleRepo interface
+type IRecurringScheduleRepo interface {
+	Create(req *domSchema.RSCreateRequest, i identity.Identity) (*domSchema.RSCreateResponse, error)
+	List(req *domSchema.RSListRequest, i identity.Identity) (*domSchema.RSListResponse, error)
+	SetStatus(req *domSchema.RSSetStatusRequest, i identity.Identity) (*domSchema.RSSetStatusResponse, error)
+	Delete(req *domSchema.RSDeleteRequest, i identity.Identity) (*domSchema.RSDeleteResponse, error)
+	ListRun(req *domSchema.RSListRunRequest, i identity.Identity) (*domSchema.RSListRunResponse, error)
+
+	ClaimDue(limit int, disposableDomains map[string]bool) ([]*domSchema.DueSchedule, error)
+	RecordRun(id string, scheduledFor, startedAt time.Time, sendErr error) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/suppression"
+	"github.com/d3ta-go/system/system/identity"
+)
+
+// ISuppressionRepo represent SuppressionRepo interface
+type ISuppressionRepo interface {
+	List(req *domSchema.SPListRequest, i identity.Identity) (*domSchema.SPListResponse, error)
+	Add(req *domSchema.SPAddRequest, i identity.Identity) (*domSchema.SPAddResponse, error)
+	Remove(req *domSchema.SPRemoveRequest, i identity.Identity) (*domSchema.SPRemoveResponse, error)
+	Check(req *domSchema.SPCheckRequest, i identity.Identity) (*domSchema.SPCheckResponse, error)
+
+	FindSuppressed(emails []string) (map[string]*domSchema.Suppression, error)
+}
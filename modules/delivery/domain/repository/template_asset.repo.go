@@ -0,0 +1,14 @@
+package repository
+
+import (
+	domSchemaTA "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/template_asset"
+	"github.com/d3ta-go/system/system/identity"
+)
+
+// ITemplateAssetRepo represent TemplateAssetRepo interface
+type ITemplateAssetRepo interface {
+	Add(req *domSchemaTA.TAAddRequest, i identity.Identity) (*domSchemaTA.TAAddResponse, error)
+	List(req *domSchemaTA.TAListRequest, i identity.Identity) (*domSchemaTA.TAListResponse, error)
+	Delete(req *domSchemaTA.TADeleteRequest, i identity.Identity) (*domSchemaTA.TADeleteResponse, error)
+	CarryOver(req *domSchemaTA.TACarryOverRequest, i identity.Identity) (*domSchemaTA.TAListResponse, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/template_setting"
+	"github.com/d3ta-go/system/system/identity"
+)
+
+// ITemplateSettingRepo represent TemplateSettingRepo interface
+type ITemplateSettingRepo interface {
+	Find(req *domSchema.TSFindRequest, i identity.Identity) (*domSchema.TSFindResponse, error)
+	Update(req *domSchema.TSUpdateRequest, i identity.Identity) (*domSchema.TSUpdateResponse, error)
+
+	FindByCode(templateCode string) (*domSchema.TemplateSetting, error)
+}
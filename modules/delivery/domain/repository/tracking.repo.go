@@ -0,0 +1,14 @@
+package repository
+
+import (
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/tracking"
+	"github.com/d3ta-go/system/system/identity"
+)
+
+// ITrackingRepo represent TrackingRepo interface
+type ITrackingRepo interface {
+	RecordOpen(token *domSchema.OpenToken, i identity.Identity) (*domSchema.RecordOpenResponse, error)
+	RecordClick(token *domSchema.ClickToken, i identity.Identity) (*domSchema.RecordClickResponse, error)
+	FindMessageClicks(req *domSchema.MessageClicksRequest, i identity.Identity) (*domSchema.MessageClicksResponse, error)
+	FindTemplateClicks(req *domSchema.TemplateClicksRequest, i identity.Identity) (*domSchema.TemplateClicksResponse, error)
+}
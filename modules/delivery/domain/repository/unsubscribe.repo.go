@@ -0,0 +1,14 @@
+package repository
+
+import (
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/unsubscribe"
+	"github.com/d3ta-go/system/system/identity"
+)
+
+// IUnsubscribeRepo represent UnsubscribeRepo interface
+type IUnsubscribeRepo interface {
+	Find(token *domSchema.Token, i identity.Identity) (*domSchema.UnsubscribeResponse, error)
+	Unsubscribe(token *domSchema.Token, method domSchema.Method, i identity.Identity) (*domSchema.UnsubscribeResponse, error)
+
+	FindUnsubscribed(emails []string) (map[string]bool, error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"time"
+
+	domSchemaMessage "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/webhook"
+	"github.com/d3ta-go/system/system/identity"
+)
+
+// IWebhookRepo represent WebhookRepo interface
+type IWebhookRepo interface {
+	Create(req *domSchema.WHCreateRequest, i identity.Identity) (*domSchema.WHCreateResponse, error)
+	List(req *domSchema.WHListRequest, i identity.Identity) (*domSchema.WHListResponse, error)
+	Delete(req *domSchema.WHDeleteRequest, i identity.Identity) (*domSchema.WHDeleteResponse, error)
+	ListDelivery(req *domSchema.WHListDeliveryRequest, i identity.Identity) (*domSchema.WHListDeliveryResponse, error)
+	Replay(req *domSchema.WHReplayRequest, i identity.Identity) (*domSchema.WHReplayResponse, error)
+
+	Publish(event domSchema.EventType, data interface{}) error
+	ClaimDue(limit int, leaseTimeout time.Duration) ([]*domSchema.DueDelivery, error)
+	Complete(d *domSchema.DueDelivery, result *domSchema.DeliveryResult, sendErr error, policy domSchemaMessage.RetryPolicy) error
+}
@@ -0,0 +1,22 @@
+package batch
+
+import domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+
+// MaxBatchRecipients represent maximum recipients in one batch request
+const MaxBatchRecipients = 1000
+
+// SendBatchRequest represent SendBatchRequest
+type SendBatchRequest struct {
+	TemplateCode   string                      `json:"templateCode"`
+	From           *domSchemaEmail.MailAddress `json:"from"`
+	Recipients     []*BatchRecipient           `json:"recipients"`
+	ProcessingType string                      `json:"processingType"`
+}
+
+// BatchRecipient represent single recipient (with its own template data) in a batch
+type BatchRecipient struct {
+	To           *domSchemaEmail.MailAddress   `json:"to"`
+	CC           []*domSchemaEmail.MailAddress `json:"cc"`
+	BCC          []*domSchemaEmail.MailAddress `json:"bcc"`
+	TemplateData map[string]interface{}        `json:"templateData"`
+}
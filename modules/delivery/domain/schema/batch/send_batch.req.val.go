@@ -0,0 +1,25 @@
+package batch
+
+import (
+	domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validate SendBatchRequest
+// (recipients are skipped here and validated one by one while sending, so one bad address does not fail the whole batch)
+func (r *SendBatchRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.TemplateCode, validation.Required),
+		validation.Field(&r.From, validation.Required),
+		validation.Field(&r.Recipients, validation.Required, validation.Length(1, MaxBatchRecipients), validation.Skip),
+		validation.Field(&r.ProcessingType, validation.Required, validation.In(string(domSchemaEmail.SYNCProcess), string(domSchemaEmail.ASYNCProcess))),
+	)
+}
+
+// Validate BatchRecipient
+func (r *BatchRecipient) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.To, validation.Required),
+		validation.Field(&r.TemplateData, validation.Required),
+	)
+}
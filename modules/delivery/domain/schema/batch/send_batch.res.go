@@ -0,0 +1,51 @@
+package batch
+
+import "encoding/json"
+
+// SendBatchResponse type
+type SendBatchResponse struct {
+	TemplateCode string             `json:"templateCode"`
+	Total        int                `json:"total"`
+	Succeeded    int                `json:"succeeded"`
+	Suppressed   int                `json:"suppressed"` // nothing sent, the recipient (to) is suppressed
+	Failed       int                `json:"failed"`
+	Results      []*SendBatchResult `json:"results"`
+}
+
+// SendBatchResult represent sending result of one batch recipient
+type SendBatchResult struct {
+	Index      int      `json:"index"`
+	To         string   `json:"to"`
+	MessageID  string   `json:"messageId,omitempty"`
+	Status     string   `json:"status"`
+	Suppressed []string `json:"suppressed,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// FailedStatus represent status of failed batch recipient
+const FailedStatus = "FAILED"
+
+// SuppressedStatus represent status of suppressed batch recipient (as the send status)
+const SuppressedStatus = "SUPPRESSED"
+
+// Add add the sending result of one batch recipient, counted by its status
+func (r *SendBatchResponse) Add(result *SendBatchResult) {
+	switch result.Status {
+	case FailedStatus:
+		r.Failed++
+	case SuppressedStatus:
+		r.Suppressed++
+	default:
+		r.Succeeded++
+	}
+	r.Results = append(r.Results, result)
+}
+
+// ToJSON covert to JSON
+func (r *SendBatchResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,21 @@
+package batch
+
+import (
+	"testing"
+
+	domSchemaMessage "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendBatchResponse_Add(t *testing.T) {
+	res := &SendBatchResponse{Total: 4}
+	res.Add(&SendBatchResult{Index: 0, Status: "SENT.SYNC"})
+	res.Add(&SendBatchResult{Index: 1, Status: "QUEUED"})
+	res.Add(&SendBatchResult{Index: 2, Status: domSchemaMessage.SuppressedSendStatus})
+	res.Add(&SendBatchResult{Index: 3, Status: FailedStatus, Error: "Empty recipient"})
+
+	assert.Equal(t, 2, res.Succeeded)
+	assert.Equal(t, 1, res.Suppressed)
+	assert.Equal(t, 1, res.Failed)
+	assert.Len(t, res.Results, 4)
+}
@@ -0,0 +1,118 @@
+package batch
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+)
+
+const (
+	// CSVColumnEmail represent recipient email column on mail merge csv file
+	CSVColumnEmail = "email"
+	// CSVColumnName represent recipient name column on mail merge csv file
+	CSVColumnName = "name"
+)
+
+// SendBatchCSVRequest represent SendBatchCSVRequest (mail merge from csv file)
+type SendBatchCSVRequest struct {
+	TemplateCode   string                      `json:"templateCode"`
+	From           *domSchemaEmail.MailAddress `json:"from"`
+	ProcessingType string                      `json:"processingType"`
+
+	// Columns are csv header, other than email & name, each column is a templateData key
+	Columns    []string          `json:"columns"`
+	Recipients []*BatchRecipient `json:"-"`
+}
+
+// ParseCSV parse mail merge csv content (first row is header) into Columns and Recipients
+func (r *SendBatchCSVRequest) ParseCSV(f io.Reader) error {
+	reader := csv.NewReader(f)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return fmt.Errorf("Empty CSV file")
+	}
+	if err != nil {
+		return fmt.Errorf("Invalid CSV file: %s", err.Error())
+	}
+
+	r.Columns = nil
+	for idx, col := range header {
+		col = strings.TrimSpace(col)
+		if idx == 0 {
+			// remove utf-8 BOM (spreadsheet export)
+			col = strings.TrimPrefix(col, "\ufeff")
+		}
+		if lc := strings.ToLower(col); lc == CSVColumnEmail || lc == CSVColumnName {
+			col = lc
+		}
+		r.Columns = append(r.Columns, col)
+	}
+
+	r.Recipients = nil
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("Invalid CSV file: %s", err.Error())
+		}
+		if r.isEmptyRow(row) {
+			continue
+		}
+
+		rcpt := &BatchRecipient{
+			To:           &domSchemaEmail.MailAddress{},
+			TemplateData: make(map[string]interface{}),
+		}
+		for idx, col := range r.Columns {
+			val := strings.TrimSpace(row[idx])
+			switch col {
+			case CSVColumnEmail:
+				rcpt.To.Email = val
+			case CSVColumnName:
+				rcpt.To.Name = val
+			default:
+				rcpt.TemplateData[col] = val
+			}
+		}
+		r.Recipients = append(r.Recipients, rcpt)
+	}
+
+	return nil
+}
+
+// MissingColumns list template variables which are not available on csv columns
+func (r *SendBatchCSVRequest) MissingColumns(templateVariables []string) []string {
+	var missing []string
+	for _, v := range templateVariables {
+		if !r.HasColumn(v) {
+			missing = append(missing, v)
+		}
+	}
+	return missing
+}
+
+// HasColumn check if column exist
+func (r *SendBatchCSVRequest) HasColumn(col string) bool {
+	for _, c := range r.Columns {
+		if c == col {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *SendBatchCSVRequest) isEmptyRow(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
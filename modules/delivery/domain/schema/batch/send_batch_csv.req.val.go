@@ -0,0 +1,54 @@
+package batch
+
+import (
+	"fmt"
+	"strings"
+
+	domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validate SendBatchCSVRequest
+func (r *SendBatchCSVRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.TemplateCode, validation.Required),
+		validation.Field(&r.From, validation.Required),
+		validation.Field(&r.ProcessingType, validation.Required, validation.In(string(domSchemaEmail.SYNCProcess), string(domSchemaEmail.ASYNCProcess))),
+		validation.Field(&r.Columns, validation.Required, validation.By(r.requiredColumns)),
+		validation.Field(&r.Recipients, validation.Required, validation.Length(1, MaxBatchRecipients), validation.Skip),
+	)
+}
+
+// ValidateTemplateVariables make sure every template variable has its own csv column,
+// except the variables provided on sending (e.g: unsubscribe link)
+func (r *SendBatchCSVRequest) ValidateTemplateVariables(templateVariables, providedVariables []string) error {
+	provided := make(map[string]bool, len(providedVariables))
+	for _, v := range providedVariables {
+		provided[v] = true
+	}
+	var required []string
+	for _, v := range templateVariables {
+		if !provided[v] {
+			required = append(required, v)
+		}
+	}
+	if missing := r.MissingColumns(required); len(missing) > 0 {
+		return validation.Errors{
+			"columns": fmt.Errorf("missing column(s) required by email template: %s", strings.Join(missing, ", ")),
+		}
+	}
+	return nil
+}
+
+func (r *SendBatchCSVRequest) requiredColumns(value interface{}) error {
+	var missing []string
+	for _, col := range []string{CSVColumnEmail, CSVColumnName} {
+		if !r.HasColumn(col) {
+			missing = append(missing, col)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required column(s): %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package batch
+
+import (
+	"strings"
+	"testing"
+
+	domSchemaTS "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/template_setting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendBatchCSVRequest_ParseCSV(t *testing.T) {
+	csvContent := "\ufeffEmail,Name,Header.Name,Body.ActivationURL\n" +
+		"john.doe@domain.tld,John Doe,John,https://domain.tld/activate/1\n" +
+		",,,\n" +
+		"jane.doe@domain.tld, Jane Doe ,Jane,https://domain.tld/activate/2\n"
+
+	req := new(SendBatchCSVRequest)
+	if assert.NoError(t, req.ParseCSV(strings.NewReader(csvContent))) {
+		assert.Equal(t, []string{"email", "name", "Header.Name", "Body.ActivationURL"}, req.Columns)
+		if assert.Len(t, req.Recipients, 2) {
+			assert.Equal(t, "jane.doe@domain.tld", req.Recipients[1].To.Email)
+			assert.Equal(t, "Jane Doe", req.Recipients[1].To.Name)
+			assert.Equal(t, "https://domain.tld/activate/2", req.Recipients[1].TemplateData["Body.ActivationURL"])
+			assert.NotContains(t, req.Recipients[1].TemplateData, "email")
+		}
+		assert.Equal(t, []string{"Footer.Name"}, req.MissingColumns([]string{"Header.Name", "Footer.Name"}))
+	}
+
+	assert.Error(t, req.ParseCSV(strings.NewReader("")))
+	assert.Error(t, req.ParseCSV(strings.NewReader("email,name\njohn.doe@domain.tld\n")))
+}
+
+func TestSendBatchCSVRequest_Validate(t *testing.T) {
+	req := new(SendBatchCSVRequest)
+	req.TemplateCode = "activate-registration-html"
+	req.ProcessingType = "SYNC"
+
+	assert.NoError(t, req.ParseCSV(strings.NewReader("email,Header.Name\njohn.doe@domain.tld,John\n")))
+	assert.Error(t, req.Validate())
+
+	assert.Error(t, req.ValidateTemplateVariables([]string{"Header.Name", "Footer.Name"}, nil))
+	assert.NoError(t, req.ValidateTemplateVariables([]string{"Header.Name"}, nil))
+}
+
+func TestSendBatchCSVRequest_ValidateTemplateVariables_Provided(t *testing.T) {
+	tpl := `{{define "T"}}Dear {{index . "Header.Name"}},
+<a href="{{index . "Unsubscribe.URL"}}">Unsubscribe</a>{{end}}`
+
+	vars, err := TemplateVariables(tpl)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	req := new(SendBatchCSVRequest)
+	assert.NoError(t, req.ParseCSV(strings.NewReader("email,name,Header.Name\njohn.doe@domain.tld,John Doe,John\n")))
+
+	// marketing template: the unsubscribe link is provided on sending
+	marketing := &domSchemaTS.TemplateSetting{Category: domSchemaTS.MarketingCategory}
+	assert.NoError(t, req.ValidateTemplateVariables(vars, marketing.ProvidedTemplateData()))
+
+	// transactional template: no unsubscribe link, the column is required
+	transactional := domSchemaTS.NewTemplateSetting("activate-registration-html")
+	assert.Error(t, req.ValidateTemplateVariables(vars, transactional.ProvidedTemplateData()))
+}
+
+func TestTemplateVariables(t *testing.T) {
+	tpl := `{{define "T"}}Dear {{index . "Header.Name"}},
+{{if index . "Body.ActivationURL"}}<a href="{{index . "Body.ActivationURL"}}">Activate</a>{{end}}
+{{range .Items}}{{.Title}}{{end}}
+{{index . "Footer.Name"}}{{end}}`
+
+	vars, err := TemplateVariables(tpl)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"Body.ActivationURL", "Footer.Name", "Header.Name", "Items"}, vars)
+	}
+
+	_, err = TemplateVariables(`{{define "T"}}{{.Name}`)
+	assert.Error(t, err)
+
+	// subject, body and text body
+	vars, err = TemplateVariables(`Welcome {{index . "Header.Name"}}`, tpl, "", `{{define "T"}}Dear {{index . "Header.Name"}}, {{.Signature}}{{end}}`)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"Body.ActivationURL", "Footer.Name", "Header.Name", "Items", "Signature"}, vars)
+	}
+}
@@ -0,0 +1,92 @@
+package batch
+
+import (
+	"sort"
+	"text/template"
+	"text/template/parse"
+)
+
+// TemplateVariables list the templateData keys used by the templates of an email template (subject, body, text body),
+// e.g: `{{index . "Header.Name"}}` or `{{.Name}}` (only on the root data context)
+func TemplateVariables(tpls ...string) ([]string, error) {
+	found := make(map[string]bool)
+	for _, tpl := range tpls {
+		if tpl == "" {
+			continue
+		}
+		t, err := template.New("vars").Parse(tpl)
+		if err != nil {
+			return nil, err
+		}
+		for _, tmpl := range t.Templates() {
+			if tmpl.Tree != nil && tmpl.Tree.Root != nil {
+				walkTemplateNode(tmpl.Tree.Root, found)
+			}
+		}
+	}
+
+	vars := make([]string, 0, len(found))
+	for k := range found {
+		vars = append(vars, k)
+	}
+	sort.Strings(vars)
+
+	return vars, nil
+}
+
+func walkTemplateNode(node parse.Node, found map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			walkTemplateNode(c, found)
+		}
+	case *parse.ActionNode:
+		walkTemplatePipe(n.Pipe, found)
+	case *parse.IfNode:
+		walkTemplatePipe(n.Pipe, found)
+		walkTemplateNode(n.List, found)
+		walkTemplateNode(n.ElseList, found)
+	case *parse.RangeNode:
+		// dot is changed inside range body, only the pipeline uses root data
+		walkTemplatePipe(n.Pipe, found)
+		walkTemplateNode(n.ElseList, found)
+	case *parse.WithNode:
+		// dot is changed inside with body, only the pipeline uses root data
+		walkTemplatePipe(n.Pipe, found)
+		walkTemplateNode(n.ElseList, found)
+	case *parse.TemplateNode:
+		walkTemplatePipe(n.Pipe, found)
+	}
+}
+
+func walkTemplatePipe(pipe *parse.PipeNode, found map[string]bool) {
+	if pipe == nil {
+		return
+	}
+	for _, cmd := range pipe.Cmds {
+		// {{index . "Key"}}
+		if len(cmd.Args) >= 3 {
+			if ident, ok := cmd.Args[0].(*parse.IdentifierNode); ok && ident.Ident == "index" {
+				if _, ok := cmd.Args[1].(*parse.DotNode); ok {
+					if key, ok := cmd.Args[2].(*parse.StringNode); ok {
+						found[key.Text] = true
+					}
+				}
+			}
+		}
+		for _, arg := range cmd.Args {
+			switch a := arg.(type) {
+			case *parse.FieldNode:
+				// {{.Key}} or {{.Key.Sub}} -> Key
+				if len(a.Ident) > 0 {
+					found[a.Ident[0]] = true
+				}
+			case *parse.PipeNode:
+				walkTemplatePipe(a, found)
+			}
+		}
+	}
+}
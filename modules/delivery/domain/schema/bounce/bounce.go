@@ -0,0 +1,32 @@
+package bounce
+
+// Type represent bounce type
+type Type string
+
+const (
+	// HardBounce permanent delivery failure (the message record is set to BOUNCED)
+	HardBounce Type = "HARD"
+	// SoftBounce transient delivery failure (e.g: mailbox full)
+	SoftBounce Type = "SOFT"
+	// Complaint recipient reported the message as spam (feedback report)
+	Complaint Type = "COMPLAINT"
+)
+
+// ReportType represent ingested report type
+type ReportType string
+
+const (
+	// DeliveryStatusReport RFC 3464 delivery status notification
+	DeliveryStatusReport ReportType = "DSN"
+	// FeedbackReport RFC 5965 abuse reporting format
+	FeedbackReport ReportType = "ARF"
+)
+
+// Bounce type (bounce or complaint of one recipient)
+type Bounce struct {
+	Recipient      string `json:"recipient"`
+	Type           Type   `json:"type"`
+	Action         string `json:"action,omitempty"`
+	Status         string `json:"status,omitempty"`
+	DiagnosticCode string `json:"diagnosticCode,omitempty"`
+}
@@ -0,0 +1,9 @@
+package bounce
+
+// IngestBounceRequest type (parsed delivery status notification or feedback report)
+type IngestBounceRequest struct {
+	ReportType      ReportType `json:"reportType"`
+	MessageIDHeader string     `json:"messageIdHeader"` // Message-ID of the original message
+	FeedbackType    string     `json:"feedbackType,omitempty"`
+	Bounces         []*Bounce  `json:"bounces"`
+}
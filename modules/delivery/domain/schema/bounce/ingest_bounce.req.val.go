@@ -0,0 +1,14 @@
+package bounce
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validate IngestBounceRequest
+func (r *IngestBounceRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ReportType, validation.Required, validation.In(DeliveryStatusReport, FeedbackReport)),
+		validation.Field(&r.MessageIDHeader, validation.Length(0, 255)),
+		validation.Field(&r.Bounces, validation.Required.Error("no failed recipient in the report")),
+	)
+}
@@ -0,0 +1,22 @@
+package bounce
+
+import "encoding/json"
+
+// IngestBounceResponse type
+type IngestBounceResponse struct {
+	ReportType      ReportType `json:"reportType"`
+	MessageID       string     `json:"messageId,omitempty"` // message record ID, when matched
+	MessageIDHeader string     `json:"messageIdHeader"`
+	Matched         bool       `json:"matched"`
+	Bounces         []*Bounce  `json:"bounces"`
+	Suppressed      []string   `json:"suppressed,omitempty"` // addresses added to the suppression list
+}
+
+// ToJSON covert to JSON
+func (r *IngestBounceResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
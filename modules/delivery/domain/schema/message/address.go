@@ -0,0 +1,132 @@
+package message
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+
+	domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"golang.org/x/net/idna"
+)
+
+// NormalizeEmail parse email address (RFC 5322 addr-spec, without display name),
+// the domain is normalized to lower case ASCII (IDNA punycode), e.g: `john@bücher.example` -> `john@xn--bcher-kva.example`
+func NormalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return "", fmt.Errorf("cannot be blank")
+	}
+	a, err := mail.ParseAddress(email)
+	if err != nil {
+		return "", fmt.Errorf("must be a valid email address")
+	}
+	if a.Name != "" || a.Address != strings.Trim(email, "<>") {
+		return "", fmt.Errorf("must be an email address without display name")
+	}
+
+	at := strings.LastIndex(a.Address, "@")
+	local, domain := a.Address[:at], a.Address[at+1:]
+	for _, c := range local {
+		if c > 127 {
+			return "", fmt.Errorf("must have an ASCII local part (before `@`)")
+		}
+	}
+	domain, err = NormalizeDomain(domain)
+	if err != nil {
+		return "", err
+	}
+	return local + "@" + domain, nil
+}
+
+// NormalizeDomain normalize domain name to lower case ASCII (IDNA punycode)
+func NormalizeDomain(domain string) (string, error) {
+	d, err := idna.Lookup.ToASCII(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+	if err != nil || !strings.Contains(d, ".") {
+		return "", fmt.Errorf("must have a valid domain name")
+	}
+	return strings.ToLower(d), nil
+}
+
+// isBlockedDomain check whether the domain (or one of its parent domains) of a normalized email address is in the blocklist
+func isBlockedDomain(email string, blocklist map[string]bool) bool {
+	if len(blocklist) == 0 {
+		return false
+	}
+	domain := email[strings.LastIndex(email, "@")+1:]
+	for {
+		if blocklist[domain] {
+			return true
+		}
+		dot := strings.Index(domain, ".")
+		if dot < 0 {
+			return false
+		}
+		domain = domain[dot+1:]
+	}
+}
+
+// ValidateAddresses validate and normalize the addresses (from, to, cc, bcc, replyTo and calendar event) of the request:
+// RFC 5322 parsing, IDNA domain normalization and the disposable domain blocklist (recipients only).
+// Duplicate recipients are removed (to, then cc, then bcc wins), errors are field-level, e.g: `cc[1].email`
+func (r *SendMessageRequest) ValidateAddresses(disposableDomains map[string]bool) error {
+	errs := validation.Errors{}
+
+	normalize := func(field string, m *domSchemaEmail.MailAddress, recipient bool) {
+		if m == nil {
+			return
+		}
+		email, err := NormalizeEmail(m.Email)
+		if err != nil {
+			errs[field+".email"] = err
+			return
+		}
+		if recipient && isBlockedDomain(email, disposableDomains) {
+			errs[field+".email"] = fmt.Errorf("disposable email domain is not allowed")
+			return
+		}
+		m.Email = email
+	}
+	normalize("from", r.From, false)
+	normalize("to", r.To, true)
+	normalize("replyTo", r.ReplyTo, false)
+	for idx, v := range r.CC {
+		normalize(fmt.Sprintf("cc[%d]", idx), v, true)
+	}
+	for idx, v := range r.BCC {
+		normalize(fmt.Sprintf("bcc[%d]", idx), v, true)
+	}
+	if r.CalendarEvent != nil {
+		normalize("calendarEvent.organizer", r.CalendarEvent.Organizer, false)
+		for idx, v := range r.CalendarEvent.Attendees {
+			normalize(fmt.Sprintf("calendarEvent.attendees[%d]", idx), v, false)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	// duplicate recipients
+	seen := make(map[string]bool)
+	if r.To != nil {
+		seen[strings.ToLower(r.To.Email)] = true
+	}
+	dedupe := func(ms []*domSchemaEmail.MailAddress) []*domSchemaEmail.MailAddress {
+		if ms == nil {
+			return nil
+		}
+		kept := make([]*domSchemaEmail.MailAddress, 0, len(ms))
+		for _, v := range ms {
+			if v == nil || seen[strings.ToLower(v.Email)] {
+				continue
+			}
+			seen[strings.ToLower(v.Email)] = true
+			kept = append(kept, v)
+		}
+		return kept
+	}
+	r.CC = dedupe(r.CC)
+	r.BCC = dedupe(r.BCC)
+
+	return nil
+}
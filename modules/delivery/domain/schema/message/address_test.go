@@ -0,0 +1,78 @@
+package message
+
+import (
+	"testing"
+
+	domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	email, err := NormalizeEmail(" John.Doe@Domain.TLD ")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "John.Doe@domain.tld", email)
+	}
+	email, err = NormalizeEmail("john@bücher.example")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "john@xn--bcher-kva.example", email)
+	}
+
+	_, err = NormalizeEmail("john.doe")
+	assert.Error(t, err)
+	_, err = NormalizeEmail("John Doe <john.doe@domain.tld>")
+	assert.Error(t, err)
+	_, err = NormalizeEmail("jöhn@domain.tld")
+	assert.Error(t, err)
+	_, err = NormalizeEmail("john@localhost")
+	assert.Error(t, err)
+}
+
+func TestSendMessageRequest_ValidateAddresses(t *testing.T) {
+	disposable := map[string]bool{"mailinator.com": true}
+
+	req := &SendMessageRequest{
+		From: &domSchemaEmail.MailAddress{Email: "no-reply@domain.tld", Name: "No Reply"},
+		To:   &domSchemaEmail.MailAddress{Email: "john.doe@domain.tld", Name: "John Doe"},
+		CC: []*domSchemaEmail.MailAddress{
+			{Email: "jane.doe@domain.tld", Name: "Jane Doe"},
+			{Email: "jane.doe@domain", Name: "Jane Doe"},
+		},
+		BCC: []*domSchemaEmail.MailAddress{
+			{Email: "audit@sub.mailinator.com", Name: "Audit"},
+		},
+	}
+	err := req.ValidateAddresses(disposable)
+	if assert.Error(t, err) {
+		errs, ok := err.(validation.Errors)
+		if assert.True(t, ok) {
+			assert.Contains(t, errs, "cc[1].email")
+			assert.Contains(t, errs, "bcc[0].email")
+			assert.NotContains(t, errs, "cc[0].email")
+			assert.Len(t, errs, 2)
+		}
+	}
+
+	// duplicates across to, cc and bcc
+	req = &SendMessageRequest{
+		From: &domSchemaEmail.MailAddress{Email: "no-reply@domain.tld", Name: "No Reply"},
+		To:   &domSchemaEmail.MailAddress{Email: "john.doe@Domain.tld", Name: "John Doe"},
+		CC: []*domSchemaEmail.MailAddress{
+			{Email: "john.doe@domain.tld", Name: "John Doe"},
+			{Email: "jane.doe@domain.tld", Name: "Jane Doe"},
+		},
+		BCC: []*domSchemaEmail.MailAddress{
+			{Email: "Jane.Doe@DOMAIN.tld", Name: "Jane Doe"},
+			{Email: "audit@domain.tld", Name: "Audit"},
+		},
+	}
+	if assert.NoError(t, req.ValidateAddresses(disposable)) {
+		assert.Equal(t, "john.doe@domain.tld", req.To.Email)
+		if assert.Len(t, req.CC, 1) {
+			assert.Equal(t, "jane.doe@domain.tld", req.CC[0].Email)
+		}
+		if assert.Len(t, req.BCC, 1) {
+			assert.Equal(t, "audit@domain.tld", req.BCC[0].Email)
+		}
+	}
+}
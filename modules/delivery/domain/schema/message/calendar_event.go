@@ -0,0 +1,111 @@
+package message
+
+import (
+	"fmt"
+	"time"
+
+	domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// CalendarMethod represent iTIP (RFC 5546) method of a calendar event
+type CalendarMethod string
+
+const (
+	// RequestCalendarMethod invite to (or update) the event
+	RequestCalendarMethod CalendarMethod = "REQUEST"
+	// CancelCalendarMethod cancel the event
+	CancelCalendarMethod CalendarMethod = "CANCEL"
+)
+
+// calendarLocalTimeLayout date-time without offset, interpreted in the event timezone
+const calendarLocalTimeLayout = "2006-01-02T15:04:05"
+
+// CalendarEvent represent meeting invite (iCalendar VEVENT) sent with the message, as text/calendar part and .ics attachment
+type CalendarEvent struct {
+	UID         string                        `json:"uid"`         // unique event ID, the same UID updates or cancels the event
+	Method      string                        `json:"method"`      // REQUEST (default) or CANCEL
+	Sequence    int                           `json:"sequence"`    // revision, increased on each update or cancel of the event
+	Summary     string                        `json:"summary"`     // optional: the message subject
+	Description string                        `json:"description"` // optional
+	Start       string                        `json:"start"`       // RFC 3339, or local date-time (`2006-01-02T15:04:05`) in timezone
+	End         string                        `json:"end"`         // RFC 3339, or local date-time (`2006-01-02T15:04:05`) in timezone
+	Timezone    string                        `json:"timezone"`    // optional: IANA time zone (e.g: `Asia/Jakarta`), default: UTC
+	Location    string                        `json:"location"`    // optional
+	Organizer   *domSchemaEmail.MailAddress   `json:"organizer"`   // optional: the sender (from)
+	Attendees   []*domSchemaEmail.MailAddress `json:"attendees"`   // optional: the recipient (to) and cc
+}
+
+// Validate CalendarEvent
+func (e *CalendarEvent) Validate() error {
+	loc, errLoc := e.location()
+	return validation.ValidateStruct(e,
+		validation.Field(&e.UID, validation.Required, validation.Length(1, 255)),
+		validation.Field(&e.Method, validation.In(string(RequestCalendarMethod), string(CancelCalendarMethod))),
+		validation.Field(&e.Sequence, validation.Min(0)),
+		validation.Field(&e.Summary, validation.Length(0, 255)),
+		validation.Field(&e.Timezone, validation.By(func(interface{}) error { return errLoc })),
+		validation.Field(&e.Start, validation.Required, validation.By(calendarTime(loc))),
+		validation.Field(&e.End, validation.Required, validation.By(calendarTime(loc)), validation.By(func(interface{}) error {
+			start, end, err := e.Times()
+			if err == nil && !end.After(start) {
+				return fmt.Errorf("must be after start")
+			}
+			return nil
+		})),
+		validation.Field(&e.Location, validation.Length(0, 255)),
+	)
+}
+
+// GetMethod get Method (or default value)
+func (e *CalendarEvent) GetMethod() CalendarMethod {
+	if e.Method == "" {
+		return RequestCalendarMethod
+	}
+	return CalendarMethod(e.Method)
+}
+
+// Times get parsed start and end of the event
+func (e *CalendarEvent) Times() (start, end time.Time, err error) {
+	loc, err := e.location()
+	if err != nil {
+		return
+	}
+	if start, err = parseCalendarTime(e.Start, loc); err != nil {
+		return
+	}
+	end, err = parseCalendarTime(e.End, loc)
+	return
+}
+
+func (e *CalendarEvent) location() (*time.Location, error) {
+	if e.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(e.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("must be a valid IANA time zone (e.g: `Asia/Jakarta`)")
+	}
+	return loc, nil
+}
+
+func parseCalendarTime(str string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, str); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation(calendarLocalTimeLayout, str, loc)
+}
+
+// calendarTime check RFC 3339 or local date-time (in the event timezone)
+func calendarTime(loc *time.Location) validation.RuleFunc {
+	return func(value interface{}) error {
+		str, _ := value.(string)
+		if str == "" || loc == nil {
+			return nil
+		}
+		if _, err := parseCalendarTime(str, loc); err != nil {
+			return fmt.Errorf("must be a valid RFC 3339 date-time, or local date-time (`2006-01-02T15:04:05`)")
+		}
+		return nil
+	}
+}
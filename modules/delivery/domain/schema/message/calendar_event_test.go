@@ -0,0 +1,50 @@
+package message
+
+import (
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendarEvent_Validate(t *testing.T) {
+	e := &CalendarEvent{
+		UID:      "meeting-1001@domain.tld",
+		Start:    "2026-10-20T10:00:00",
+		End:      "2026-10-20T11:00:00",
+		Timezone: "Asia/Jakarta",
+	}
+	if assert.NoError(t, e.Validate()) {
+		start, end, err := e.Times()
+		if assert.NoError(t, err) {
+			assert.Equal(t, time.Date(2026, 10, 20, 3, 0, 0, 0, time.UTC), start.UTC())
+			assert.Equal(t, time.Hour, end.Sub(start))
+		}
+		assert.Equal(t, RequestCalendarMethod, e.GetMethod())
+	}
+
+	e = &CalendarEvent{
+		Method:   "DECLINE",
+		Start:    "2026-10-20T11:00:00+07:00",
+		End:      "2026-10-20T10:00:00+07:00",
+		Timezone: "Mars/Olympus_Mons",
+	}
+	err := e.Validate()
+	if assert.Error(t, err) {
+		errs, ok := err.(validation.Errors)
+		if assert.True(t, ok) {
+			assert.Contains(t, errs, "uid")
+			assert.Contains(t, errs, "method")
+			assert.Contains(t, errs, "timezone")
+		}
+	}
+
+	e.Timezone = ""
+	err = e.Validate()
+	if assert.Error(t, err) {
+		errs, _ := err.(validation.Errors)
+		assert.Contains(t, errs, "end")
+		assert.NotContains(t, errs, "start")
+	}
+}
@@ -0,0 +1,6 @@
+package message
+
+// FindMessageRequest type
+type FindMessageRequest struct {
+	ID string `json:"id"`
+}
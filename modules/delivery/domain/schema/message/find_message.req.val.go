@@ -0,0 +1,13 @@
+package message
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	valIs "github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// Validate FindMessageRequest
+func (r *FindMessageRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ID, validation.Required, valIs.UUID),
+	)
+}
@@ -0,0 +1,18 @@
+package message
+
+import "encoding/json"
+
+// FindMessageResponse type
+type FindMessageResponse struct {
+	Query FindMessageRequest `json:"query"`
+	Data  Message            `json:"data"`
+}
+
+// ToJSON covert to JSON
+func (r *FindMessageResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,6 @@
+package message
+
+// FindMessageBodyRequest type
+type FindMessageBodyRequest struct {
+	ID string `json:"id"`
+}
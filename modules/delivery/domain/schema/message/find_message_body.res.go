@@ -0,0 +1,25 @@
+package message
+
+import "encoding/json"
+
+// FindMessageBodyResponse type
+type FindMessageBodyResponse struct {
+	Query FindMessageBodyRequest `json:"query"`
+	Data  MessageBody            `json:"data"`
+}
+
+// MessageBody type (rendered content of a Message)
+type MessageBody struct {
+	ID      string `json:"id"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"` // empty until sent
+}
+
+// ToJSON covert to JSON
+func (r *FindMessageBodyResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,79 @@
+package message
+
+import (
+	"fmt"
+	"net/textproto"
+	"regexp"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Priority represent message priority (X-Priority, Importance and Priority headers)
+type Priority string
+
+const (
+	// HighPriority high priority (X-Priority: 1)
+	HighPriority Priority = "HIGH"
+	// NormalPriority normal priority (default, no priority headers)
+	NormalPriority Priority = "NORMAL"
+	// LowPriority low priority (X-Priority: 5)
+	LowPriority Priority = "LOW"
+)
+
+// CustomHeaderPrefix prefix of custom headers (of the send request)
+const CustomHeaderPrefix = "X-"
+
+// MaxCustomHeaders maximum number of custom headers (of the send request)
+const MaxCustomHeaders = 20
+
+// DeniedHeaders headers which can not be set as custom headers (canonical header key),
+// e.g: set by the service (priority, original recipients), MTAs or spam filters
+var DeniedHeaders = map[string]bool{
+	"Bcc":               true,
+	"Received":          true,
+	"Return-Path":       true,
+	"X-Original-To":     true,
+	"X-Original-Cc":     true,
+	"X-Original-Bcc":    true,
+	"X-Forwarded-To":    true,
+	"X-Forwarded-For":   true,
+	"X-Originating-Ip":  true,
+	"X-Priority":        true,
+	"X-Msmail-Priority": true,
+	"X-Spam-Status":     true,
+	"X-Spam-Flag":       true,
+	"X-Spam-Score":      true,
+}
+
+// headerNameRegexp represent valid header field name (RFC 5322: printable US-ASCII except colon)
+var headerNameRegexp = regexp.MustCompile(`^[!-9;-~]+$`)
+
+// validCustomHeaders validate custom headers: `X-` header names (not denied), single line values
+func validCustomHeaders(value interface{}) error {
+	headers, _ := value.(map[string]string)
+	if len(headers) > MaxCustomHeaders {
+		return fmt.Errorf("must have at most %d headers", MaxCustomHeaders)
+	}
+
+	errs := validation.Errors{}
+	for name, v := range headers {
+		key := textproto.CanonicalMIMEHeaderKey(name)
+		switch {
+		case !headerNameRegexp.MatchString(name):
+			errs[name] = fmt.Errorf("invalid header name")
+		case DeniedHeaders[key]:
+			errs[name] = fmt.Errorf("header is not allowed")
+		case !strings.HasPrefix(key, CustomHeaderPrefix) || len(key) == len(CustomHeaderPrefix):
+			errs[name] = fmt.Errorf("must be an `%s` header", CustomHeaderPrefix)
+		case strings.ContainsAny(v, "\r\n"):
+			errs[name] = fmt.Errorf("must be a single line value")
+		case len(v) > 998:
+			errs[name] = fmt.Errorf("must be at most 998 characters")
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
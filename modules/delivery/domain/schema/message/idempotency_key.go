@@ -0,0 +1,19 @@
+package message
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// IdempotencyKey represent Idempotency-Key of send request (per identity)
+type IdempotencyKey struct {
+	Username    string `json:"username"`
+	Key         string `json:"key"`
+	Fingerprint string `json:"fingerprint"` // request payload fingerprint
+}
+
+// Validate validate IdempotencyKey
+func (k *IdempotencyKey) Validate() error {
+	return validation.Errors{
+		"Idempotency-Key": validation.Validate(k.Key, validation.Required, validation.Length(1, 255)),
+	}.Filter()
+}
@@ -0,0 +1,23 @@
+package message
+
+import (
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyKey_Validate(t *testing.T) {
+	k := &IdempotencyKey{Username: "john.doe", Key: "a1b2c3d4-order-1001"}
+	assert.NoError(t, k.Validate())
+
+	k.Key = strings.Repeat("x", 256)
+	err := k.Validate()
+	if assert.Error(t, err) {
+		errs, ok := err.(validation.Errors)
+		if assert.True(t, ok) {
+			assert.Contains(t, errs, "Idempotency-Key")
+		}
+	}
+}
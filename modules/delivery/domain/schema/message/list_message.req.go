@@ -0,0 +1,68 @@
+package message
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultListLimit default number of Messages per page
+	DefaultListLimit = 50
+	// MaxListLimit maximum number of Messages per page
+	MaxListLimit = 200
+)
+
+// ListMessageRequest type
+type ListMessageRequest struct {
+	Recipient    string `json:"recipient"`    // optional: to, cc or bcc email address
+	TemplateCode string `json:"templateCode"` // optional
+	Status       string `json:"status"`       // optional: QUEUED, SENDING, SENT, FAILED or BOUNCED
+	SentBy       string `json:"sentBy"`       // optional: sender identity (username)
+	Since        string `json:"since"`        // optional: RFC 3339 date-time, created at or after
+	Until        string `json:"until"`        // optional: RFC 3339 date-time, created before
+	Cursor       string `json:"cursor"`       // optional: nextCursor of the previous page
+	Limit        int    `json:"limit"`        // optional: default 50, max 200
+}
+
+// GetLimit get page limit (or the default)
+func (r *ListMessageRequest) GetLimit() int {
+	if r.Limit <= 0 {
+		return DefaultListLimit
+	}
+	return r.Limit
+}
+
+// GetSince get Since as time (zero when empty)
+func (r *ListMessageRequest) GetSince() time.Time {
+	t, _ := time.Parse(time.RFC3339, r.Since)
+	return t
+}
+
+// GetUntil get Until as time (zero when empty)
+func (r *ListMessageRequest) GetUntil() time.Time {
+	t, _ := time.Parse(time.RFC3339, r.Until)
+	return t
+}
+
+// GetCursor get last (internal) record id of the previous page, 0 when empty
+func (r *ListMessageRequest) GetCursor() (uint64, error) {
+	if r.Cursor == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(r.Cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	id, err := strconv.ParseUint(string(b), 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	return id, nil
+}
+
+// EncodeCursor encode (internal) record id as opaque page cursor
+func EncodeCursor(id uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(id, 10)))
+}
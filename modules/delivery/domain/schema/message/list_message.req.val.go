@@ -0,0 +1,38 @@
+package message
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validate ListMessageRequest
+func (r *ListMessageRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Recipient, validation.Length(0, 255)),
+		validation.Field(&r.TemplateCode, validation.Length(0, 100)),
+		validation.Field(&r.Status, validation.In(string(QueuedStatus), string(SendingStatus), string(SentStatus), string(FailedStatus), string(BouncedStatus))),
+		validation.Field(&r.SentBy, validation.Length(0, 255)),
+		validation.Field(&r.Since, validation.Date(time.RFC3339).Error("must be a valid RFC 3339 date-time")),
+		validation.Field(&r.Until, validation.Date(time.RFC3339).Error("must be a valid RFC 3339 date-time"), validation.By(r.afterSince)),
+		validation.Field(&r.Cursor, validation.By(r.validCursor)),
+		validation.Field(&r.Limit, validation.Min(0), validation.Max(MaxListLimit)),
+	)
+}
+
+func (r *ListMessageRequest) afterSince(value interface{}) error {
+	since, until := r.GetSince(), r.GetUntil()
+	if since.IsZero() || until.IsZero() {
+		return nil
+	}
+	if !until.After(since) {
+		return errors.New("must be after since")
+	}
+	return nil
+}
+
+func (r *ListMessageRequest) validCursor(value interface{}) error {
+	_, err := r.GetCursor()
+	return err
+}
@@ -0,0 +1,53 @@
+package message
+
+import (
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListMessageRequest_Cursor(t *testing.T) {
+	r := &ListMessageRequest{}
+	id, err := r.GetCursor()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), id)
+
+	r.Cursor = EncodeCursor(1234)
+	id, err = r.GetCursor()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1234), id)
+
+	r.Cursor = "not-a-cursor"
+	_, err = r.GetCursor()
+	assert.Error(t, err)
+}
+
+func TestListMessageRequest_Validate(t *testing.T) {
+	r := &ListMessageRequest{
+		Status: string(SentStatus),
+		Since:  "2026-10-01T00:00:00Z",
+		Until:  "2026-10-18T00:00:00Z",
+		Cursor: EncodeCursor(10),
+	}
+	assert.NoError(t, r.Validate())
+	assert.Equal(t, DefaultListLimit, r.GetLimit())
+
+	r = &ListMessageRequest{
+		Status: "DELIVERED",
+		Since:  "2026-10-18T00:00:00Z",
+		Until:  "2026-10-01T00:00:00Z",
+		Cursor: "xyz",
+		Limit:  MaxListLimit + 1,
+	}
+	err := r.Validate()
+	if assert.Error(t, err) {
+		errs, ok := err.(validation.Errors)
+		if assert.True(t, ok) {
+			assert.Contains(t, errs, "status")
+			assert.Contains(t, errs, "until")
+			assert.Contains(t, errs, "cursor")
+			assert.Contains(t, errs, "limit")
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package message
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// MessageSummary type (summary row of a Message, without recipients detail and body)
+type MessageSummary struct {
+	ID             string     `json:"id"`
+	TemplateCode   string     `json:"templateCode"`
+	ToEmail        string     `json:"toEmail"`
+	Subject        string     `json:"subject"`
+	ProcessingType string     `json:"processingType"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	SentBy         string     `json:"sentBy"`
+	CreatedAt      *time.Time `json:"createdAt"`
+	SentAt         *time.Time `json:"sentAt"`
+}
+
+// ListMessageResponse type
+type ListMessageResponse struct {
+	Query ListMessageRequest      `json:"query"`
+	Data  ListMessageResponseData `json:"data"`
+}
+
+// ListMessageResponseData type
+type ListMessageResponseData struct {
+	Count      int64             `json:"count"`
+	Messages   []*MessageSummary `json:"messages"`
+	NextCursor string            `json:"nextCursor,omitempty"` // empty on the last page
+}
+
+// ToJSON covert to JSON
+func (r *ListMessageResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
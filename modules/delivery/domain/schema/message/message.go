@@ -0,0 +1,55 @@
+package message
+
+import "time"
+
+// MessageStatus represent Message (record) delivery status
+type MessageStatus string
+
+const (
+	// QueuedStatus waiting in the outbox (ASYNC, scheduled or retry)
+	QueuedStatus MessageStatus = "QUEUED"
+	// SendingStatus being sent to SMTP Server
+	SendingStatus MessageStatus = "SENDING"
+	// SentStatus accepted by SMTP Server
+	SentStatus MessageStatus = "SENT"
+	// FailedStatus delivery failed (final)
+	FailedStatus MessageStatus = "FAILED"
+	// BouncedStatus bounced by the recipient mail server, after it was sent
+	BouncedStatus MessageStatus = "BOUNCED"
+)
+
+// Message type (message record of a send request)
+type Message struct {
+	ID               string          `json:"id"`
+	MessageIDHeader  string          `json:"messageIdHeader"` // RFC 5322 Message-ID
+	TemplateCode     string          `json:"templateCode"`
+	FromEmail        string          `json:"fromEmail"`
+	FromName         string          `json:"fromName"`
+	ToEmail          string          `json:"toEmail"`
+	ToName           string          `json:"toName"`
+	CC               string          `json:"cc,omitempty"`
+	BCC              string          `json:"bcc,omitempty"`
+	Subject          string          `json:"subject"`
+	ProcessingType   string          `json:"processingType"`
+	Status           string          `json:"status"`
+	Attempts         int             `json:"attempts"`
+	LastSMTPResponse string          `json:"lastSmtpResponse"`
+	SendAt           *time.Time      `json:"sendAt,omitempty"`
+	QueuedAt         *time.Time      `json:"queuedAt"`
+	SendingAt        *time.Time      `json:"sendingAt"`
+	SentAt           *time.Time      `json:"sentAt"`
+	FailedAt         *time.Time      `json:"failedAt"`
+	BouncedAt        *time.Time      `json:"bouncedAt"`
+	FirstOpenedAt    *time.Time      `json:"firstOpenedAt"`
+	OpenCount        int             `json:"openCount"`
+	SentBy           string          `json:"sentBy"`
+	CreatedAt        *time.Time      `json:"createdAt"`
+	Events           []*MessageEvent `json:"events,omitempty"`
+}
+
+// MessageEvent type (status transition of a Message)
+type MessageEvent struct {
+	Status       string    `json:"status"`
+	SMTPResponse string    `json:"smtpResponse,omitempty"`
+	OccurredAt   time.Time `json:"occurredAt"`
+}
@@ -0,0 +1,40 @@
+package message
+
+import "time"
+
+// OutboxMessage represent a claimed outbox message (ASYNC or scheduled send, due to be delivered)
+type OutboxMessage struct {
+	ID          string              `json:"id"`
+	Request     *SendMessageRequest `json:"request"`
+	Attempts    int                 `json:"attempts"`
+	MaxAttempts int                 `json:"maxAttempts"`
+	ScheduledBy string              `json:"scheduledBy"`
+	IPAddress   string              `json:"ipAddress"`
+}
+
+// RetryPolicy represent outbox delivery retry policy (exponential backoff)
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Backoff get delay before the next attempt, after `attempts` failed attempts
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	d := p.InitialBackoff
+	for n := 1; n < attempts; n++ {
+		d *= 2
+		if p.MaxBackoff > 0 && d >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return d
+}
+
+// CanRetry check whether another attempt is allowed after `attempts` failed attempts
+func (p RetryPolicy) CanRetry(attempts int) bool {
+	return attempts < p.MaxAttempts
+}
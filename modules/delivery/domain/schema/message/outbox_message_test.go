@@ -0,0 +1,27 @@
+package message
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, InitialBackoff: 30 * time.Second, MaxBackoff: 5 * time.Minute}
+
+	assert.Equal(t, 30*time.Second, p.Backoff(1))
+	assert.Equal(t, 60*time.Second, p.Backoff(2))
+	assert.Equal(t, 120*time.Second, p.Backoff(3))
+	assert.Equal(t, 240*time.Second, p.Backoff(4))
+	assert.Equal(t, 5*time.Minute, p.Backoff(5))
+	assert.Equal(t, 5*time.Minute, p.Backoff(50))
+}
+
+func TestRetryPolicy_CanRetry(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3}
+
+	assert.True(t, p.CanRetry(1))
+	assert.True(t, p.CanRetry(2))
+	assert.False(t, p.CanRetry(3))
+}
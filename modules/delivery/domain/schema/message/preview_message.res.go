@@ -0,0 +1,24 @@
+package message
+
+import "encoding/json"
+
+// PreviewMessageResponse type, the rendered message of a send request (dry run, nothing is sent)
+type PreviewMessageResponse struct {
+	TemplateCode    string   `json:"templateCode"`
+	MessageIDHeader string   `json:"messageIdHeader"`
+	Format          string   `json:"format"`
+	Subject         string   `json:"subject"`
+	Body            string   `json:"body"`
+	TextBody        string   `json:"textBody,omitempty"`   // text/plain alternative of the HTML body
+	Raw             string   `json:"raw"`                  // raw MIME message (RFC 5322), as transmitted to the SMTP server
+	Suppressed      []string `json:"suppressed,omitempty"` // suppressed addresses (to, cc and bcc), that would not be sent to
+}
+
+// ToJSON covert to JSON
+func (r *PreviewMessageResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
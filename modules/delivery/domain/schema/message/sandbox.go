@@ -0,0 +1,59 @@
+package message
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ProductionStage environment stage (environment.stage) where emails are sent to the real recipients
+const ProductionStage = "PRODUCTION"
+
+// ErrRecipientSandboxed the recipient (to) is not allowed by the sandbox policy of a non-PRODUCTION stage, nothing is sent
+var ErrRecipientSandboxed = errors.New("Recipient is not allowed in sandbox")
+
+// Sandbox represent recipient policy of non-PRODUCTION stages: the recipients not in the allowlist
+// are redirected to the catch-all address (or dropped when there is none)
+type Sandbox struct {
+	Stage     string
+	CatchAll  string
+	Allowlist []string // email addresses or `@domain`
+}
+
+// IsEnabled check whether the sandbox policy applies (any stage other than PRODUCTION)
+func (s *Sandbox) IsEnabled() bool {
+	return strings.ToUpper(strings.TrimSpace(s.Stage)) != ProductionStage
+}
+
+// IsAllowed check whether the email address is in the allowlist
+func (s *Sandbox) IsAllowed(email string) bool {
+	email = strings.ToLower(strings.TrimSpace(email))
+	for _, v := range s.Allowlist {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" {
+			continue
+		}
+		if strings.HasPrefix(v, "@") {
+			if strings.HasSuffix(email, v) {
+				return true
+			}
+		} else if email == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Recipient get sandboxed email address of a recipient: the address itself (allowlist), the catch-all address,
+// or empty (dropped)
+func (s *Sandbox) Recipient(email string) string {
+	if s.IsAllowed(email) {
+		return email
+	}
+	return strings.TrimSpace(s.CatchAll)
+}
+
+// Subject prefix the subject with the stage, e.g: `[STAGING] Account Activation`
+func (s *Sandbox) Subject(subject string) string {
+	return fmt.Sprintf("[%s] %s", strings.ToUpper(strings.TrimSpace(s.Stage)), subject)
+}
@@ -0,0 +1,32 @@
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandbox_IsEnabled(t *testing.T) {
+	assert.False(t, (&Sandbox{Stage: "PRODUCTION"}).IsEnabled())
+	assert.True(t, (&Sandbox{Stage: "STAGING"}).IsEnabled())
+	assert.True(t, (&Sandbox{Stage: "DEVELOPMENT"}).IsEnabled())
+	assert.True(t, (&Sandbox{}).IsEnabled())
+}
+
+func TestSandbox_Recipient(t *testing.T) {
+	s := &Sandbox{
+		Stage:     "STAGING",
+		CatchAll:  "catch-all@domain.tld",
+		Allowlist: []string{"qa@customer.tld", "@domain.tld"},
+	}
+
+	assert.Equal(t, "QA@Customer.tld", s.Recipient("QA@Customer.tld"))
+	assert.Equal(t, "john.doe@domain.tld", s.Recipient("john.doe@domain.tld"))
+	assert.Equal(t, "catch-all@domain.tld", s.Recipient("john.doe@customer.tld"))
+	assert.Equal(t, "catch-all@domain.tld", s.Recipient("john.doe@sub-domain.tld"))
+
+	s.CatchAll = ""
+	assert.Equal(t, "", s.Recipient("john.doe@customer.tld"))
+
+	assert.Equal(t, "[STAGING] Account Activation", s.Subject("Account Activation"))
+}
@@ -0,0 +1,248 @@
+package message
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+	domSchemaET "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email_template"
+	domSchemaTS "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/template_setting"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// SendMessageRequest represent SendMessageRequest (email template message, with attachments)
+type SendMessageRequest struct {
+	TemplateCode   string                        `json:"templateCode"`
+	From           *domSchemaEmail.MailAddress   `json:"from"`
+	To             *domSchemaEmail.MailAddress   `json:"to"`
+	CC             []*domSchemaEmail.MailAddress `json:"cc"`
+	BCC            []*domSchemaEmail.MailAddress `json:"bcc"`
+	ReplyTo        *domSchemaEmail.MailAddress   `json:"replyTo"`  // optional
+	Headers        map[string]string             `json:"headers"`  // optional: custom `X-` headers
+	Priority       string                        `json:"priority"` // optional: HIGH, NORMAL or LOW
+	TemplateData   map[string]interface{}        `json:"templateData"`
+	ProcessingType string                        `json:"processingType"`
+	Attachments    []*Attachment                 `json:"attachments"`
+	SendAt         string                        `json:"sendAt"`              // RFC 3339, empty: send now
+	TrackOpens     *bool                         `json:"trackOpens"`          // open tracking pixel (HTML), nil: template settings
+	CalendarEvent  *CalendarEvent                `json:"calendarEvent"`       // optional: meeting invite
+	MessageID      string                        `json:"messageId,omitempty"` // message record ID, assigned when the request is queued
+
+	Template *domSchemaET.ETFindByCodeData `json:"-"`
+	Setting  *domSchemaTS.TemplateSetting  `json:"-"`
+	Compiled *CompiledTemplate             `json:"-"` // parsed Template, shared by the sends of a batch
+}
+
+// Attachment represent email attachment, the content is sent base64 encoded (EncodedContent),
+// or uploaded and held in memory (Content) or spooled into a file (FilePath)
+type Attachment struct {
+	Filename       string `json:"filename"`
+	ContentType    string `json:"contentType"`
+	ContentID      string `json:"contentId"`
+	EncodedContent string `json:"content"`
+	Size           int64  `json:"-"` // computed from the content (or spooled file), never from the request
+	Content        []byte `json:"-"`
+	FilePath       string `json:"-"`
+}
+
+// DecodeAttachments decode base64 encoded attachment contents
+func (r *SendMessageRequest) DecodeAttachments() error {
+	errs := validation.Errors{}
+	for idx, a := range r.Attachments {
+		if a.EncodedContent == "" {
+			continue
+		}
+		b, err := base64.StdEncoding.DecodeString(a.EncodedContent)
+		if err != nil {
+			errs[fmt.Sprintf("attachments[%d].content", idx)] = fmt.Errorf("must be encoded in Base64")
+			continue
+		}
+		a.Content = b
+		a.Size = int64(len(b))
+		a.EncodedContent = ""
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// EncodeAttachments encode attachment contents (in memory or spooled file) into base64 (EncodedContent),
+// so the request can be persisted (e.g: scheduled delivery)
+func (r *SendMessageRequest) EncodeAttachments() error {
+	for _, a := range r.Attachments {
+		if a == nil || a.EncodedContent != "" {
+			continue
+		}
+		rc, err := a.Open()
+		if err != nil {
+			return err
+		}
+		b, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+		a.EncodedContent = base64.StdEncoding.EncodeToString(b)
+		a.Content = nil
+		a.Size = 0
+	}
+	return nil
+}
+
+// Fingerprint get sha256 fingerprint of the request payload (incl. decoded attachment contents),
+// used to detect an Idempotency-Key reused with a different payload
+func (r *SendMessageRequest) Fingerprint() (string, error) {
+	h := sha256.New()
+
+	payload := *r
+	payload.Attachments = nil
+	if err := json.NewEncoder(h).Encode(payload); err != nil {
+		return "", err
+	}
+	for _, a := range r.Attachments {
+		if a == nil {
+			continue
+		}
+		fmt.Fprintf(h, "%s\n%s\n%s\n%s\n", a.Filename, a.ContentType, a.ContentID, a.EncodedContent)
+		rc, err := a.Open()
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, rc)
+		rc.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// GetCompiledTemplate get parsed email template (default version), compiled on first use
+func (r *SendMessageRequest) GetCompiledTemplate() (*CompiledTemplate, error) {
+	if r.Compiled == nil {
+		ct, err := CompileTemplate(r.Template)
+		if err != nil {
+			return nil, err
+		}
+		r.Compiled = ct
+	}
+	return r.Compiled, nil
+}
+
+// IsTrackOpens check whether the open tracking pixel is injected (per send, or by template settings)
+func (r *SendMessageRequest) IsTrackOpens() bool {
+	if r.TrackOpens != nil {
+		return *r.TrackOpens
+	}
+	return r.Setting != nil && r.Setting.TrackOpens
+}
+
+// IsTrackClicks check whether the links are rewritten through the click redirect (by template settings)
+func (r *SendMessageRequest) IsTrackClicks() bool {
+	return r.Setting != nil && r.Setting.TrackClicks
+}
+
+// IsInlineCSS check whether the CSS rules of <style> blocks are inlined into HTML bodies (by template settings)
+func (r *SendMessageRequest) IsInlineCSS() bool {
+	return r.Setting != nil && r.Setting.InlineCSS
+}
+
+// GetTextBodyTpl get text body template of the HTML email (by template settings, of the template version), empty: generated from the HTML body
+func (r *SendMessageRequest) GetTextBodyTpl() string {
+	if r.Template == nil {
+		return ""
+	}
+	return r.Setting.GetTextBodyTpl(r.Template.DefaultTemplateVersion.ID)
+}
+
+// GetSendAt get parsed SendAt (zero time: send now)
+func (r *SendMessageRequest) GetSendAt() time.Time {
+	if r.SendAt == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, r.SendAt)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// Open open attachment content
+func (a *Attachment) Open() (io.ReadCloser, error) {
+	if a.FilePath != "" {
+		return os.Open(a.FilePath)
+	}
+	return ioutil.NopCloser(bytes.NewReader(a.Content)), nil
+}
+
+// AttachmentLimits represent attachment limits
+type AttachmentLimits struct {
+	MaxFileSize         int64
+	MaxTotalSize        int64
+	AllowedContentTypes []string
+}
+
+// RemoveSpooledAttachments remove spooled attachment files (if any)
+func (r *SendMessageRequest) RemoveSpooledAttachments() {
+	for _, a := range r.Attachments {
+		if a != nil && a.FilePath != "" {
+			os.Remove(a.FilePath)
+		}
+	}
+}
+
+// ErrRecipientSuppressed the recipient (to) is in the suppression list, nothing is sent
+var ErrRecipientSuppressed = errors.New("Recipient is suppressed")
+
+// Recipients get email addresses of the recipient (to), cc and bcc
+func (r *SendMessageRequest) Recipients() []string {
+	var emails []string
+	if r.To != nil {
+		emails = append(emails, r.To.Email)
+	}
+	for _, v := range append(append([]*domSchemaEmail.MailAddress{}, r.CC...), r.BCC...) {
+		if v != nil {
+			emails = append(emails, v.Email)
+		}
+	}
+	return emails
+}
+
+// Suppress remove suppressed cc and bcc addresses, returns all suppressed addresses (to, cc and bcc)
+// and whether the recipient (to) itself is suppressed
+func (r *SendMessageRequest) Suppress(isSuppressed func(email string) bool) (suppressed []string, toSuppressed bool) {
+	if r.To != nil && isSuppressed(r.To.Email) {
+		suppressed = append(suppressed, r.To.Email)
+		toSuppressed = true
+	}
+
+	filter := func(ms []*domSchemaEmail.MailAddress) []*domSchemaEmail.MailAddress {
+		if ms == nil {
+			return nil
+		}
+		kept := make([]*domSchemaEmail.MailAddress, 0, len(ms))
+		for _, v := range ms {
+			if v != nil && isSuppressed(v.Email) {
+				suppressed = append(suppressed, v.Email)
+				continue
+			}
+			kept = append(kept, v)
+		}
+		return kept
+	}
+	r.CC = filter(r.CC)
+	r.BCC = filter(r.BCC)
+
+	return suppressed, toSuppressed
+}
@@ -0,0 +1,138 @@
+package message
+
+import (
+	"fmt"
+	"mime"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validate SendMessageRequest
+func (r *SendMessageRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.TemplateCode, validation.Required),
+		validation.Field(&r.From, validation.Required),
+		validation.Field(&r.To, validation.Required),
+		validation.Field(&r.ReplyTo),
+		validation.Field(&r.Headers, validation.By(validCustomHeaders)),
+		validation.Field(&r.Priority, validation.In(string(HighPriority), string(NormalPriority), string(LowPriority))),
+		validation.Field(&r.TemplateData, validation.Required),
+		validation.Field(&r.ProcessingType, validation.Required, validation.In(string(domSchemaEmail.SYNCProcess), string(domSchemaEmail.ASYNCProcess))),
+		validation.Field(&r.SendAt, validation.Date(time.RFC3339).Error("must be a valid RFC 3339 date-time"), validation.By(futureTime)),
+		validation.Field(&r.CalendarEvent),
+	)
+}
+
+// ValidateAttachments validate attachments against attachment limits,
+// the sizes are computed from the (decoded) contents or spooled files
+func (r *SendMessageRequest) ValidateAttachments(limits AttachmentLimits) error {
+	errs := validation.Errors{}
+
+	var total int64
+	for idx, a := range r.Attachments {
+		if err := a.computeSize(); err != nil {
+			errs[fmt.Sprintf("attachments[%d]", idx)] = err
+			continue
+		}
+		if err := a.Validate(limits); err != nil {
+			if fieldErrs, ok := err.(validation.Errors); ok {
+				for field, fieldErr := range fieldErrs {
+					errs[fmt.Sprintf("attachments[%d].%s", idx, field)] = fieldErr
+				}
+			} else {
+				errs[fmt.Sprintf("attachments[%d]", idx)] = err
+			}
+			continue
+		}
+		total += a.Size
+	}
+	if len(errs) == 0 && limits.MaxTotalSize > 0 && total > limits.MaxTotalSize {
+		errs["attachments"] = fmt.Errorf("total size (%d bytes) exceeds the maximum of %d bytes", total, limits.MaxTotalSize)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Validate Attachment
+func (a *Attachment) Validate(limits AttachmentLimits) error {
+	// size is computed, it is not a (json) field of the request
+	return validation.Errors{
+		"filename":    validation.Validate(a.Filename, validation.Required),
+		"contentType": validation.Validate(a.ContentType, validation.Required, validation.By(contentTypeAllowed(limits.AllowedContentTypes))),
+		"contentId":   validation.Validate(a.ContentID, validation.Length(0, 250), validation.Match(contentIDRegexp)),
+		"content":     validation.Validate(a.EncodedContent, validation.When(len(a.Content) == 0 && a.FilePath == "", validation.Required)),
+		"size":        validation.Validate(a.Size, validation.By(sizeAllowed(limits.MaxFileSize))),
+	}.Filter()
+}
+
+// computeSize set size of the content (in memory, or spooled file)
+func (a *Attachment) computeSize() error {
+	if a.FilePath == "" {
+		a.Size = int64(len(a.Content))
+		return nil
+	}
+	fi, err := os.Stat(a.FilePath)
+	if err != nil {
+		return err
+	}
+	a.Size = fi.Size()
+	return nil
+}
+
+// contentIDRegexp represent allowed Content-ID (without angle brackets), e.g: `logo` or `logo@domain.tld`
+var contentIDRegexp = regexp.MustCompile(`^[^\s<>@"]+(@[^\s<>@"]+)?$`)
+
+// contentTypeAllowed check content type (without parameters) against allowed list (empty: allow all)
+func contentTypeAllowed(allowed []string) validation.RuleFunc {
+	return func(value interface{}) error {
+		ct, _ := value.(string)
+		if ct == "" || len(allowed) == 0 {
+			return nil
+		}
+		mt, _, err := mime.ParseMediaType(ct)
+		if err != nil {
+			return fmt.Errorf("invalid content type `%s`", ct)
+		}
+		for _, a := range allowed {
+			if strings.EqualFold(a, mt) {
+				return nil
+			}
+		}
+		return fmt.Errorf("content type `%s` is not allowed", mt)
+	}
+}
+
+// futureTime check RFC 3339 date-time is not in the past
+func futureTime(value interface{}) error {
+	str, _ := value.(string)
+	if str == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, str)
+	if err != nil {
+		return nil // handled by validation.Date
+	}
+	if t.Before(time.Now()) {
+		return fmt.Errorf("must be a future date-time")
+	}
+	return nil
+}
+
+// sizeAllowed check size against maximum size (0: no limit)
+func sizeAllowed(max int64) validation.RuleFunc {
+	return func(value interface{}) error {
+		size, _ := value.(int64)
+		if max > 0 && size > max {
+			return fmt.Errorf("size (%d bytes) exceeds the maximum of %d bytes", size, max)
+		}
+		return nil
+	}
+}
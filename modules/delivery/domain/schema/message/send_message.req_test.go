@@ -0,0 +1,277 @@
+package message
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+	domSchemaTS "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/template_setting"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendMessageRequest_DecodeAttachments(t *testing.T) {
+	req := &SendMessageRequest{
+		Attachments: []*Attachment{
+			{Filename: "invoice.txt", ContentType: "text/plain", EncodedContent: "SW52b2ljZSBOby4gMDAx"},
+			{Filename: "broken.txt", ContentType: "text/plain", EncodedContent: "not base64!"},
+		},
+	}
+
+	err := req.DecodeAttachments()
+	if assert.Error(t, err) {
+		errs, ok := err.(validation.Errors)
+		if assert.True(t, ok) {
+			assert.Contains(t, errs, "attachments[1].content")
+			assert.NotContains(t, errs, "attachments[0].content")
+		}
+	}
+	assert.Equal(t, "Invoice No. 001", string(req.Attachments[0].Content))
+	assert.Equal(t, int64(15), req.Attachments[0].Size)
+}
+
+func TestSendMessageRequest_ValidateAttachments(t *testing.T) {
+	limits := AttachmentLimits{
+		MaxFileSize:         10,
+		MaxTotalSize:        15,
+		AllowedContentTypes: []string{"application/pdf", "text/plain"},
+	}
+
+	req := &SendMessageRequest{
+		Attachments: []*Attachment{
+			{Filename: "invoice.pdf", ContentType: "application/pdf", Content: []byte("%PDF-1.4"), Size: 8},
+			{Filename: "logo.png", ContentType: "image/png", ContentID: "logo", Content: []byte("PNG"), Size: 3},
+			{Filename: "report.txt", ContentType: "text/plain; charset=utf-8", Content: []byte("12345678901"), Size: 11},
+			{Filename: "empty.txt", ContentType: "text/plain"},
+			{Filename: "inline.txt", ContentType: "text/plain", ContentID: "<bad id>", Content: []byte("1"), Size: 1},
+		},
+	}
+	err := req.ValidateAttachments(limits)
+	if assert.Error(t, err) {
+		errs, ok := err.(validation.Errors)
+		if assert.True(t, ok) {
+			assert.NotContains(t, errs, "attachments[0].contentType")
+			assert.Contains(t, errs, "attachments[1].contentType")
+			assert.Contains(t, errs, "attachments[2].size")
+			assert.Contains(t, errs, "attachments[3].content")
+			assert.Contains(t, errs, "attachments[4].contentId")
+		}
+	}
+
+	req.Attachments = []*Attachment{
+		{Filename: "invoice.pdf", ContentType: "application/pdf", Content: []byte("%PDF-1.4"), Size: 8},
+		{Filename: "note.txt", ContentType: "text/plain", Content: []byte("12345678"), Size: 8},
+	}
+	err = req.ValidateAttachments(limits)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.(validation.Errors), "attachments")
+	}
+
+	req.Attachments = req.Attachments[:1]
+	assert.NoError(t, req.ValidateAttachments(limits))
+}
+
+func TestSendMessageRequest_Validate_SendAt(t *testing.T) {
+	sendAtErr := func(req *SendMessageRequest) error {
+		if errs, ok := req.Validate().(validation.Errors); ok {
+			return errs["sendAt"]
+		}
+		return nil
+	}
+
+	req := &SendMessageRequest{
+		TemplateCode:   "activate-registration-html",
+		TemplateData:   map[string]interface{}{"Header.Name": "John Doe"},
+		ProcessingType: "SYNC",
+	}
+	assert.NoError(t, sendAtErr(req))
+	assert.True(t, req.GetSendAt().IsZero())
+
+	sendAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	req.SendAt = sendAt.Format(time.RFC3339)
+	assert.NoError(t, sendAtErr(req))
+	assert.True(t, sendAt.Equal(req.GetSendAt()))
+
+	req.SendAt = "2020-11-16 13:47:21"
+	assert.Error(t, sendAtErr(req))
+
+	req.SendAt = time.Now().Add(-time.Hour).Format(time.RFC3339)
+	assert.Error(t, sendAtErr(req))
+}
+
+func TestSendMessageRequest_Validate_HeadersPriority(t *testing.T) {
+	fieldErrs := func(req *SendMessageRequest) validation.Errors {
+		errs, _ := req.Validate().(validation.Errors)
+		return errs
+	}
+
+	req := &SendMessageRequest{
+		TemplateCode:   "activate-registration-html",
+		TemplateData:   map[string]interface{}{"Header.Name": "John Doe"},
+		ProcessingType: "SYNC",
+		Headers:        map[string]string{"X-Correlation-ID": "order-1001", "x-ticket": "1234"},
+		Priority:       string(HighPriority),
+	}
+	assert.NoError(t, fieldErrs(req)["headers"])
+	assert.NoError(t, fieldErrs(req)["priority"])
+
+	req.Headers = map[string]string{
+		"Bcc":         "audit@domain.tld",
+		"Received":    "from evil.tld",
+		"X-Priority":  "1",
+		"Subject":     "Overridden",
+		"X-Bad Name":  "value",
+		"X-Multiline": "value\r\nBcc: audit@domain.tld",
+	}
+	errs, ok := fieldErrs(req)["headers"].(validation.Errors)
+	if assert.True(t, ok) {
+		assert.Len(t, errs, 6)
+		assert.Equal(t, "header is not allowed", errs["Bcc"].Error())
+		assert.Equal(t, "header is not allowed", errs["Received"].Error())
+		assert.Equal(t, "header is not allowed", errs["X-Priority"].Error())
+		assert.Equal(t, "must be an `X-` header", errs["Subject"].Error())
+		assert.Equal(t, "invalid header name", errs["X-Bad Name"].Error())
+		assert.Equal(t, "must be a single line value", errs["X-Multiline"].Error())
+	}
+
+	req.Priority = "URGENT"
+	assert.Error(t, fieldErrs(req)["priority"])
+}
+
+func TestSendMessageRequest_ValidateAttachments_Size(t *testing.T) {
+	// the size is never taken from the request
+	var req SendMessageRequest
+	if !assert.NoError(t, json.Unmarshal([]byte(`{"attachments":[{"filename":"a.txt","contentType":"text/plain","size":5}]}`), &req)) {
+		return
+	}
+	assert.Equal(t, int64(0), req.Attachments[0].Size)
+	err := req.ValidateAttachments(AttachmentLimits{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.(validation.Errors), "attachments[0].content")
+	}
+
+	// computed from the content: a forged size does not bypass the limits
+	req.Attachments[0].Content = []byte("12345678901")
+	req.Attachments[0].Size = 1
+	err = req.ValidateAttachments(AttachmentLimits{MaxFileSize: 10})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.(validation.Errors), "attachments[0].size")
+	}
+	assert.Equal(t, int64(11), req.Attachments[0].Size)
+}
+
+func TestSendMessageRequest_EncodeAttachments(t *testing.T) {
+	f, err := ioutil.TempFile("", "attachment-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString("Spooled Report")
+	f.Close()
+
+	req := &SendMessageRequest{
+		Attachments: []*Attachment{
+			{Filename: "invoice.txt", ContentType: "text/plain", Content: []byte("Invoice No. 001"), Size: 15},
+			{Filename: "report.txt", ContentType: "text/plain", FilePath: f.Name(), Size: 14},
+		},
+	}
+	if assert.NoError(t, req.EncodeAttachments()) {
+		assert.Equal(t, "SW52b2ljZSBOby4gMDAx", req.Attachments[0].EncodedContent)
+		assert.Nil(t, req.Attachments[0].Content)
+		assert.Equal(t, "U3Bvb2xlZCBSZXBvcnQ=", req.Attachments[1].EncodedContent)
+	}
+
+	if assert.NoError(t, req.DecodeAttachments()) {
+		assert.Equal(t, "Invoice No. 001", string(req.Attachments[0].Content))
+		assert.Equal(t, "Spooled Report", string(req.Attachments[1].Content))
+	}
+}
+
+func TestSendMessageRequest_Fingerprint(t *testing.T) {
+	newReq := func(content string) *SendMessageRequest {
+		return &SendMessageRequest{
+			TemplateCode:   "activate-registration-html",
+			TemplateData:   map[string]interface{}{"Header.Name": "John Doe", "Body.UserAccount": "john.doe"},
+			ProcessingType: "SYNC",
+			Attachments: []*Attachment{
+				{Filename: "invoice.txt", ContentType: "text/plain", Content: []byte(content)},
+			},
+		}
+	}
+
+	fp1, err := newReq("Invoice No. 001").Fingerprint()
+	if assert.NoError(t, err) {
+		assert.Len(t, fp1, 64)
+	}
+	fp2, _ := newReq("Invoice No. 001").Fingerprint()
+	assert.Equal(t, fp1, fp2)
+
+	fp3, _ := newReq("Invoice No. 002").Fingerprint()
+	assert.NotEqual(t, fp1, fp3)
+
+	req := newReq("Invoice No. 001")
+	req.TemplateData["Body.UserAccount"] = "jane.doe"
+	fp4, _ := req.Fingerprint()
+	assert.NotEqual(t, fp1, fp4)
+}
+
+func TestSendMessageRequest_Suppress(t *testing.T) {
+	suppressed := map[string]bool{"dead@example.com": true, "spam@example.com": true}
+	isSuppressed := func(email string) bool { return suppressed[strings.ToLower(email)] }
+
+	req := &SendMessageRequest{
+		To:  &domSchemaEmail.MailAddress{Email: "john.doe@example.com"},
+		CC:  []*domSchemaEmail.MailAddress{{Email: "Dead@example.com"}, {Email: "jane.doe@example.com"}},
+		BCC: []*domSchemaEmail.MailAddress{{Email: "spam@example.com"}},
+	}
+	assert.Equal(t, []string{"john.doe@example.com", "Dead@example.com", "jane.doe@example.com", "spam@example.com"}, req.Recipients())
+
+	res, toSuppressed := req.Suppress(isSuppressed)
+	assert.False(t, toSuppressed)
+	assert.Equal(t, []string{"Dead@example.com", "spam@example.com"}, res)
+	if assert.Len(t, req.CC, 1) {
+		assert.Equal(t, "jane.doe@example.com", req.CC[0].Email)
+	}
+	assert.Empty(t, req.BCC)
+
+	req = &SendMessageRequest{To: &domSchemaEmail.MailAddress{Email: "dead@example.com"}}
+	res, toSuppressed = req.Suppress(isSuppressed)
+	assert.True(t, toSuppressed)
+	assert.Equal(t, []string{"dead@example.com"}, res)
+}
+
+func TestSendMessageRequest_IsTrackOpens(t *testing.T) {
+	on, off := true, false
+
+	req := &SendMessageRequest{}
+	assert.False(t, req.IsTrackOpens())
+
+	req.Setting = &domSchemaTS.TemplateSetting{TrackOpens: true}
+	assert.True(t, req.IsTrackOpens())
+
+	// per send flag overrides the template settings
+	req.TrackOpens = &off
+	assert.False(t, req.IsTrackOpens())
+
+	req.Setting.TrackOpens = false
+	req.TrackOpens = &on
+	assert.True(t, req.IsTrackOpens())
+}
+
+func TestSendMessageRequest_GetTextBodyTpl(t *testing.T) {
+	req := &SendMessageRequest{Template: testTemplate("Welcome", `{{define "T"}}<p>Hello</p>{{end}}`)}
+	req.Template.DefaultTemplateVersion.ID = 2
+	assert.Equal(t, "", req.GetTextBodyTpl())
+
+	// set for the (default) template version
+	req.Setting = &domSchemaTS.TemplateSetting{TextBodyTpl: `{{define "T"}}Hello{{end}}`, TextBodyVersionID: 2}
+	assert.Equal(t, `{{define "T"}}Hello{{end}}`, req.GetTextBodyTpl())
+
+	// set for the previous template version: generated from the HTML body
+	req.Template.DefaultTemplateVersion.ID = 3
+	assert.Equal(t, "", req.GetTextBodyTpl())
+}
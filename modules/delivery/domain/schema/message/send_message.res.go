@@ -0,0 +1,26 @@
+package message
+
+import "encoding/json"
+
+// SendMessageResponse type
+type SendMessageResponse struct {
+	TemplateCode    string   `json:"templateCode"`
+	MessageID       string   `json:"messageId,omitempty"`
+	MessageIDHeader string   `json:"messageIdHeader,omitempty"`
+	Status          string   `json:"status"`
+	ScheduleID      string   `json:"scheduleId,omitempty"`
+	SendAt          string   `json:"sendAt,omitempty"`
+	Suppressed      []string `json:"suppressed,omitempty"` // suppressed addresses (to, cc and bcc), not sent to
+}
+
+// SuppressedSendStatus status of a send request whose recipient (to) is suppressed, nothing is sent
+const SuppressedSendStatus = "SUPPRESSED"
+
+// ToJSON covert to JSON
+func (r *SendMessageResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
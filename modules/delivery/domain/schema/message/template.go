@@ -0,0 +1,53 @@
+package message
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+	textTemplate "text/template"
+
+	domSchemaET "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email_template"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// BodyTemplateName template executed of the body template (bodyTpl), e.g: `{{define "T"}}Hello{{end}}`
+const BodyTemplateName = "T"
+
+// CompiledTemplate email template (default version) parsed once, to render the messages of many sends (e.g: batch)
+type CompiledTemplate struct {
+	subject *textTemplate.Template
+	body    *template.Template
+}
+
+// CompileTemplate parse the templates of the email template (default version)
+func CompileTemplate(tpl *domSchemaET.ETFindByCodeData) (*CompiledTemplate, error) {
+	// the subject is a header (not HTML), e.g: `Welcome {{index . "Header.Name"}}`
+	subject, err := textTemplate.New("subject").Parse(tpl.DefaultTemplateVersion.SubjectTpl)
+	if err != nil {
+		return nil, err
+	}
+	body, err := template.New("body").Parse(tpl.DefaultTemplateVersion.BodyTpl)
+	if err != nil {
+		return nil, err
+	}
+	return &CompiledTemplate{subject: subject, body: body}, nil
+}
+
+// ExecuteSubject render the subject with the template data (as a single line),
+// an error is a validation error of the template data
+func (t *CompiledTemplate) ExecuteSubject(data map[string]interface{}) (string, error) {
+	buf := new(bytes.Buffer)
+	if err := t.subject.Execute(buf, data); err != nil {
+		return "", validation.Errors{"templateData": err}
+	}
+	return strings.Join(strings.Fields(buf.String()), " "), nil
+}
+
+// ExecuteBody render the body with the template data
+func (t *CompiledTemplate) ExecuteBody(data map[string]interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := t.body.ExecuteTemplate(buf, BodyTemplateName, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,69 @@
+package message
+
+import (
+	"testing"
+
+	domSchemaET "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email_template"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func testTemplate(subjectTpl, bodyTpl string) *domSchemaET.ETFindByCodeData {
+	tpl := new(domSchemaET.ETFindByCodeData)
+	tpl.DefaultTemplateVersion.SubjectTpl = subjectTpl
+	tpl.DefaultTemplateVersion.BodyTpl = bodyTpl
+	return tpl
+}
+
+func TestCompileTemplate(t *testing.T) {
+	ct, err := CompileTemplate(testTemplate("Welcome", `{{define "T"}}<p>Hello {{.Name}}</p>{{end}}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	// parsed once, executed for many sends
+	for _, name := range []string{"John", "<Jane>"} {
+		body, err := ct.ExecuteBody(map[string]interface{}{"Name": name})
+		if assert.NoError(t, err) {
+			assert.Contains(t, string(body), "Hello")
+		}
+	}
+	body, _ := ct.ExecuteBody(map[string]interface{}{"Name": "<Jane>"})
+	assert.Equal(t, "<p>Hello &lt;Jane&gt;</p>", string(body))
+
+	_, err = CompileTemplate(testTemplate("Welcome", `{{define "T"}}{{.Name}`))
+	assert.Error(t, err)
+}
+
+func TestCompiledTemplate_ExecuteSubject(t *testing.T) {
+	ct, err := CompileTemplate(testTemplate(`Welcome {{index . "Header.Name"}}, <{{index . "Body.UserAccount"}}>`, `{{define "T"}}Hello{{end}}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	subject, err := ct.ExecuteSubject(map[string]interface{}{"Header.Name": "John\r\nBcc: x@domain.tld", "Body.UserAccount": "john.doe"})
+	if assert.NoError(t, err) {
+		// not HTML escaped, a single line
+		assert.Equal(t, "Welcome John Bcc: x@domain.tld, <john.doe>", subject)
+	}
+
+	ct, err = CompileTemplate(testTemplate(`Welcome {{.Name.First}}`, `{{define "T"}}Hello{{end}}`))
+	if assert.NoError(t, err) {
+		_, err = ct.ExecuteSubject(map[string]interface{}{"Name": "John"})
+		if assert.Error(t, err) {
+			_, ok := err.(validation.Errors)
+			assert.True(t, ok)
+		}
+	}
+
+	_, err = CompileTemplate(testTemplate(`Welcome {{.Name`, `{{define "T"}}Hello{{end}}`))
+	assert.Error(t, err)
+}
+
+func TestSendMessageRequest_GetCompiledTemplate(t *testing.T) {
+	req := &SendMessageRequest{Template: testTemplate("Welcome", `{{define "T"}}Hello{{end}}`)}
+	ct, err := req.GetCompiledTemplate()
+	if assert.NoError(t, err) {
+		assert.True(t, ct == req.Compiled)
+		again, _ := req.GetCompiledTemplate()
+		assert.True(t, ct == again)
+	}
+}
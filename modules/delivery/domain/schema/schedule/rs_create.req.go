@@ -0,0 +1,32 @@
+package schedule
+
+import (
+	domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+	domSchemaMessage "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+)
+
+// RSCreateRequest type
+type RSCreateRequest struct {
+	Name           string                        `json:"name"`
+	CronExpression string                        `json:"cronExpression"`
+	Timezone       string                        `json:"timezone"` // optional, IANA timezone (e.g: Asia/Jakarta), default: server local time
+	TemplateCode   string                        `json:"templateCode"`
+	From           *domSchemaEmail.MailAddress   `json:"from"`
+	To             *domSchemaEmail.MailAddress   `json:"to"`
+	CC             []*domSchemaEmail.MailAddress `json:"cc"`
+	BCC            []*domSchemaEmail.MailAddress `json:"bcc"`
+	TemplateData   map[string]interface{}        `json:"templateData"`
+}
+
+// ToSendMessageRequest convert to SendMessageRequest (sent on each run)
+func (r *RSCreateRequest) ToSendMessageRequest() *domSchemaMessage.SendMessageRequest {
+	return &domSchemaMessage.SendMessageRequest{
+		TemplateCode:   r.TemplateCode,
+		From:           r.From,
+		To:             r.To,
+		CC:             r.CC,
+		BCC:            r.BCC,
+		TemplateData:   r.TemplateData,
+		ProcessingType: string(domSchemaEmail.SYNCProcess),
+	}
+}
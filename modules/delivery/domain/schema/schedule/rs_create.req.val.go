@@ -0,0 +1,33 @@
+package schedule
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validate RSCreateRequest
+func (r *RSCreateRequest) Validate() error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&r.CronExpression, validation.Required, validation.Length(1, 100), validation.By(r.validateCron)),
+		validation.Field(&r.Timezone, validation.Length(0, 100)),
+	); err != nil {
+		return err
+	}
+	return r.ToSendMessageRequest().Validate()
+}
+
+func (r *RSCreateRequest) validateCron(value interface{}) error {
+	return ValidateCron(r.CronExpression, r.Timezone, time.Now())
+}
+
+// ValidateAddresses validate and normalize the addresses of the request, as the addresses of a send request
+func (r *RSCreateRequest) ValidateAddresses(disposableDomains map[string]bool) error {
+	msgReq := r.ToSendMessageRequest()
+	if err := msgReq.ValidateAddresses(disposableDomains); err != nil {
+		return err
+	}
+	r.From, r.To, r.CC, r.BCC = msgReq.From, msgReq.To, msgReq.CC, msgReq.BCC
+	return nil
+}
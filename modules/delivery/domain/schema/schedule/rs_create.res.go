@@ -0,0 +1,17 @@
+package schedule
+
+import "encoding/json"
+
+// RSCreateResponse type
+type RSCreateResponse struct {
+	Schedule
+}
+
+// ToJSON covert to JSON
+func (r *RSCreateResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
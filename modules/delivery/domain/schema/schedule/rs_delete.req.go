@@ -0,0 +1,6 @@
+package schedule
+
+// RSDeleteRequest type
+type RSDeleteRequest struct {
+	ID string `json:"id"`
+}
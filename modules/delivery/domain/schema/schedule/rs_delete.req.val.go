@@ -0,0 +1,13 @@
+package schedule
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	valIs "github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// Validate RSDeleteRequest
+func (r *RSDeleteRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ID, validation.Required, valIs.UUID),
+	)
+}
@@ -0,0 +1,18 @@
+package schedule
+
+import "encoding/json"
+
+// RSDeleteResponse type
+type RSDeleteResponse struct {
+	Query RSDeleteRequest `json:"query"`
+	Data  Schedule        `json:"data"`
+}
+
+// ToJSON covert to JSON
+func (r *RSDeleteResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
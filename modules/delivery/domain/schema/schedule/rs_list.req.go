@@ -0,0 +1,7 @@
+package schedule
+
+// RSListRequest type
+type RSListRequest struct {
+	Status       string `json:"status"`       // optional: ACTIVE or PAUSED
+	TemplateCode string `json:"templateCode"` // optional
+}
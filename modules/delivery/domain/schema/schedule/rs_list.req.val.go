@@ -0,0 +1,13 @@
+package schedule
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validate RSListRequest
+func (r *RSListRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Status, validation.In(string(ActiveStatus), string(PausedStatus))),
+		validation.Field(&r.TemplateCode, validation.Length(0, 100)),
+	)
+}
@@ -0,0 +1,24 @@
+package schedule
+
+import "encoding/json"
+
+// RSListResponse type
+type RSListResponse struct {
+	Query RSListRequest      `json:"query"`
+	Data  RSListResponseData `json:"data"`
+}
+
+// RSListResponseData type
+type RSListResponseData struct {
+	Count     int64       `json:"count"`
+	Schedules []*Schedule `json:"schedules"`
+}
+
+// ToJSON covert to JSON
+func (r *RSListResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
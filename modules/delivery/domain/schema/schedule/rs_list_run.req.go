@@ -0,0 +1,7 @@
+package schedule
+
+// RSListRunRequest type
+type RSListRunRequest struct {
+	ID    string `json:"id"`
+	Limit int    `json:"limit"` // optional, default: 50 (latest runs)
+}
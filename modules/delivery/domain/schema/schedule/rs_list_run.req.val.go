@@ -0,0 +1,14 @@
+package schedule
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	valIs "github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// Validate RSListRunRequest
+func (r *RSListRunRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ID, validation.Required, valIs.UUID),
+		validation.Field(&r.Limit, validation.Min(0), validation.Max(500)),
+	)
+}
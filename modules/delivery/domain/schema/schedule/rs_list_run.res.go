@@ -0,0 +1,25 @@
+package schedule
+
+import "encoding/json"
+
+// RSListRunResponse type
+type RSListRunResponse struct {
+	Query RSListRunRequest      `json:"query"`
+	Data  RSListRunResponseData `json:"data"`
+}
+
+// RSListRunResponseData type
+type RSListRunResponseData struct {
+	Schedule Schedule `json:"schedule"`
+	Count    int64    `json:"count"`
+	Runs     []*Run   `json:"runs"`
+}
+
+// ToJSON covert to JSON
+func (r *RSListRunResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
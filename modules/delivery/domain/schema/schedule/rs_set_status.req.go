@@ -0,0 +1,7 @@
+package schedule
+
+// RSSetStatusRequest type (pause or resume a recurring Schedule)
+type RSSetStatusRequest struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
@@ -0,0 +1,14 @@
+package schedule
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	valIs "github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// Validate RSSetStatusRequest
+func (r *RSSetStatusRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ID, validation.Required, valIs.UUID),
+		validation.Field(&r.Status, validation.Required, validation.In(string(ActiveStatus), string(PausedStatus))),
+	)
+}
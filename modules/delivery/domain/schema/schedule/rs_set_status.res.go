@@ -0,0 +1,18 @@
+package schedule
+
+import "encoding/json"
+
+// RSSetStatusResponse type
+type RSSetStatusResponse struct {
+	Query RSSetStatusRequest `json:"query"`
+	Data  Schedule           `json:"data"`
+}
+
+// ToJSON covert to JSON
+func (r *RSSetStatusResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
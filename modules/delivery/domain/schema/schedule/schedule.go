@@ -0,0 +1,139 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+	domSchemaMessage "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+	"github.com/robfig/cron/v3"
+)
+
+// Status represent recurring Schedule status
+type Status string
+
+const (
+	// ActiveStatus schedule is running
+	ActiveStatus Status = "ACTIVE"
+	// PausedStatus schedule is paused
+	PausedStatus Status = "PAUSED"
+)
+
+// RunStatus represent Schedule run status
+type RunStatus string
+
+const (
+	// RunSent message of the run has been sent
+	RunSent RunStatus = "SENT"
+	// RunFailed message of the run failed
+	RunFailed RunStatus = "FAILED"
+)
+
+// Schedule type (recurring send of an Email Template)
+type Schedule struct {
+	ID             string                        `json:"id"`
+	Name           string                        `json:"name"`
+	CronExpression string                        `json:"cronExpression"`
+	Timezone       string                        `json:"timezone"`
+	TemplateCode   string                        `json:"templateCode"`
+	From           *domSchemaEmail.MailAddress   `json:"from"`
+	To             *domSchemaEmail.MailAddress   `json:"to"`
+	CC             []*domSchemaEmail.MailAddress `json:"cc"`
+	BCC            []*domSchemaEmail.MailAddress `json:"bcc"`
+	TemplateData   map[string]interface{}        `json:"templateData"`
+	Status         string                        `json:"status"`
+	NextRunAt      *time.Time                    `json:"nextRunAt"`
+	LastRunAt      *time.Time                    `json:"lastRunAt"`
+	CreatedBy      string                        `json:"createdBy"`
+}
+
+// Run type (one run of a recurring Schedule)
+type Run struct {
+	ID           string     `json:"id"`
+	ScheduledFor time.Time  `json:"scheduledFor"`
+	StartedAt    *time.Time `json:"startedAt"`
+	FinishedAt   *time.Time `json:"finishedAt"`
+	Status       string     `json:"status"`
+	Error        string     `json:"error,omitempty"`
+}
+
+// DueSchedule type (claimed recurring Schedule, due to be sent)
+type DueSchedule struct {
+	ID           string                               `json:"id"`
+	ScheduledFor time.Time                            `json:"scheduledFor"`
+	Request      *domSchemaMessage.SendMessageRequest `json:"request"`
+	CreatedBy    string                               `json:"createdBy"`
+	IPAddress    string                               `json:"ipAddress"`
+}
+
+// Payload type (persisted send request of a recurring Schedule)
+type Payload struct {
+	From         *domSchemaEmail.MailAddress   `json:"from"`
+	To           *domSchemaEmail.MailAddress   `json:"to"`
+	CC           []*domSchemaEmail.MailAddress `json:"cc"`
+	BCC          []*domSchemaEmail.MailAddress `json:"bcc"`
+	TemplateData map[string]interface{}        `json:"templateData"`
+}
+
+// MinRunInterval minimum interval between the runs of a recurring Schedule
+const MinRunInterval = 15 * time.Minute
+
+// checkedRuns number of (next) runs checked against MinRunInterval
+const checkedRuns = 500
+
+// cronParser standard cron expressions only (minute hour day-of-month month day-of-week), descriptors (e.g: `@every 1s`) are not accepted
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// NextRun get the next run time (after `after`) of a standard (5 fields) cron expression in timezone (empty: server local time)
+func NextRun(cronExpression, timezone string, after time.Time) (time.Time, error) {
+	sched, loc, err := parseCron(cronExpression, timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	next := sched.Next(after.In(loc))
+	if next.IsZero() {
+		return next, fmt.Errorf("cron expression `%s` has no next run", cronExpression)
+	}
+	return next, nil
+}
+
+// ValidateCron validate standard (5 fields) cron expression in timezone, its runs (after `after`) must be at least MinRunInterval apart
+func ValidateCron(cronExpression, timezone string, after time.Time) error {
+	prev, err := NextRun(cronExpression, timezone, after)
+	if err != nil {
+		return err
+	}
+	sched, _, _ := parseCron(cronExpression, timezone)
+	for n := 0; n < checkedRuns; n++ {
+		next := sched.Next(prev)
+		if next.IsZero() {
+			break
+		}
+		if next.Sub(prev) < MinRunInterval {
+			return fmt.Errorf("runs must be at least %s apart (%s and %s)", MinRunInterval, prev.Format(time.RFC3339), next.Format(time.RFC3339))
+		}
+		prev = next
+	}
+	return nil
+}
+
+func parseCron(cronExpression, timezone string) (cron.Schedule, *time.Location, error) {
+	loc := time.Local
+	if timezone != "" {
+		l, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid timezone `%s`", timezone)
+		}
+		loc = l
+	}
+	// exactly 5 fields: no descriptor, nor TZ= prefix (see timezone)
+	if len(strings.Fields(cronExpression)) != 5 {
+		return nil, nil, fmt.Errorf("invalid cron expression (expected 5 fields: minute hour day-of-month month day-of-week)")
+	}
+	sched, err := cronParser.Parse(cronExpression)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid cron expression (%s)", err.Error())
+	}
+	return sched, loc, nil
+}
@@ -0,0 +1,92 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextRun(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Skipf("time.LoadLocation: %s", err.Error())
+	}
+
+	// every monday 08:00 (Asia/Jakarta)
+	after := time.Date(2026, 10, 18, 10, 0, 0, 0, loc) // sunday
+	next, err := NextRun("0 8 * * 1", "Asia/Jakarta", after)
+	if assert.NoError(t, err) {
+		assert.True(t, time.Date(2026, 10, 19, 8, 0, 0, 0, loc).Equal(next))
+	}
+
+	_, err = NextRun("0 8 * *", "", after)
+	assert.Error(t, err)
+
+	_, err = NextRun("0 8 * * 1", "Mars/Olympus_Mons", after)
+	assert.Error(t, err)
+}
+
+func TestRSCreateRequest_Validate(t *testing.T) {
+	req := &RSCreateRequest{
+		Name:           "Weekly Report",
+		CronExpression: "every monday",
+		Timezone:       "Asia/Jakarta",
+		TemplateCode:   "activate-registration-html",
+	}
+	if err := req.Validate(); assert.Error(t, err) {
+		assert.Contains(t, err.(validation.Errors), "cronExpression")
+	}
+}
+
+func TestRSCreateRequest_ValidateAddresses(t *testing.T) {
+	disposable := map[string]bool{"mailinator.com": true}
+
+	req := &RSCreateRequest{
+		From: &domSchemaEmail.MailAddress{Email: "no-reply@domain.tld", Name: "No Reply"},
+		To:   &domSchemaEmail.MailAddress{Email: "john.doe@mailinator.com", Name: "John Doe"},
+	}
+	if err := req.ValidateAddresses(disposable); assert.Error(t, err) {
+		assert.Contains(t, err.(validation.Errors), "to.email")
+	}
+
+	// normalized, duplicate recipients removed
+	req = &RSCreateRequest{
+		From: &domSchemaEmail.MailAddress{Email: "no-reply@domain.tld", Name: "No Reply"},
+		To:   &domSchemaEmail.MailAddress{Email: "john.doe@Domain.tld", Name: "John Doe"},
+		CC: []*domSchemaEmail.MailAddress{
+			{Email: "John.Doe@domain.tld", Name: "John Doe"},
+		},
+	}
+	if assert.NoError(t, req.ValidateAddresses(disposable)) {
+		assert.Equal(t, "john.doe@domain.tld", req.To.Email)
+		assert.Empty(t, req.CC)
+	}
+}
+
+func TestValidateCron(t *testing.T) {
+	after := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+
+	for _, expr := range []string{"0 8 * * 1", "*/15 * * * *", "0,30 8-17 * * 1-5", "45 23 * * *"} {
+		assert.NoError(t, ValidateCron(expr, "Asia/Jakarta", after), expr)
+	}
+
+	for _, expr := range []string{
+		"@every 1s", "@every 1h", "@hourly", "@daily", // descriptors
+		"0 0 8 * * 1", "TZ=UTC 0 8 * * 1", "0 8 * *", // not 5 fields
+		"* * * * *", "*/5 * * * *", "0,10 8 * * *", "2,59 * * * *", "*/1 8 * * 1", // runs less than 15 minutes apart
+	} {
+		assert.Error(t, ValidateCron(expr, "", after), expr)
+	}
+
+	req := &RSCreateRequest{
+		Name:           "Every Second",
+		CronExpression: "@every 1s",
+		TemplateCode:   "activate-registration-html",
+	}
+	if err := req.Validate(); assert.Error(t, err) {
+		assert.Contains(t, err.(validation.Errors), "cronExpression")
+	}
+}
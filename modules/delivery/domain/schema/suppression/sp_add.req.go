@@ -0,0 +1,16 @@
+package suppression
+
+// SPAddRequest type
+type SPAddRequest struct {
+	Email  string `json:"email"`
+	Reason string `json:"reason"` // optional: MANUAL (default), HARD_BOUNCE or COMPLAINT
+	Note   string `json:"note"`   // optional
+}
+
+// GetReason get suppression reason (or the default)
+func (r *SPAddRequest) GetReason() Reason {
+	if r.Reason == "" {
+		return ManualReason
+	}
+	return Reason(r.Reason)
+}
@@ -0,0 +1,15 @@
+package suppression
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	valIs "github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// Validate SPAddRequest
+func (r *SPAddRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Email, validation.Required, validation.Length(1, 255), valIs.Email),
+		validation.Field(&r.Reason, validation.In(string(HardBounceReason), string(ComplaintReason), string(ManualReason))),
+		validation.Field(&r.Note, validation.Length(0, 1000)),
+	)
+}
@@ -0,0 +1,18 @@
+package suppression
+
+import "encoding/json"
+
+// SPAddResponse type
+type SPAddResponse struct {
+	Query SPAddRequest `json:"query"`
+	Data  Suppression  `json:"data"`
+}
+
+// ToJSON covert to JSON
+func (r *SPAddResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,6 @@
+package suppression
+
+// SPCheckRequest type
+type SPCheckRequest struct {
+	Email string `json:"email"`
+}
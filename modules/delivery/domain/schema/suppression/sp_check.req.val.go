@@ -0,0 +1,13 @@
+package suppression
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	valIs "github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// Validate SPCheckRequest
+func (r *SPCheckRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Email, validation.Required, validation.Length(1, 255), valIs.Email),
+	)
+}
@@ -0,0 +1,25 @@
+package suppression
+
+import "encoding/json"
+
+// SPCheckResponse type
+type SPCheckResponse struct {
+	Query SPCheckRequest      `json:"query"`
+	Data  SPCheckResponseData `json:"data"`
+}
+
+// SPCheckResponseData type
+type SPCheckResponseData struct {
+	Email       string       `json:"email"`
+	Suppressed  bool         `json:"suppressed"`
+	Suppression *Suppression `json:"suppression,omitempty"`
+}
+
+// ToJSON covert to JSON
+func (r *SPCheckResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,51 @@
+package suppression
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+)
+
+const (
+	// DefaultListLimit default number of Suppressions per page
+	DefaultListLimit = 50
+	// MaxListLimit maximum number of Suppressions per page
+	MaxListLimit = 200
+)
+
+// SPListRequest type
+type SPListRequest struct {
+	Email  string `json:"email"`  // optional: part of email address (e.g: domain)
+	Reason string `json:"reason"` // optional: HARD_BOUNCE, COMPLAINT or MANUAL
+	Cursor string `json:"cursor"` // optional: nextCursor of the previous page
+	Limit  int    `json:"limit"`  // optional: default 50, max 200
+}
+
+// GetLimit get page limit (or the default)
+func (r *SPListRequest) GetLimit() int {
+	if r.Limit <= 0 {
+		return DefaultListLimit
+	}
+	return r.Limit
+}
+
+// GetCursor get last (internal) record id of the previous page, 0 when empty
+func (r *SPListRequest) GetCursor() (uint64, error) {
+	if r.Cursor == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(r.Cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	id, err := strconv.ParseUint(string(b), 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	return id, nil
+}
+
+// EncodeCursor encode (internal) record id as opaque page cursor
+func EncodeCursor(id uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(id, 10)))
+}
@@ -0,0 +1,20 @@
+package suppression
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validate SPListRequest
+func (r *SPListRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Email, validation.Length(0, 255)),
+		validation.Field(&r.Reason, validation.In(string(HardBounceReason), string(ComplaintReason), string(ManualReason))),
+		validation.Field(&r.Cursor, validation.By(r.validCursor)),
+		validation.Field(&r.Limit, validation.Min(0), validation.Max(MaxListLimit)),
+	)
+}
+
+func (r *SPListRequest) validCursor(value interface{}) error {
+	_, err := r.GetCursor()
+	return err
+}
@@ -0,0 +1,25 @@
+package suppression
+
+import "encoding/json"
+
+// SPListResponse type
+type SPListResponse struct {
+	Query SPListRequest      `json:"query"`
+	Data  SPListResponseData `json:"data"`
+}
+
+// SPListResponseData type
+type SPListResponseData struct {
+	Count        int64          `json:"count"`
+	Suppressions []*Suppression `json:"suppressions"`
+	NextCursor   string         `json:"nextCursor,omitempty"`
+}
+
+// ToJSON covert to JSON
+func (r *SPListResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,6 @@
+package suppression
+
+// SPRemoveRequest type
+type SPRemoveRequest struct {
+	Email string `json:"email"`
+}
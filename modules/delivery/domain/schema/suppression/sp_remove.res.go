@@ -0,0 +1,18 @@
+package suppression
+
+import "encoding/json"
+
+// SPRemoveResponse type
+type SPRemoveResponse struct {
+	Query SPRemoveRequest `json:"query"`
+	Data  Suppression     `json:"data"`
+}
+
+// ToJSON covert to JSON
+func (r *SPRemoveResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
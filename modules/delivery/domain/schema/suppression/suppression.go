@@ -0,0 +1,34 @@
+package suppression
+
+import (
+	"strings"
+	"time"
+)
+
+// Reason represent suppression reason
+type Reason string
+
+const (
+	// HardBounceReason added automatically by a hard bounce
+	HardBounceReason Reason = "HARD_BOUNCE"
+	// ComplaintReason added automatically by a complaint (feedback report)
+	ComplaintReason Reason = "COMPLAINT"
+	// ManualReason added through the API
+	ManualReason Reason = "MANUAL"
+)
+
+// Suppression type (suppressed recipient address)
+type Suppression struct {
+	ID        string     `json:"id"`
+	Email     string     `json:"email"`
+	Reason    Reason     `json:"reason"`
+	Source    string     `json:"source,omitempty"` // bounce ID (automatic) or username (manual)
+	Note      string     `json:"note,omitempty"`
+	CreatedBy string     `json:"createdBy"`
+	CreatedAt *time.Time `json:"createdAt"`
+}
+
+// NormalizeEmail normalize email address for suppression lookup (case insensitive)
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,17 @@
+package templateasset
+
+import "encoding/base64"
+
+// TAAddRequest type
+type TAAddRequest struct {
+	TemplateCode string `json:"templateCode"`
+	ContentID    string `json:"contentId"`
+	Filename     string `json:"filename"`
+	ContentType  string `json:"contentType"`
+	Content      string `json:"content"` // base64 encoded content
+}
+
+// DecodeContent decode base64 encoded content
+func (r *TAAddRequest) DecodeContent() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(r.Content)
+}
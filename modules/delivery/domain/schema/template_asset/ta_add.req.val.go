@@ -0,0 +1,30 @@
+package templateasset
+
+import (
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	valIs "github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// Validate TAAddRequest
+func (r *TAAddRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.TemplateCode, validation.Length(10, 100), validation.Required),
+		validation.Field(&r.ContentID, validation.Required, validation.Length(1, 250), validation.Match(contentIDRegexp)),
+		validation.Field(&r.Filename, validation.Required, validation.Length(1, 255)),
+		validation.Field(&r.ContentType, validation.Required, validation.In(AllowedContentTypes...)),
+		validation.Field(&r.Content, validation.Required, valIs.Base64, validation.By(r.validateSize)),
+	)
+}
+
+func (r *TAAddRequest) validateSize(value interface{}) error {
+	b, err := r.DecodeContent()
+	if err != nil {
+		return nil
+	}
+	if int64(len(b)) > MaxAssetSize {
+		return fmt.Errorf("size (%d bytes) exceeds the maximum of %d bytes", len(b), MaxAssetSize)
+	}
+	return nil
+}
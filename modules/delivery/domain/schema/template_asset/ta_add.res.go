@@ -0,0 +1,19 @@
+package templateasset
+
+import "encoding/json"
+
+// TAAddResponse type
+type TAAddResponse struct {
+	TemplateCode string        `json:"templateCode"`
+	Version      string        `json:"version"`
+	Asset        TemplateAsset `json:"asset"`
+}
+
+// ToJSON covert to JSON
+func (r *TAAddResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
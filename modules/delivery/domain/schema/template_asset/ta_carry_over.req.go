@@ -0,0 +1,6 @@
+package templateasset
+
+// TACarryOverRequest type (copy assets of the previous template version into the current version)
+type TACarryOverRequest struct {
+	TemplateCode string `json:"templateCode"`
+}
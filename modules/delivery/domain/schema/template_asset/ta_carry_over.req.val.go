@@ -0,0 +1,12 @@
+package templateasset
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validate TACarryOverRequest
+func (r *TACarryOverRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.TemplateCode, validation.Length(10, 100), validation.Required),
+	)
+}
@@ -0,0 +1,7 @@
+package templateasset
+
+// TADeleteRequest type
+type TADeleteRequest struct {
+	TemplateCode string `json:"templateCode"`
+	ContentID    string `json:"contentId"`
+}
@@ -0,0 +1,13 @@
+package templateasset
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validate TADeleteRequest
+func (r *TADeleteRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.TemplateCode, validation.Length(10, 100), validation.Required),
+		validation.Field(&r.ContentID, validation.Required, validation.Length(1, 250)),
+	)
+}
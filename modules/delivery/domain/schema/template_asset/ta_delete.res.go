@@ -0,0 +1,18 @@
+package templateasset
+
+import "encoding/json"
+
+// TADeleteResponse type
+type TADeleteResponse struct {
+	Query TADeleteRequest `json:"query"`
+	Data  TemplateAsset   `json:"data"`
+}
+
+// ToJSON covert to JSON
+func (r *TADeleteResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
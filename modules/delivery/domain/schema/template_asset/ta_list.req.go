@@ -0,0 +1,7 @@
+package templateasset
+
+// TAListRequest type
+type TAListRequest struct {
+	TemplateCode string `json:"templateCode"`
+	Version      string `json:"version"` // optional, default: current (default) template version
+}
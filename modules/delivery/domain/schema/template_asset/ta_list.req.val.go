@@ -0,0 +1,13 @@
+package templateasset
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validate TAListRequest
+func (r *TAListRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.TemplateCode, validation.Length(10, 100), validation.Required),
+		validation.Field(&r.Version, validation.Length(0, 100)),
+	)
+}
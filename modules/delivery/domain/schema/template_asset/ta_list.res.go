@@ -0,0 +1,26 @@
+package templateasset
+
+import "encoding/json"
+
+// TAListResponse type
+type TAListResponse struct {
+	Query TAListRequest      `json:"query"`
+	Data  TAListResponseData `json:"data"`
+}
+
+// TAListResponseData type
+type TAListResponseData struct {
+	TemplateCode string           `json:"templateCode"`
+	Version      string           `json:"version"`
+	Count        int64            `json:"count"`
+	Assets       []*TemplateAsset `json:"assets"`
+}
+
+// ToJSON covert to JSON
+func (r *TAListResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,29 @@
+package templateasset
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// MaxAssetSize represent maximum size (bytes) of one template asset
+const MaxAssetSize int64 = 1 << 20
+
+// AllowedContentTypes represent allowed template asset (inline image) content types
+var AllowedContentTypes = []interface{}{"image/png", "image/jpeg", "image/gif"}
+
+// contentIDRegexp represent allowed Content-ID (without angle brackets), e.g: `logo` or `logo@domain.tld`
+var contentIDRegexp = regexp.MustCompile(`^[^\s<>@"/]+(@[^\s<>@"/]+)?$`)
+
+// TemplateAsset type (inline image of an email template version, referenced as `cid:<contentId>` in bodyTpl)
+type TemplateAsset struct {
+	UUID        string `json:"uuid"`
+	ContentID   string `json:"contentId"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+}
+
+// CIDReference get `cid:` reference (used in bodyTpl)
+func (a *TemplateAsset) CIDReference() string {
+	return fmt.Sprintf("cid:%s", a.ContentID)
+}
@@ -0,0 +1,69 @@
+package templatesetting
+
+import (
+	"time"
+
+	domSchemaUnsubscribe "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/unsubscribe"
+)
+
+// Category represent email template category
+type Category string
+
+const (
+	// TransactionalCategory transactional emails (default), e.g: account activation
+	TransactionalCategory Category = "TRANSACTIONAL"
+	// MarketingCategory marketing (bulk) emails, sent with unsubscribe links and List-Unsubscribe headers
+	MarketingCategory Category = "MARKETING"
+)
+
+// TextTemplateName template executed of the text body template (as bodyTpl)
+const TextTemplateName = "T"
+
+// TemplateSetting type (delivery settings of an email template)
+type TemplateSetting struct {
+	TemplateCode      string     `json:"templateCode"`
+	Category          Category   `json:"category"`
+	TrackOpens        bool       `json:"trackOpens"`                  // inject open tracking pixel into HTML bodies
+	TrackClicks       bool       `json:"trackClicks"`                 // rewrite links of HTML bodies through the signed click redirect
+	TextBodyTpl       string     `json:"textBodyTpl"`                 // plain-text alternative of HTML bodies (template), empty: generated from the HTML body
+	TextBodyVersionID uint64     `json:"textBodyVersionId,omitempty"` // template version the text body template was set for
+	TextBodyOutdated  bool       `json:"textBodyOutdated,omitempty"`  // the template has a new (default) version: the text body is generated from the HTML body
+	InlineCSS         bool       `json:"inlineCss"`                   // inline CSS rules of <style> blocks into style attributes of HTML bodies
+	UpdatedBy         string     `json:"updatedBy,omitempty"`
+	UpdatedAt         *time.Time `json:"updatedAt,omitempty"`
+}
+
+// NewTemplateSetting new TemplateSetting with default settings (of a template without settings)
+func NewTemplateSetting(templateCode string) *TemplateSetting {
+	return &TemplateSetting{
+		TemplateCode: templateCode,
+		Category:     TransactionalCategory,
+	}
+}
+
+// GetTextBodyTpl get text body template of the template version, empty: not set, or set for another template version
+// (e.g: before the template was updated), the text body is generated from the HTML body
+func (s *TemplateSetting) GetTextBodyTpl(templateVersionID uint64) string {
+	if s == nil || s.TextBodyVersionID != templateVersionID {
+		return ""
+	}
+	return s.TextBodyTpl
+}
+
+// IsTextBodyOutdated check whether the text body template is set for another (previous) template version
+func (s *TemplateSetting) IsTextBodyOutdated(templateVersionID uint64) bool {
+	return s != nil && s.TextBodyTpl != "" && s.TextBodyVersionID != templateVersionID
+}
+
+// IsMarketing check whether the template is a marketing template
+func (s *TemplateSetting) IsMarketing() bool {
+	return s != nil && s.Category == MarketingCategory
+}
+
+// ProvidedTemplateData list the templateData keys provided on sending (not by the request), e.g: the unsubscribe link of marketing templates
+func (s *TemplateSetting) ProvidedTemplateData() []string {
+	if s.IsMarketing() {
+		return []string{domSchemaUnsubscribe.URLTemplateData}
+	}
+	return nil
+}
@@ -0,0 +1,6 @@
+package templatesetting
+
+// TSFindRequest type
+type TSFindRequest struct {
+	TemplateCode string `json:"templateCode"`
+}
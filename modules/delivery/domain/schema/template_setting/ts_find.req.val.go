@@ -0,0 +1,12 @@
+package templatesetting
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validate TSFindRequest
+func (r *TSFindRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.TemplateCode, validation.Length(10, 100), validation.Required),
+	)
+}
@@ -0,0 +1,18 @@
+package templatesetting
+
+import "encoding/json"
+
+// TSFindResponse type
+type TSFindResponse struct {
+	Query TSFindRequest   `json:"query"`
+	Data  TemplateSetting `json:"data"`
+}
+
+// ToJSON covert to JSON
+func (r *TSFindResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
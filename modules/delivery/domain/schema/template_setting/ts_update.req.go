@@ -0,0 +1,11 @@
+package templatesetting
+
+// TSUpdateRequest type
+type TSUpdateRequest struct {
+	TemplateCode string  `json:"templateCode"`
+	Category     string  `json:"category"`    // TRANSACTIONAL or MARKETING, empty: unchanged
+	TrackOpens   *bool   `json:"trackOpens"`  // nil: unchanged
+	TrackClicks  *bool   `json:"trackClicks"` // nil: unchanged
+	TextBodyTpl  *string `json:"textBodyTpl"` // nil: unchanged, empty: generated from the HTML body
+	InlineCSS    *bool   `json:"inlineCss"`   // nil: unchanged
+}
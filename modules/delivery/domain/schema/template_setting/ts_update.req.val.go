@@ -0,0 +1,33 @@
+package templatesetting
+
+import (
+	"fmt"
+	"text/template"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validate TSUpdateRequest
+func (r *TSUpdateRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.TemplateCode, validation.Length(10, 100), validation.Required),
+		validation.Field(&r.Category, validation.In(string(TransactionalCategory), string(MarketingCategory))),
+		validation.Field(&r.TextBodyTpl, validation.By(validTextTemplate)),
+	)
+}
+
+// validTextTemplate check text body template: a Go template defining `T` (as bodyTpl), e.g: `{{define "T"}}Hello{{end}}`
+func validTextTemplate(value interface{}) error {
+	tpl, _ := value.(*string)
+	if tpl == nil || *tpl == "" {
+		return nil
+	}
+	t, err := template.New("text").Parse(*tpl)
+	if err != nil {
+		return fmt.Errorf("must be a valid template: %s", err.Error())
+	}
+	if t.Lookup(TextTemplateName) == nil {
+		return fmt.Errorf("must define template `%s`", TextTemplateName)
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package templatesetting
+
+import "encoding/json"
+
+// TSUpdateResponse type
+type TSUpdateResponse struct {
+	Query TSUpdateRequest `json:"query"`
+	Data  TemplateSetting `json:"data"`
+}
+
+// ToJSON covert to JSON
+func (r *TSUpdateResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,6 @@
+package tracking
+
+// MessageClicksRequest type
+type MessageClicksRequest struct {
+	MessageID string `json:"messageId"`
+}
@@ -0,0 +1,13 @@
+package tracking
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	valIs "github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// Validate MessageClicksRequest
+func (r *MessageClicksRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.MessageID, validation.Required, valIs.UUID),
+	)
+}
@@ -0,0 +1,18 @@
+package tracking
+
+import "encoding/json"
+
+// MessageClicksResponse type
+type MessageClicksResponse struct {
+	Query MessageClicksRequest `json:"query"`
+	Data  ClickStats           `json:"data"`
+}
+
+// ToJSON covert to JSON
+func (r *MessageClicksResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,6 @@
+package tracking
+
+// RecordClickRequest type
+type RecordClickRequest struct {
+	Token string `json:"token"` // signed click redirect token
+}
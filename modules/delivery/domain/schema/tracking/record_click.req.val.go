@@ -0,0 +1,12 @@
+package tracking
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validate RecordClickRequest
+func (r *RecordClickRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Token, validation.Required, validation.Length(1, 8192)),
+	)
+}
@@ -0,0 +1,23 @@
+package tracking
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// RecordClickResponse type
+type RecordClickResponse struct {
+	MessageID string    `json:"messageId"`
+	LinkIndex int       `json:"linkIndex"`
+	URL       string    `json:"url"` // redirect url
+	ClickedAt time.Time `json:"clickedAt"`
+}
+
+// ToJSON covert to JSON
+func (r *RecordClickResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
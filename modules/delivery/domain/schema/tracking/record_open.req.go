@@ -0,0 +1,6 @@
+package tracking
+
+// RecordOpenRequest type
+type RecordOpenRequest struct {
+	Token string `json:"token"` // signed open tracking pixel token
+}
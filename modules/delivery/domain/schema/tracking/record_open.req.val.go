@@ -0,0 +1,12 @@
+package tracking
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validate RecordOpenRequest
+func (r *RecordOpenRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Token, validation.Required, validation.Length(1, 2048)),
+	)
+}
@@ -0,0 +1,22 @@
+package tracking
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// RecordOpenResponse type
+type RecordOpenResponse struct {
+	MessageID     string     `json:"messageId"`
+	FirstOpenedAt *time.Time `json:"firstOpenedAt"`
+	OpenCount     int        `json:"openCount"`
+}
+
+// ToJSON covert to JSON
+func (r *RecordOpenResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,22 @@
+package tracking
+
+import "time"
+
+// TemplateClicksRequest type
+type TemplateClicksRequest struct {
+	TemplateCode string `json:"templateCode"`
+	Since        string `json:"since"` // optional: RFC 3339 date-time, clicked at or after
+	Until        string `json:"until"` // optional: RFC 3339 date-time, clicked before
+}
+
+// GetSince get Since as time (zero when empty)
+func (r *TemplateClicksRequest) GetSince() time.Time {
+	t, _ := time.Parse(time.RFC3339, r.Since)
+	return t
+}
+
+// GetUntil get Until as time (zero when empty)
+func (r *TemplateClicksRequest) GetUntil() time.Time {
+	t, _ := time.Parse(time.RFC3339, r.Until)
+	return t
+}
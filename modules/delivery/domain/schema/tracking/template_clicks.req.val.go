@@ -0,0 +1,28 @@
+package tracking
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validate TemplateClicksRequest
+func (r *TemplateClicksRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.TemplateCode, validation.Required, validation.Length(10, 100)),
+		validation.Field(&r.Since, validation.Date(time.RFC3339).Error("must be a valid RFC 3339 date-time")),
+		validation.Field(&r.Until, validation.Date(time.RFC3339).Error("must be a valid RFC 3339 date-time"), validation.By(r.afterSince)),
+	)
+}
+
+func (r *TemplateClicksRequest) afterSince(value interface{}) error {
+	since, until := r.GetSince(), r.GetUntil()
+	if since.IsZero() || until.IsZero() {
+		return nil
+	}
+	if !until.After(since) {
+		return errors.New("must be after since")
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package tracking
+
+import "encoding/json"
+
+// TemplateClicksResponse type
+type TemplateClicksResponse struct {
+	Query TemplateClicksRequest `json:"query"`
+	Data  ClickStats            `json:"data"`
+}
+
+// ToJSON covert to JSON
+func (r *TemplateClicksResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,32 @@
+package tracking
+
+import "time"
+
+// OpenToken type (payload of the signed open tracking pixel link)
+type OpenToken struct {
+	MessageID string `json:"m"` // message record ID
+}
+
+// ClickToken type (payload of the signed click redirect link)
+type ClickToken struct {
+	MessageID string `json:"m"` // message record ID
+	LinkIndex int    `json:"i"` // index of the link in the HTML body
+	URL       string `json:"u"` // original link url
+}
+
+// ClickStats type (click counts of a message or of a template)
+type ClickStats struct {
+	MessageID       string        `json:"messageId,omitempty"`
+	TemplateCode    string        `json:"templateCode"`
+	TotalClicks     int64         `json:"totalClicks"`
+	ClickedMessages int64         `json:"clickedMessages"` // messages with at least one click
+	Links           []*LinkClicks `json:"links"`
+}
+
+// LinkClicks type (click count of a link, by link index and url)
+type LinkClicks struct {
+	LinkIndex     int        `json:"linkIndex"`
+	URL           string     `json:"url"`
+	Clicks        int64      `json:"clicks"`
+	LastClickedAt *time.Time `json:"lastClickedAt"`
+}
@@ -0,0 +1,20 @@
+package unsubscribe
+
+// URLTemplateData template data key of the unsubscribe link (marketing templates), e.g: `{{index . "Unsubscribe.URL"}}`
+const URLTemplateData = "Unsubscribe.URL"
+
+// Method represent how the recipient unsubscribed
+type Method string
+
+const (
+	// OneClickMethod RFC 8058 one-click unsubscribe (List-Unsubscribe-Post), sent by the mail client
+	OneClickMethod Method = "ONE_CLICK"
+	// LinkMethod unsubscribe link in the email body, confirmed on the unsubscribe page
+	LinkMethod Method = "LINK"
+)
+
+// Token type (payload of the signed unsubscribe link)
+type Token struct {
+	MessageID string `json:"m"` // message record ID
+	Email     string `json:"e"` // recipient email address
+}
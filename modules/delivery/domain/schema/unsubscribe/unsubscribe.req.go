@@ -0,0 +1,7 @@
+package unsubscribe
+
+// UnsubscribeRequest type
+type UnsubscribeRequest struct {
+	Token  string `json:"token"`  // signed unsubscribe link token
+	Method string `json:"method"` // ONE_CLICK or LINK (not used to find the unsubscribe)
+}
@@ -0,0 +1,13 @@
+package unsubscribe
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validate UnsubscribeRequest
+func (r *UnsubscribeRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Token, validation.Required, validation.Length(1, 2048)),
+		validation.Field(&r.Method, validation.In(string(OneClickMethod), string(LinkMethod))),
+	)
+}
@@ -0,0 +1,20 @@
+package unsubscribe
+
+import "encoding/json"
+
+// UnsubscribeResponse type
+type UnsubscribeResponse struct {
+	Email        string `json:"email"`
+	MessageID    string `json:"messageId"`
+	TemplateCode string `json:"templateCode,omitempty"`
+	Unsubscribed bool   `json:"unsubscribed"`
+}
+
+// ToJSON covert to JSON
+func (r *UnsubscribeResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType represent webhook event type
+type EventType string
+
+const (
+	// MessageSentEvent message accepted by SMTP Server
+	MessageSentEvent EventType = "message.sent"
+	// MessageFailedEvent message delivery failed (final)
+	MessageFailedEvent EventType = "message.failed"
+	// MessageBouncedEvent message bounced by the recipient mail server
+	MessageBouncedEvent EventType = "message.bounced"
+	// TemplateUpdatedEvent email template created, updated, (de)activated or deleted
+	TemplateUpdatedEvent EventType = "template.updated"
+)
+
+// EventTypes list of supported event types
+var EventTypes = []EventType{MessageSentEvent, MessageFailedEvent, MessageBouncedEvent, TemplateUpdatedEvent}
+
+// Webhook type (registered webhook endpoint)
+type Webhook struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	URL       string     `json:"url"`
+	Events    []string   `json:"events"`
+	OwnedBy   string     `json:"ownedBy"`
+	CreatedAt *time.Time `json:"createdAt"`
+}
+
+// Delivery type (one event delivery to a webhook endpoint)
+type Delivery struct {
+	ID             string          `json:"id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"nextAttemptAt,omitempty"`
+	DeliveredAt    *time.Time      `json:"deliveredAt"`
+	ResponseStatus int             `json:"responseStatus"`
+	LastError      string          `json:"lastError,omitempty"`
+	ReplayOf       string          `json:"replayOf,omitempty"`
+	CreatedAt      *time.Time      `json:"createdAt"`
+}
+
+// Event type (request body POSTed to the webhook endpoint)
+type Event struct {
+	ID         string      `json:"id"`
+	Type       EventType   `json:"type"`
+	OccurredAt time.Time   `json:"occurredAt"`
+	Data       interface{} `json:"data"`
+}
+
+// MessageEventData type (data of message.* events)
+type MessageEventData struct {
+	ID              string `json:"id"`
+	MessageIDHeader string `json:"messageIdHeader"`
+	TemplateCode    string `json:"templateCode"`
+	ToEmail         string `json:"toEmail"`
+	Status          string `json:"status"`
+	SMTPResponse    string `json:"smtpResponse,omitempty"`
+	SentBy          string `json:"sentBy"`
+}
+
+// TemplateAction represent changes of an email template
+type TemplateAction string
+
+const (
+	// TemplateCreated template created
+	TemplateCreated TemplateAction = "CREATED"
+	// TemplateUpdated new template version
+	TemplateUpdated TemplateAction = "UPDATED"
+	// TemplateSetActive template activated or deactivated
+	TemplateSetActive TemplateAction = "SET_ACTIVE"
+	// TemplateDeleted template deleted
+	TemplateDeleted TemplateAction = "DELETED"
+)
+
+// TemplateEventData type (data of template.* events)
+type TemplateEventData struct {
+	TemplateCode string         `json:"templateCode"`
+	Action       TemplateAction `json:"action"`
+	UpdatedBy    string         `json:"updatedBy"`
+}
+
+// DueDelivery type (claimed webhook delivery, due to be sent)
+type DueDelivery struct {
+	ID       string `json:"id"`
+	URL      string `json:"url"`
+	Secret   string `json:"-"`
+	Event    string `json:"event"`
+	Payload  []byte `json:"payload"`
+	Attempts int    `json:"attempts"`
+}
+
+// DeliveryResult type (response status of the webhook endpoint, the response body is not kept)
+type DeliveryResult struct {
+	StatusCode int `json:"statusCode"`
+}
@@ -0,0 +1,8 @@
+package webhook
+
+// WHCreateRequest type
+type WHCreateRequest struct {
+	Name   string   `json:"name"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"` // message.sent, message.failed, message.bounced, template.updated
+}
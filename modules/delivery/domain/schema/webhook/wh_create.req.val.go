@@ -0,0 +1,42 @@
+package webhook
+
+import (
+	"errors"
+	"net/url"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	valIs "github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// Validate WHCreateRequest
+func (r *WHCreateRequest) Validate() error {
+	events := make([]interface{}, 0, len(EventTypes))
+	for _, v := range EventTypes {
+		events = append(events, string(v))
+	}
+
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&r.URL, validation.Required, validation.Length(1, 2048), valIs.URL, validation.By(httpURL)),
+		validation.Field(&r.Events, validation.Required, validation.Each(validation.In(events...))),
+	)
+}
+
+// httpURL check URL scheme is http or https, and the host is public
+func httpURL(value interface{}) error {
+	str, _ := value.(string)
+	if str == "" {
+		return nil
+	}
+	u, err := url.Parse(str)
+	if err != nil {
+		return nil // handled by is.URL
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("must be an http or https URL")
+	}
+	if !IsPublicHost(u.Hostname()) {
+		return errors.New("must not be a local, private, loopback or link-local address")
+	}
+	return nil
+}
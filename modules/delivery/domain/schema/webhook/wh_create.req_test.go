@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"net"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWHCreateRequest_Validate(t *testing.T) {
+	r := &WHCreateRequest{
+		Name:   "account-service",
+		URL:    "https://account.example.com/hooks/email",
+		Events: []string{string(MessageSentEvent), string(MessageFailedEvent)},
+	}
+	assert.NoError(t, r.Validate())
+
+	r = &WHCreateRequest{
+		Name:   "account-service",
+		URL:    "ftp://account.example.com/hooks/email",
+		Events: []string{"message.opened"},
+	}
+	err := r.Validate()
+	if assert.Error(t, err) {
+		errs, ok := err.(validation.Errors)
+		if assert.True(t, ok) {
+			assert.Contains(t, errs, "url")
+			assert.Contains(t, errs, "events")
+		}
+	}
+
+	// local endpoints (SSRF)
+	for _, v := range []string{
+		"http://localhost:8080/hooks",
+		"http://127.0.0.1/hooks",
+		"http://10.0.0.5/hooks",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/hooks",
+		"http://[fe80::1]/hooks",
+	} {
+		r := &WHCreateRequest{Name: "account-service", URL: v, Events: []string{string(MessageSentEvent)}}
+		if err := r.Validate(); assert.Error(t, err, v) {
+			assert.Contains(t, err.(validation.Errors), "url", v)
+		}
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	assert.True(t, IsPublicIP(net.ParseIP("93.184.216.34")))
+	assert.True(t, IsPublicIP(net.ParseIP("2606:2800:220:1:248:1893:25c8:1946")))
+
+	assert.False(t, IsPublicIP(net.ParseIP("192.168.1.10")))
+	assert.False(t, IsPublicIP(net.ParseIP("172.31.0.1")))
+	assert.False(t, IsPublicIP(net.ParseIP("0.0.0.0")))
+	assert.False(t, IsPublicIP(net.ParseIP("::ffff:127.0.0.1")))
+	assert.False(t, IsPublicIP(net.ParseIP("fd00::1")))
+}
@@ -0,0 +1,18 @@
+package webhook
+
+import "encoding/json"
+
+// WHCreateResponse type
+type WHCreateResponse struct {
+	Webhook
+	Secret string `json:"secret"` // signing secret, only returned on create
+}
+
+// ToJSON covert to JSON
+func (r *WHCreateResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
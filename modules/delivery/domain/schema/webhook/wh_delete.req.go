@@ -0,0 +1,6 @@
+package webhook
+
+// WHDeleteRequest type
+type WHDeleteRequest struct {
+	ID string `json:"id"`
+}
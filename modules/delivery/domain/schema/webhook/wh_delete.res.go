@@ -0,0 +1,18 @@
+package webhook
+
+import "encoding/json"
+
+// WHDeleteResponse type
+type WHDeleteResponse struct {
+	Query WHDeleteRequest `json:"query"`
+	Data  Webhook         `json:"data"`
+}
+
+// ToJSON covert to JSON
+func (r *WHDeleteResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
@@ -0,0 +1,6 @@
+package webhook
+
+// WHListRequest type
+type WHListRequest struct {
+	Event string `json:"event"` // optional
+}
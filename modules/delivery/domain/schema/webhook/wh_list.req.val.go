@@ -0,0 +1,12 @@
+package webhook
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Validate WHListRequest
+func (r *WHListRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Event, validation.Length(0, 100)),
+	)
+}
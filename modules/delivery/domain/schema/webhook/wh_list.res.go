@@ -0,0 +1,24 @@
+package webhook
+
+import "encoding/json"
+
+// WHListResponse type
+type WHListResponse struct {
+	Query WHListRequest      `json:"query"`
+	Data  WHListResponseData `json:"data"`
+}
+
+// WHListResponseData type
+type WHListResponseData struct {
+	Count    int64      `json:"count"`
+	Webhooks []*Webhook `json:"webhooks"`
+}
+
+// ToJSON covert to JSON
+func (r *WHListResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
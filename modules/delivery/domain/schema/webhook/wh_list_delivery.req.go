@@ -0,0 +1,8 @@
+package webhook
+
+// WHListDeliveryRequest type
+type WHListDeliveryRequest struct {
+	ID     string `json:"id"`
+	Status string `json:"status"` // optional: PENDING, DELIVERING, DELIVERED or FAILED
+	Limit  int    `json:"limit"`  // optional, default: 50 (latest deliveries)
+}
@@ -0,0 +1,15 @@
+package webhook
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	valIs "github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// Validate WHListDeliveryRequest
+func (r *WHListDeliveryRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ID, validation.Required, valIs.UUID),
+		validation.Field(&r.Status, validation.In("PENDING", "DELIVERING", "DELIVERED", "FAILED")),
+		validation.Field(&r.Limit, validation.Min(0), validation.Max(500)),
+	)
+}
@@ -0,0 +1,25 @@
+package webhook
+
+import "encoding/json"
+
+// WHListDeliveryResponse type
+type WHListDeliveryResponse struct {
+	Query WHListDeliveryRequest      `json:"query"`
+	Data  WHListDeliveryResponseData `json:"data"`
+}
+
+// WHListDeliveryResponseData type
+type WHListDeliveryResponseData struct {
+	Webhook    Webhook     `json:"webhook"`
+	Count      int64       `json:"count"`
+	Deliveries []*Delivery `json:"deliveries"`
+}
+
+// ToJSON covert to JSON
+func (r *WHListDeliveryResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
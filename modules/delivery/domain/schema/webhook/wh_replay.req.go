@@ -0,0 +1,7 @@
+package webhook
+
+// WHReplayRequest type
+type WHReplayRequest struct {
+	ID         string `json:"id"`
+	DeliveryID string `json:"deliveryId"`
+}
@@ -0,0 +1,14 @@
+package webhook
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	valIs "github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// Validate WHReplayRequest
+func (r *WHReplayRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ID, validation.Required, valIs.UUID),
+		validation.Field(&r.DeliveryID, validation.Required, valIs.UUID),
+	)
+}
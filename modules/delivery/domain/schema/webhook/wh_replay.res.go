@@ -0,0 +1,18 @@
+package webhook
+
+import "encoding/json"
+
+// WHReplayResponse type
+type WHReplayResponse struct {
+	Query WHReplayRequest `json:"query"`
+	Data  Delivery        `json:"data"` // the new (PENDING) delivery
+}
+
+// ToJSON covert to JSON
+func (r *WHReplayResponse) ToJSON() []byte {
+	json, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+	return json
+}
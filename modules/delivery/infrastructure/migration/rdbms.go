@@ -0,0 +1,361 @@
+package migration
+
+import (
+	migRunner "github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/migration/rdbms"
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+)
+
+// NewRDBMSMigration create new RDBMSMigration
+func NewRDBMSMigration(h *handler.Handler) (*RDBMSMigration, error) {
+	var err error
+
+	mig := new(RDBMSMigration)
+	mig.handler = h
+
+	cfg, err := h.GetDefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+	if mig.migrator, err = migRDBMS.NewBaseGormMigrator(h, cfg.Databases.EmailDB.ConnectionName); err != nil {
+		return nil, err
+	}
+
+	return mig, nil
+}
+
+// RDBMSMigration represent RDBMSMigration
+type RDBMSMigration struct {
+	handler  *handler.Handler
+	migrator *migRDBMS.BaseGormMigrator
+}
+
+// Run run migration
+func (m *RDBMSMigration) Run() error {
+	if err := m._runMigrates(); err != nil {
+		return err
+	}
+	if err := m._runSeeds(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RollBack rollback migration
+func (m *RDBMSMigration) RollBack() error {
+	if err := m._rollBackSeeds(); err != nil {
+		return err
+	}
+
+	if err := m._rollBackMigrates(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *RDBMSMigration) _runMigrates() error {
+	migrate20261018001InitTable, err := migRunner.NewMigrate20261018001InitTable(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018002ScheduledMessage, err := migRunner.NewMigrate20261018002ScheduledMessage(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018003RecurringSchedule, err := migRunner.NewMigrate20261018003RecurringSchedule(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018004OutboxMessage, err := migRunner.NewMigrate20261018004OutboxMessage(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018005IdempotencyKey, err := migRunner.NewMigrate20261018005IdempotencyKey(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018006Message, err := migRunner.NewMigrate20261018006Message(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018007Webhook, err := migRunner.NewMigrate20261018007Webhook(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018008Bounce, err := migRunner.NewMigrate20261018008Bounce(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018009Suppression, err := migRunner.NewMigrate20261018009Suppression(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018010TemplateSetting, err := migRunner.NewMigrate20261018010TemplateSetting(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018011OpenTracking, err := migRunner.NewMigrate20261018011OpenTracking(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018012ClickTracking, err := migRunner.NewMigrate20261018012ClickTracking(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018013TextBody, err := migRunner.NewMigrate20261018013TextBody(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018014InlineCSS, err := migRunner.NewMigrate20261018014InlineCSS(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018015WebhookEvent, err := migRunner.NewMigrate20261018015WebhookEvent(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018016TextBodyVersion, err := migRunner.NewMigrate20261018016TextBodyVersion(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018017WebhookResponseBody, err := migRunner.NewMigrate20261018017WebhookResponseBody(m.handler)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := m.handler.GetDefaultConfig()
+	if err != nil {
+		return err
+	}
+	if err := m.migrator.RunMigrates(m.handler, cfg.Databases.EmailDB.ConnectionName,
+		migrate20261018001InitTable,
+		migrate20261018002ScheduledMessage,
+		migrate20261018003RecurringSchedule,
+		migrate20261018004OutboxMessage,
+		migrate20261018005IdempotencyKey,
+		migrate20261018006Message,
+		migrate20261018007Webhook,
+		migrate20261018008Bounce,
+		migrate20261018009Suppression,
+		migrate20261018010TemplateSetting,
+		migrate20261018011OpenTracking,
+		migrate20261018012ClickTracking,
+		migrate20261018013TextBody,
+		migrate20261018014InlineCSS,
+		migrate20261018015WebhookEvent,
+		migrate20261018016TextBodyVersion,
+		migrate20261018017WebhookResponseBody,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *RDBMSMigration) _rollBackMigrates() error {
+	migrate20261018001InitTable, err := migRunner.NewMigrate20261018001InitTable(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018002ScheduledMessage, err := migRunner.NewMigrate20261018002ScheduledMessage(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018003RecurringSchedule, err := migRunner.NewMigrate20261018003RecurringSchedule(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018004OutboxMessage, err := migRunner.NewMigrate20261018004OutboxMessage(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018005IdempotencyKey, err := migRunner.NewMigrate20261018005IdempotencyKey(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018006Message, err := migRunner.NewMigrate20261018006Message(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018007Webhook, err := migRunner.NewMigrate20261018007Webhook(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018008Bounce, err := migRunner.NewMigrate20261018008Bounce(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018009Suppression, err := migRunner.NewMigrate20261018009Suppression(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018010TemplateSetting, err := migRunner.NewMigrate20261018010TemplateSetting(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018011OpenTracking, err := migRunner.NewMigrate20261018011OpenTracking(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018012ClickTracking, err := migRunner.NewMigrate20261018012ClickTracking(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018013TextBody, err := migRunner.NewMigrate20261018013TextBody(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018014InlineCSS, err := migRunner.NewMigrate20261018014InlineCSS(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018015WebhookEvent, err := migRunner.NewMigrate20261018015WebhookEvent(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018016TextBodyVersion, err := migRunner.NewMigrate20261018016TextBodyVersion(m.handler)
+	if err != nil {
+		return err
+	}
+	migrate20261018017WebhookResponseBody, err := migRunner.NewMigrate20261018017WebhookResponseBody(m.handler)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := m.handler.GetDefaultConfig()
+	if err != nil {
+		return err
+	}
+	if err := m.migrator.RollBackMigrates(m.handler, cfg.Databases.EmailDB.ConnectionName,
+		// reverse order
+		migrate20261018017WebhookResponseBody,
+		migrate20261018016TextBodyVersion,
+		migrate20261018015WebhookEvent,
+		migrate20261018014InlineCSS,
+		migrate20261018013TextBody,
+		migrate20261018012ClickTracking,
+		migrate20261018011OpenTracking,
+		migrate20261018010TemplateSetting,
+		migrate20261018009Suppression,
+		migrate20261018008Bounce,
+		migrate20261018007Webhook,
+		migrate20261018006Message,
+		migrate20261018005IdempotencyKey,
+		migrate20261018004OutboxMessage,
+		migrate20261018003RecurringSchedule,
+		migrate20261018002ScheduledMessage,
+		migrate20261018001InitTable,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *RDBMSMigration) _runSeeds() error {
+	// iam
+	if err := m._runIdentitySeeds(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *RDBMSMigration) _rollBackSeeds() error {
+	// iam
+	if err := m._rollBackIdentitySeeds(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *RDBMSMigration) _runIdentitySeeds() error {
+	seed20261018001InitCasbinSend, err := migRunner.NewSeed20261018001InitCasbinSend(m.handler)
+	if err != nil {
+		return err
+	}
+	seed20261018002InitCasbinTemplate, err := migRunner.NewSeed20261018002InitCasbinTemplate(m.handler)
+	if err != nil {
+		return err
+	}
+	seed20261018003InitCasbinSchedule, err := migRunner.NewSeed20261018003InitCasbinSchedule(m.handler)
+	if err != nil {
+		return err
+	}
+	seed20261018004InitCasbinMessage, err := migRunner.NewSeed20261018004InitCasbinMessage(m.handler)
+	if err != nil {
+		return err
+	}
+	seed20261018005InitCasbinWebhook, err := migRunner.NewSeed20261018005InitCasbinWebhook(m.handler)
+	if err != nil {
+		return err
+	}
+	seed20261018006InitCasbinSuppression, err := migRunner.NewSeed20261018006InitCasbinSuppression(m.handler)
+	if err != nil {
+		return err
+	}
+	seed20261018007InitCasbinTracking, err := migRunner.NewSeed20261018007InitCasbinTracking(m.handler)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := m.handler.GetDefaultConfig()
+	if err != nil {
+		return err
+	}
+	if err := m.migrator.RunSeeds(m.handler, cfg.Databases.IdentityDB.ConnectionName,
+		seed20261018001InitCasbinSend,
+		seed20261018002InitCasbinTemplate,
+		seed20261018003InitCasbinSchedule,
+		seed20261018004InitCasbinMessage,
+		seed20261018005InitCasbinWebhook,
+		seed20261018006InitCasbinSuppression,
+		seed20261018007InitCasbinTracking); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *RDBMSMigration) _rollBackIdentitySeeds() error {
+	seed20261018001InitCasbinSend, err := migRunner.NewSeed20261018001InitCasbinSend(m.handler)
+	if err != nil {
+		return err
+	}
+	seed20261018002InitCasbinTemplate, err := migRunner.NewSeed20261018002InitCasbinTemplate(m.handler)
+	if err != nil {
+		return err
+	}
+	seed20261018003InitCasbinSchedule, err := migRunner.NewSeed20261018003InitCasbinSchedule(m.handler)
+	if err != nil {
+		return err
+	}
+	seed20261018004InitCasbinMessage, err := migRunner.NewSeed20261018004InitCasbinMessage(m.handler)
+	if err != nil {
+		return err
+	}
+	seed20261018005InitCasbinWebhook, err := migRunner.NewSeed20261018005InitCasbinWebhook(m.handler)
+	if err != nil {
+		return err
+	}
+	seed20261018006InitCasbinSuppression, err := migRunner.NewSeed20261018006InitCasbinSuppression(m.handler)
+	if err != nil {
+		return err
+	}
+	seed20261018007InitCasbinTracking, err := migRunner.NewSeed20261018007InitCasbinTracking(m.handler)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := m.handler.GetDefaultConfig()
+	if err != nil {
+		return err
+	}
+	if err := m.migrator.RollBackSeeds(m.handler, cfg.Databases.IdentityDB.ConnectionName,
+		seed20261018001InitCasbinSend,
+		seed20261018002InitCasbinTemplate,
+		seed20261018003InitCasbinSchedule,
+		seed20261018004InitCasbinMessage,
+		seed20261018005InitCasbinWebhook,
+		seed20261018006InitCasbinSuppression,
+		seed20261018007InitCasbinTracking); err != nil {
+		return err
+	}
+	return nil
+}
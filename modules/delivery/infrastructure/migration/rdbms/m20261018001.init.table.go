@@ -0,0 +1,58 @@
+package rdbms
+
+import (
+	"fmt"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+	"gorm.io/gorm"
+)
+
+// Migrate20261018001InitTable type
+type Migrate20261018001InitTable struct {
+	migRDBMS.BaseGormMigratorRunner
+}
+
+// NewMigrate20261018001InitTable constructor
+func NewMigrate20261018001InitTable(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	gmr := new(Migrate20261018001InitTable)
+	gmr.SetHandler(h)
+	gmr.SetID("Migrate20261018001InitTable")
+	return gmr, nil
+}
+
+// GetID get Migrate20261018001InitTable ID
+func (dmr *Migrate20261018001InitTable) GetID() string {
+	return fmt.Sprintf("%T", dmr)
+}
+
+// Run run Migrate20261018001InitTable
+func (dmr *Migrate20261018001InitTable) Run(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if err := dmr.GetGorm().AutoMigrate(
+			&domEntity.EmailTemplateAssetEntity{},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RollBack rollback Migrate20261018001InitTable
+func (dmr *Migrate20261018001InitTable) RollBack(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if dmr.GetGorm().Migrator().HasTable(&domEntity.EmailTemplateAssetEntity{}) {
+			if err := dmr.GetGorm().Migrator().DropTable(&domEntity.EmailTemplateAssetEntity{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package rdbms
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+	"gorm.io/gorm"
+)
+
+// emailScheduledMessage20261018002 represent `eml_scheduled_messages` table as created by this migration
+// (the table is renamed to `eml_outbox_messages` by Migrate20261018004OutboxMessage)
+type emailScheduledMessage20261018002 struct {
+	ID uint64 `gorm:"primary_key;column:id"`
+
+	UUID         string     `gorm:"column:uuid;size:255;unique;not null"`
+	TemplateCode string     `gorm:"column:template_code;size:255;not null"`
+	SendAt       time.Time  `gorm:"column:send_at;index:idx_due;not null"`
+	Status       string     `gorm:"column:status;size:50;index:idx_due;not null"`
+	Payload      string     `gorm:"column:payload;type:longtext"`
+	Attempts     int        `gorm:"column:attempts"`
+	ClaimedAt    *time.Time `gorm:"column:claimed_at"`
+	ProcessedAt  *time.Time `gorm:"column:processed_at"`
+	LastError    string     `gorm:"column:last_error;type:text"`
+	ScheduledBy  string     `gorm:"column:scheduled_by;size:255"`
+	IPAddress    string     `gorm:"column:ip_address;size:100"`
+
+	CreatedBy string         `gorm:"column:sys_created_by;size:255"`
+	CreatedAt *time.Time     `gorm:"column:sys_created_at"`
+	UpdatedBy string         `gorm:"column:sys_updated_by;size:255"`
+	UpdatedAt *time.Time     `gorm:"column:sys_updated_at"`
+	DeletedBy string         `gorm:"column:sys_deleted_by;size:255"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index:idx_delete_at"`
+}
+
+// TableName get real database table name
+func (t *emailScheduledMessage20261018002) TableName() string {
+	return "eml_scheduled_messages"
+}
+
+// Migrate20261018002ScheduledMessage type
+type Migrate20261018002ScheduledMessage struct {
+	migRDBMS.BaseGormMigratorRunner
+}
+
+// NewMigrate20261018002ScheduledMessage constructor
+func NewMigrate20261018002ScheduledMessage(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	gmr := new(Migrate20261018002ScheduledMessage)
+	gmr.SetHandler(h)
+	gmr.SetID("Migrate20261018002ScheduledMessage")
+	return gmr, nil
+}
+
+// GetID get Migrate20261018002ScheduledMessage ID
+func (dmr *Migrate20261018002ScheduledMessage) GetID() string {
+	return fmt.Sprintf("%T", dmr)
+}
+
+// Run run Migrate20261018002ScheduledMessage
+func (dmr *Migrate20261018002ScheduledMessage) Run(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if err := dmr.GetGorm().AutoMigrate(
+			&emailScheduledMessage20261018002{},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RollBack rollback Migrate20261018002ScheduledMessage
+func (dmr *Migrate20261018002ScheduledMessage) RollBack(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if dmr.GetGorm().Migrator().HasTable(&emailScheduledMessage20261018002{}) {
+			if err := dmr.GetGorm().Migrator().DropTable(&emailScheduledMessage20261018002{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
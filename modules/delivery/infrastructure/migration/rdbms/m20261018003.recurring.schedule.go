@@ -0,0 +1,64 @@
+package rdbms
+
+import (
+	"fmt"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+	"gorm.io/gorm"
+)
+
+// Migrate20261018003RecurringSchedule type
+type Migrate20261018003RecurringSchedule struct {
+	migRDBMS.BaseGormMigratorRunner
+}
+
+// NewMigrate20261018003RecurringSchedule constructor
+func NewMigrate20261018003RecurringSchedule(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	gmr := new(Migrate20261018003RecurringSchedule)
+	gmr.SetHandler(h)
+	gmr.SetID("Migrate20261018003RecurringSchedule")
+	return gmr, nil
+}
+
+// GetID get Migrate20261018003RecurringSchedule ID
+func (dmr *Migrate20261018003RecurringSchedule) GetID() string {
+	return fmt.Sprintf("%T", dmr)
+}
+
+// Run run Migrate20261018003RecurringSchedule
+func (dmr *Migrate20261018003RecurringSchedule) Run(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if err := dmr.GetGorm().AutoMigrate(
+			&domEntity.EmailRecurringScheduleEntity{},
+			&domEntity.EmailRecurringScheduleRunEntity{},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RollBack rollback Migrate20261018003RecurringSchedule
+func (dmr *Migrate20261018003RecurringSchedule) RollBack(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if dmr.GetGorm().Migrator().HasTable(&domEntity.EmailRecurringScheduleRunEntity{}) {
+			if err := dmr.GetGorm().Migrator().DropTable(&domEntity.EmailRecurringScheduleRunEntity{}); err != nil {
+				return err
+			}
+		}
+		if dmr.GetGorm().Migrator().HasTable(&domEntity.EmailRecurringScheduleEntity{}) {
+			if err := dmr.GetGorm().Migrator().DropTable(&domEntity.EmailRecurringScheduleEntity{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
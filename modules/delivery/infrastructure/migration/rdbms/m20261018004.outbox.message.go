@@ -0,0 +1,92 @@
+package rdbms
+
+import (
+	"fmt"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+	"gorm.io/gorm"
+)
+
+// Migrate20261018004OutboxMessage type
+type Migrate20261018004OutboxMessage struct {
+	migRDBMS.BaseGormMigratorRunner
+}
+
+// NewMigrate20261018004OutboxMessage constructor
+func NewMigrate20261018004OutboxMessage(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	gmr := new(Migrate20261018004OutboxMessage)
+	gmr.SetHandler(h)
+	gmr.SetID("Migrate20261018004OutboxMessage")
+	return gmr, nil
+}
+
+// GetID get Migrate20261018004OutboxMessage ID
+func (dmr *Migrate20261018004OutboxMessage) GetID() string {
+	return fmt.Sprintf("%T", dmr)
+}
+
+// Run run Migrate20261018004OutboxMessage (scheduled messages table becomes the outbox of ASYNC and scheduled sends)
+func (dmr *Migrate20261018004OutboxMessage) Run(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		m := dmr.GetGorm().Migrator()
+		if m.HasTable(&emailScheduledMessage20261018002{}) {
+			if err := m.RenameTable(&emailScheduledMessage20261018002{}, &domEntity.EmailOutboxMessageEntity{}); err != nil {
+				return err
+			}
+		}
+		if err := dmr.GetGorm().AutoMigrate(
+			&domEntity.EmailOutboxMessageEntity{},
+		); err != nil {
+			return err
+		}
+		// pending scheduled messages
+		if err := dmr.GetGorm().Model(&domEntity.EmailOutboxMessageEntity{}).Where("status = ?", "SCHEDULED").
+			Updates(map[string]interface{}{
+				"kind":            domEntity.ScheduledKind,
+				"status":          domEntity.PendingStatus,
+				"next_attempt_at": gorm.Expr("send_at"),
+			}).Error; err != nil {
+			return err
+		}
+		if err := dmr.GetGorm().Model(&domEntity.EmailOutboxMessageEntity{}).Where("kind IS NULL OR kind = ?", "").
+			Updates(map[string]interface{}{
+				"kind":            domEntity.ScheduledKind,
+				"next_attempt_at": gorm.Expr("send_at"),
+			}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RollBack rollback Migrate20261018004OutboxMessage
+func (dmr *Migrate20261018004OutboxMessage) RollBack(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		m := dmr.GetGorm().Migrator()
+		if m.HasTable(&domEntity.EmailOutboxMessageEntity{}) {
+			if err := dmr.GetGorm().Model(&domEntity.EmailOutboxMessageEntity{}).Where("status = ?", domEntity.PendingStatus).
+				Update("status", "SCHEDULED").Error; err != nil {
+				return err
+			}
+			for _, col := range []string{"kind", "next_attempt_at", "max_attempts"} {
+				if m.HasColumn(&domEntity.EmailOutboxMessageEntity{}, col) {
+					if err := m.DropColumn(&domEntity.EmailOutboxMessageEntity{}, col); err != nil {
+						return err
+					}
+				}
+			}
+			if err := m.RenameTable(&domEntity.EmailOutboxMessageEntity{}, &emailScheduledMessage20261018002{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
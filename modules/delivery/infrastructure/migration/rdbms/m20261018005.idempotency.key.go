@@ -0,0 +1,58 @@
+package rdbms
+
+import (
+	"fmt"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+	"gorm.io/gorm"
+)
+
+// Migrate20261018005IdempotencyKey type
+type Migrate20261018005IdempotencyKey struct {
+	migRDBMS.BaseGormMigratorRunner
+}
+
+// NewMigrate20261018005IdempotencyKey constructor
+func NewMigrate20261018005IdempotencyKey(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	gmr := new(Migrate20261018005IdempotencyKey)
+	gmr.SetHandler(h)
+	gmr.SetID("Migrate20261018005IdempotencyKey")
+	return gmr, nil
+}
+
+// GetID get Migrate20261018005IdempotencyKey ID
+func (dmr *Migrate20261018005IdempotencyKey) GetID() string {
+	return fmt.Sprintf("%T", dmr)
+}
+
+// Run run Migrate20261018005IdempotencyKey
+func (dmr *Migrate20261018005IdempotencyKey) Run(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if err := dmr.GetGorm().AutoMigrate(
+			&domEntity.EmailIdempotencyKeyEntity{},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RollBack rollback Migrate20261018005IdempotencyKey
+func (dmr *Migrate20261018005IdempotencyKey) RollBack(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if dmr.GetGorm().Migrator().HasTable(&domEntity.EmailIdempotencyKeyEntity{}) {
+			if err := dmr.GetGorm().Migrator().DropTable(&domEntity.EmailIdempotencyKeyEntity{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package rdbms
+
+import (
+	"fmt"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+	"gorm.io/gorm"
+)
+
+// Migrate20261018006Message type
+type Migrate20261018006Message struct {
+	migRDBMS.BaseGormMigratorRunner
+}
+
+// NewMigrate20261018006Message constructor
+func NewMigrate20261018006Message(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	gmr := new(Migrate20261018006Message)
+	gmr.SetHandler(h)
+	gmr.SetID("Migrate20261018006Message")
+	return gmr, nil
+}
+
+// GetID get Migrate20261018006Message ID
+func (dmr *Migrate20261018006Message) GetID() string {
+	return fmt.Sprintf("%T", dmr)
+}
+
+// Run run Migrate20261018006Message
+func (dmr *Migrate20261018006Message) Run(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if err := dmr.GetGorm().AutoMigrate(
+			&domEntity.EmailMessageEntity{},
+			&domEntity.EmailMessageEventEntity{},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RollBack rollback Migrate20261018006Message
+func (dmr *Migrate20261018006Message) RollBack(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if dmr.GetGorm().Migrator().HasTable(&domEntity.EmailMessageEventEntity{}) {
+			if err := dmr.GetGorm().Migrator().DropTable(&domEntity.EmailMessageEventEntity{}); err != nil {
+				return err
+			}
+		}
+		if dmr.GetGorm().Migrator().HasTable(&domEntity.EmailMessageEntity{}) {
+			if err := dmr.GetGorm().Migrator().DropTable(&domEntity.EmailMessageEntity{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package rdbms
+
+import (
+	"fmt"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+	"gorm.io/gorm"
+)
+
+// Migrate20261018007Webhook type
+type Migrate20261018007Webhook struct {
+	migRDBMS.BaseGormMigratorRunner
+}
+
+// NewMigrate20261018007Webhook constructor
+func NewMigrate20261018007Webhook(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	gmr := new(Migrate20261018007Webhook)
+	gmr.SetHandler(h)
+	gmr.SetID("Migrate20261018007Webhook")
+	return gmr, nil
+}
+
+// GetID get Migrate20261018007Webhook ID
+func (dmr *Migrate20261018007Webhook) GetID() string {
+	return fmt.Sprintf("%T", dmr)
+}
+
+// Run run Migrate20261018007Webhook
+func (dmr *Migrate20261018007Webhook) Run(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if err := dmr.GetGorm().AutoMigrate(
+			&domEntity.EmailWebhookEntity{},
+			&domEntity.EmailWebhookDeliveryEntity{},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RollBack rollback Migrate20261018007Webhook
+func (dmr *Migrate20261018007Webhook) RollBack(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if dmr.GetGorm().Migrator().HasTable(&domEntity.EmailWebhookDeliveryEntity{}) {
+			if err := dmr.GetGorm().Migrator().DropTable(&domEntity.EmailWebhookDeliveryEntity{}); err != nil {
+				return err
+			}
+		}
+		if dmr.GetGorm().Migrator().HasTable(&domEntity.EmailWebhookEntity{}) {
+			if err := dmr.GetGorm().Migrator().DropTable(&domEntity.EmailWebhookEntity{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package rdbms
+
+import (
+	"fmt"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+	"gorm.io/gorm"
+)
+
+// Migrate20261018008Bounce type
+type Migrate20261018008Bounce struct {
+	migRDBMS.BaseGormMigratorRunner
+}
+
+// NewMigrate20261018008Bounce constructor
+func NewMigrate20261018008Bounce(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	gmr := new(Migrate20261018008Bounce)
+	gmr.SetHandler(h)
+	gmr.SetID("Migrate20261018008Bounce")
+	return gmr, nil
+}
+
+// GetID get Migrate20261018008Bounce ID
+func (dmr *Migrate20261018008Bounce) GetID() string {
+	return fmt.Sprintf("%T", dmr)
+}
+
+// Run run Migrate20261018008Bounce
+func (dmr *Migrate20261018008Bounce) Run(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if err := dmr.GetGorm().AutoMigrate(
+			&domEntity.EmailBounceEntity{},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RollBack rollback Migrate20261018008Bounce
+func (dmr *Migrate20261018008Bounce) RollBack(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if dmr.GetGorm().Migrator().HasTable(&domEntity.EmailBounceEntity{}) {
+			if err := dmr.GetGorm().Migrator().DropTable(&domEntity.EmailBounceEntity{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
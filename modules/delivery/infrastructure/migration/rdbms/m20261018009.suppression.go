@@ -0,0 +1,58 @@
+package rdbms
+
+import (
+	"fmt"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+	"gorm.io/gorm"
+)
+
+// Migrate20261018009Suppression type
+type Migrate20261018009Suppression struct {
+	migRDBMS.BaseGormMigratorRunner
+}
+
+// NewMigrate20261018009Suppression constructor
+func NewMigrate20261018009Suppression(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	gmr := new(Migrate20261018009Suppression)
+	gmr.SetHandler(h)
+	gmr.SetID("Migrate20261018009Suppression")
+	return gmr, nil
+}
+
+// GetID get Migrate20261018009Suppression ID
+func (dmr *Migrate20261018009Suppression) GetID() string {
+	return fmt.Sprintf("%T", dmr)
+}
+
+// Run run Migrate20261018009Suppression
+func (dmr *Migrate20261018009Suppression) Run(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if err := dmr.GetGorm().AutoMigrate(
+			&domEntity.EmailSuppressionEntity{},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RollBack rollback Migrate20261018009Suppression
+func (dmr *Migrate20261018009Suppression) RollBack(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if dmr.GetGorm().Migrator().HasTable(&domEntity.EmailSuppressionEntity{}) {
+			if err := dmr.GetGorm().Migrator().DropTable(&domEntity.EmailSuppressionEntity{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
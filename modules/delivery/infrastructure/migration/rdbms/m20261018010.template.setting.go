@@ -0,0 +1,64 @@
+package rdbms
+
+import (
+	"fmt"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+	"gorm.io/gorm"
+)
+
+// Migrate20261018010TemplateSetting type
+type Migrate20261018010TemplateSetting struct {
+	migRDBMS.BaseGormMigratorRunner
+}
+
+// NewMigrate20261018010TemplateSetting constructor
+func NewMigrate20261018010TemplateSetting(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	gmr := new(Migrate20261018010TemplateSetting)
+	gmr.SetHandler(h)
+	gmr.SetID("Migrate20261018010TemplateSetting")
+	return gmr, nil
+}
+
+// GetID get Migrate20261018010TemplateSetting ID
+func (dmr *Migrate20261018010TemplateSetting) GetID() string {
+	return fmt.Sprintf("%T", dmr)
+}
+
+// Run run Migrate20261018010TemplateSetting
+func (dmr *Migrate20261018010TemplateSetting) Run(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if err := dmr.GetGorm().AutoMigrate(
+			&domEntity.EmailTemplateSettingEntity{},
+			&domEntity.EmailUnsubscribeEntity{},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RollBack rollback Migrate20261018010TemplateSetting
+func (dmr *Migrate20261018010TemplateSetting) RollBack(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if dmr.GetGorm().Migrator().HasTable(&domEntity.EmailUnsubscribeEntity{}) {
+			if err := dmr.GetGorm().Migrator().DropTable(&domEntity.EmailUnsubscribeEntity{}); err != nil {
+				return err
+			}
+		}
+		if dmr.GetGorm().Migrator().HasTable(&domEntity.EmailTemplateSettingEntity{}) {
+			if err := dmr.GetGorm().Migrator().DropTable(&domEntity.EmailTemplateSettingEntity{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
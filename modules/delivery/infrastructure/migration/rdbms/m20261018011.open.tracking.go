@@ -0,0 +1,68 @@
+package rdbms
+
+import (
+	"fmt"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+	"gorm.io/gorm"
+)
+
+// Migrate20261018011OpenTracking type
+type Migrate20261018011OpenTracking struct {
+	migRDBMS.BaseGormMigratorRunner
+}
+
+// NewMigrate20261018011OpenTracking constructor
+func NewMigrate20261018011OpenTracking(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	gmr := new(Migrate20261018011OpenTracking)
+	gmr.SetHandler(h)
+	gmr.SetID("Migrate20261018011OpenTracking")
+	return gmr, nil
+}
+
+// GetID get Migrate20261018011OpenTracking ID
+func (dmr *Migrate20261018011OpenTracking) GetID() string {
+	return fmt.Sprintf("%T", dmr)
+}
+
+// Run run Migrate20261018011OpenTracking
+func (dmr *Migrate20261018011OpenTracking) Run(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		// new columns: eml_messages (first_opened_at, open_count), eml_email_template_settings (track_opens)
+		if err := dmr.GetGorm().AutoMigrate(
+			&domEntity.EmailMessageEntity{},
+			&domEntity.EmailTemplateSettingEntity{},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RollBack rollback Migrate20261018011OpenTracking
+func (dmr *Migrate20261018011OpenTracking) RollBack(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		m := dmr.GetGorm().Migrator()
+		for _, col := range []string{"FirstOpenedAt", "OpenCount"} {
+			if m.HasColumn(&domEntity.EmailMessageEntity{}, col) {
+				if err := m.DropColumn(&domEntity.EmailMessageEntity{}, col); err != nil {
+					return err
+				}
+			}
+		}
+		if m.HasColumn(&domEntity.EmailTemplateSettingEntity{}, "TrackOpens") {
+			if err := m.DropColumn(&domEntity.EmailTemplateSettingEntity{}, "TrackOpens"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
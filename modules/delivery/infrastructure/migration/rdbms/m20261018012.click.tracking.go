@@ -0,0 +1,65 @@
+package rdbms
+
+import (
+	"fmt"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+	"gorm.io/gorm"
+)
+
+// Migrate20261018012ClickTracking type
+type Migrate20261018012ClickTracking struct {
+	migRDBMS.BaseGormMigratorRunner
+}
+
+// NewMigrate20261018012ClickTracking constructor
+func NewMigrate20261018012ClickTracking(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	gmr := new(Migrate20261018012ClickTracking)
+	gmr.SetHandler(h)
+	gmr.SetID("Migrate20261018012ClickTracking")
+	return gmr, nil
+}
+
+// GetID get Migrate20261018012ClickTracking ID
+func (dmr *Migrate20261018012ClickTracking) GetID() string {
+	return fmt.Sprintf("%T", dmr)
+}
+
+// Run run Migrate20261018012ClickTracking
+func (dmr *Migrate20261018012ClickTracking) Run(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		// new column: eml_email_template_settings (track_clicks)
+		if err := dmr.GetGorm().AutoMigrate(
+			&domEntity.EmailClickEntity{},
+			&domEntity.EmailTemplateSettingEntity{},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RollBack rollback Migrate20261018012ClickTracking
+func (dmr *Migrate20261018012ClickTracking) RollBack(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if dmr.GetGorm().Migrator().HasTable(&domEntity.EmailClickEntity{}) {
+			if err := dmr.GetGorm().Migrator().DropTable(&domEntity.EmailClickEntity{}); err != nil {
+				return err
+			}
+		}
+		if dmr.GetGorm().Migrator().HasColumn(&domEntity.EmailTemplateSettingEntity{}, "TrackClicks") {
+			if err := dmr.GetGorm().Migrator().DropColumn(&domEntity.EmailTemplateSettingEntity{}, "TrackClicks"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
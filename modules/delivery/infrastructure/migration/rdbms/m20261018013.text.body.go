@@ -0,0 +1,59 @@
+package rdbms
+
+import (
+	"fmt"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+	"gorm.io/gorm"
+)
+
+// Migrate20261018013TextBody type
+type Migrate20261018013TextBody struct {
+	migRDBMS.BaseGormMigratorRunner
+}
+
+// NewMigrate20261018013TextBody constructor
+func NewMigrate20261018013TextBody(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	gmr := new(Migrate20261018013TextBody)
+	gmr.SetHandler(h)
+	gmr.SetID("Migrate20261018013TextBody")
+	return gmr, nil
+}
+
+// GetID get Migrate20261018013TextBody ID
+func (dmr *Migrate20261018013TextBody) GetID() string {
+	return fmt.Sprintf("%T", dmr)
+}
+
+// Run run Migrate20261018013TextBody
+func (dmr *Migrate20261018013TextBody) Run(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		// new column: eml_email_template_settings (text_body_tpl)
+		if err := dmr.GetGorm().AutoMigrate(
+			&domEntity.EmailTemplateSettingEntity{},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RollBack rollback Migrate20261018013TextBody
+func (dmr *Migrate20261018013TextBody) RollBack(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if dmr.GetGorm().Migrator().HasColumn(&domEntity.EmailTemplateSettingEntity{}, "TextBodyTpl") {
+			if err := dmr.GetGorm().Migrator().DropColumn(&domEntity.EmailTemplateSettingEntity{}, "TextBodyTpl"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
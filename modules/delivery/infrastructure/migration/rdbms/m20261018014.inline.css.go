@@ -0,0 +1,59 @@
+package rdbms
+
+import (
+	"fmt"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+	"gorm.io/gorm"
+)
+
+// Migrate20261018014InlineCSS type
+type Migrate20261018014InlineCSS struct {
+	migRDBMS.BaseGormMigratorRunner
+}
+
+// NewMigrate20261018014InlineCSS constructor
+func NewMigrate20261018014InlineCSS(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	gmr := new(Migrate20261018014InlineCSS)
+	gmr.SetHandler(h)
+	gmr.SetID("Migrate20261018014InlineCSS")
+	return gmr, nil
+}
+
+// GetID get Migrate20261018014InlineCSS ID
+func (dmr *Migrate20261018014InlineCSS) GetID() string {
+	return fmt.Sprintf("%T", dmr)
+}
+
+// Run run Migrate20261018014InlineCSS
+func (dmr *Migrate20261018014InlineCSS) Run(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		// new column: eml_email_template_settings (inline_css)
+		if err := dmr.GetGorm().AutoMigrate(
+			&domEntity.EmailTemplateSettingEntity{},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RollBack rollback Migrate20261018014InlineCSS
+func (dmr *Migrate20261018014InlineCSS) RollBack(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if dmr.GetGorm().Migrator().HasColumn(&domEntity.EmailTemplateSettingEntity{}, "InlineCSS") {
+			if err := dmr.GetGorm().Migrator().DropColumn(&domEntity.EmailTemplateSettingEntity{}, "InlineCSS"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
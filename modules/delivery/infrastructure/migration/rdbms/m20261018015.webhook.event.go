@@ -0,0 +1,80 @@
+package rdbms
+
+import (
+	"fmt"
+	"strings"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+	"gorm.io/gorm"
+)
+
+// Migrate20261018015WebhookEvent type
+type Migrate20261018015WebhookEvent struct {
+	migRDBMS.BaseGormMigratorRunner
+}
+
+// NewMigrate20261018015WebhookEvent constructor
+func NewMigrate20261018015WebhookEvent(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	gmr := new(Migrate20261018015WebhookEvent)
+	gmr.SetHandler(h)
+	gmr.SetID("Migrate20261018015WebhookEvent")
+	return gmr, nil
+}
+
+// GetID get Migrate20261018015WebhookEvent ID
+func (dmr *Migrate20261018015WebhookEvent) GetID() string {
+	return fmt.Sprintf("%T", dmr)
+}
+
+// Run run Migrate20261018015WebhookEvent
+func (dmr *Migrate20261018015WebhookEvent) Run(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if err := dmr.GetGorm().AutoMigrate(
+			&domEntity.EmailWebhookEventEntity{},
+		); err != nil {
+			return err
+		}
+
+		// subscriptions of the registered webhook endpoints (comma separated events)
+		return dmr.GetGorm().Transaction(func(tx *gorm.DB) error {
+			var whEtts []domEntity.EmailWebhookEntity
+			if err := tx.Find(&whEtts).Error; err != nil {
+				return err
+			}
+			for _, v := range whEtts {
+				subscribed := make(map[string]bool)
+				for _, e := range strings.Split(v.Events, ",") {
+					if e == "" || subscribed[e] {
+						continue
+					}
+					subscribed[e] = true
+					if err := tx.Create(&domEntity.EmailWebhookEventEntity{WebhookID: v.ID, Event: e}).Error; err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+	}
+	return nil
+}
+
+// RollBack rollback Migrate20261018015WebhookEvent
+func (dmr *Migrate20261018015WebhookEvent) RollBack(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if dmr.GetGorm().Migrator().HasTable(&domEntity.EmailWebhookEventEntity{}) {
+			if err := dmr.GetGorm().Migrator().DropTable(&domEntity.EmailWebhookEventEntity{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
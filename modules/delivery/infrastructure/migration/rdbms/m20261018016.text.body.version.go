@@ -0,0 +1,83 @@
+package rdbms
+
+import (
+	"fmt"
+
+	domEntityEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/entity"
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+	"gorm.io/gorm"
+)
+
+// Migrate20261018016TextBodyVersion type
+type Migrate20261018016TextBodyVersion struct {
+	migRDBMS.BaseGormMigratorRunner
+}
+
+// NewMigrate20261018016TextBodyVersion constructor
+func NewMigrate20261018016TextBodyVersion(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	gmr := new(Migrate20261018016TextBodyVersion)
+	gmr.SetHandler(h)
+	gmr.SetID("Migrate20261018016TextBodyVersion")
+	return gmr, nil
+}
+
+// GetID get Migrate20261018016TextBodyVersion ID
+func (dmr *Migrate20261018016TextBodyVersion) GetID() string {
+	return fmt.Sprintf("%T", dmr)
+}
+
+// Run run Migrate20261018016TextBodyVersion
+func (dmr *Migrate20261018016TextBodyVersion) Run(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		// new column: eml_email_template_settings (text_body_version_id)
+		if err := dmr.GetGorm().AutoMigrate(
+			&domEntity.EmailTemplateSettingEntity{},
+		); err != nil {
+			return err
+		}
+
+		// existing text body templates are kept for the current (default) template version
+		return dmr.GetGorm().Transaction(func(tx *gorm.DB) error {
+			var tsEtts []domEntity.EmailTemplateSettingEntity
+			if err := tx.Where("text_body_tpl <> '' AND text_body_version_id = 0").Find(&tsEtts).Error; err != nil {
+				return err
+			}
+			for _, v := range tsEtts {
+				etEtt := new(domEntityEmail.EmailTemplateEntity)
+				res := tx.Where("code = ?", v.TemplateCode).Limit(1).Find(etEtt)
+				if res.Error != nil {
+					return res.Error
+				}
+				if res.RowsAffected == 0 {
+					continue
+				}
+				if err := tx.Model(&domEntity.EmailTemplateSettingEntity{}).Where("id = ?", v.ID).
+					Update("text_body_version_id", etEtt.DefaultVersionID).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	return nil
+}
+
+// RollBack rollback Migrate20261018016TextBodyVersion
+func (dmr *Migrate20261018016TextBodyVersion) RollBack(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if dmr.GetGorm().Migrator().HasColumn(&domEntity.EmailTemplateSettingEntity{}, "TextBodyVersionID") {
+			if err := dmr.GetGorm().Migrator().DropColumn(&domEntity.EmailTemplateSettingEntity{}, "TextBodyVersionID"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
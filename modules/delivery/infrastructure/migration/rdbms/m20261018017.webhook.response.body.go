@@ -0,0 +1,60 @@
+package rdbms
+
+import (
+	"fmt"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+	"gorm.io/gorm"
+)
+
+// Migrate20261018017WebhookResponseBody type
+type Migrate20261018017WebhookResponseBody struct {
+	migRDBMS.BaseGormMigratorRunner
+}
+
+// NewMigrate20261018017WebhookResponseBody constructor
+func NewMigrate20261018017WebhookResponseBody(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	gmr := new(Migrate20261018017WebhookResponseBody)
+	gmr.SetHandler(h)
+	gmr.SetID("Migrate20261018017WebhookResponseBody")
+	return gmr, nil
+}
+
+// GetID get Migrate20261018017WebhookResponseBody ID
+func (dmr *Migrate20261018017WebhookResponseBody) GetID() string {
+	return fmt.Sprintf("%T", dmr)
+}
+
+// Run run Migrate20261018017WebhookResponseBody
+func (dmr *Migrate20261018017WebhookResponseBody) Run(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		// the webhook endpoint response body is not kept anymore: eml_webhook_deliveries (response_body)
+		if dmr.GetGorm().Migrator().HasColumn(&domEntity.EmailWebhookDeliveryEntity{}, "response_body") {
+			if err := dmr.GetGorm().Migrator().DropColumn(&domEntity.EmailWebhookDeliveryEntity{}, "response_body"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RollBack rollback Migrate20261018017WebhookResponseBody
+func (dmr *Migrate20261018017WebhookResponseBody) RollBack(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if !dmr.GetGorm().Migrator().HasColumn(&domEntity.EmailWebhookDeliveryEntity{}, "response_body") {
+			if err := dmr.GetGorm().Exec(fmt.Sprintf("ALTER TABLE %s ADD response_body TEXT",
+				(&domEntity.EmailWebhookDeliveryEntity{}).TableName())).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package rdbms
+
+import (
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+)
+
+// NewSeed20261018001InitCasbinSend constructor, casbin policies of the send features (message, batch, CSV batch and preview)
+func NewSeed20261018001InitCasbinSend(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	return newCasbinSeed(h, "Seed20261018001InitCasbinSend", []IamCasbinRule{
+		// role:system - delivery
+		{PType: "p", V0: "role:system", V1: "system.module.delivery.message.send", V2: "EXECUTE"},
+		{PType: "p", V0: "role:system", V1: "system.module.delivery.batch.send", V2: "EXECUTE"},
+
+		// role:admin - delivery
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/send/batch", V2: "POST"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/send/batch/csv", V2: "POST"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/send/preview", V2: "POST"},
+	}), nil
+}
@@ -0,0 +1,21 @@
+package rdbms
+
+import (
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+)
+
+// NewSeed20261018002InitCasbinTemplate constructor, casbin policies of the email template features (assets and delivery settings)
+func NewSeed20261018002InitCasbinTemplate(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	return newCasbinSeed(h, "Seed20261018002InitCasbinTemplate", []IamCasbinRule{
+		// role:system - delivery
+		{PType: "p", V0: "role:system", V1: "system.module.delivery.template.asset.carryover", V2: "EXECUTE"},
+
+		// role:admin - delivery
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/template/:code/assets", V2: "GET"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/template/:code/assets", V2: "POST"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/template/:code/assets/:contentId", V2: "DELETE"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/template/:code/settings", V2: "GET"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/template/:code/settings", V2: "PUT"},
+	}), nil
+}
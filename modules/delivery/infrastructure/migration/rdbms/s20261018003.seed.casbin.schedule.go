@@ -0,0 +1,19 @@
+package rdbms
+
+import (
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+)
+
+// NewSeed20261018003InitCasbinSchedule constructor, casbin policies of the recurring schedule features
+func NewSeed20261018003InitCasbinSchedule(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	return newCasbinSeed(h, "Seed20261018003InitCasbinSchedule", []IamCasbinRule{
+		// role:admin - delivery
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/schedules", V2: "GET"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/schedules", V2: "POST"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/schedules/:id", V2: "DELETE"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/schedules/:id/pause", V2: "PUT"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/schedules/:id/resume", V2: "PUT"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/schedules/:id/runs", V2: "GET"},
+	}), nil
+}
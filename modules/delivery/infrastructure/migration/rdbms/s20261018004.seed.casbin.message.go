@@ -0,0 +1,20 @@
+package rdbms
+
+import (
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+)
+
+// NewSeed20261018004InitCasbinMessage constructor, casbin policies of the message log features (find, search and rendered body)
+func NewSeed20261018004InitCasbinMessage(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	return newCasbinSeed(h, "Seed20261018004InitCasbinMessage", []IamCasbinRule{
+		// role:admin - delivery
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/messages", V2: "GET"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/messages/:id", V2: "GET"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/messages/:id/body", V2: "GET"},
+
+		// role:support - delivery (message log without the rendered body)
+		{PType: "p", V0: "role:support", V1: "/api/v1/email/messages", V2: "GET"},
+		{PType: "p", V0: "role:support", V1: "/api/v1/email/messages/:id", V2: "GET"},
+	}), nil
+}
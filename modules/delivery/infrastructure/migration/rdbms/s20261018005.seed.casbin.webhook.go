@@ -0,0 +1,18 @@
+package rdbms
+
+import (
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+)
+
+// NewSeed20261018005InitCasbinWebhook constructor, casbin policies of the webhook features
+func NewSeed20261018005InitCasbinWebhook(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	return newCasbinSeed(h, "Seed20261018005InitCasbinWebhook", []IamCasbinRule{
+		// role:admin - delivery
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/webhooks", V2: "GET"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/webhooks", V2: "POST"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/webhooks/:id", V2: "DELETE"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/webhooks/:id/deliveries", V2: "GET"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/webhooks/:id/deliveries/:deliveryId/replay", V2: "POST"},
+	}), nil
+}
@@ -0,0 +1,25 @@
+package rdbms
+
+import (
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+)
+
+// NewSeed20261018006InitCasbinSuppression constructor, casbin policies of the bounce and suppression features
+func NewSeed20261018006InitCasbinSuppression(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	return newCasbinSeed(h, "Seed20261018006InitCasbinSuppression", []IamCasbinRule{
+		// role:admin - delivery
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/bounces", V2: "POST"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/suppressions", V2: "GET"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/suppressions", V2: "POST"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/suppressions/:email", V2: "GET"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/suppressions/:email", V2: "DELETE"},
+
+		// role:support - delivery (check why a recipient does not get emails)
+		{PType: "p", V0: "role:support", V1: "/api/v1/email/suppressions", V2: "GET"},
+		{PType: "p", V0: "role:support", V1: "/api/v1/email/suppressions/:email", V2: "GET"},
+
+		// role:mta - delivery (MTA forwarding bounces and feedback reports)
+		{PType: "p", V0: "role:mta", V1: "/api/v1/email/bounces", V2: "POST"},
+	}), nil
+}
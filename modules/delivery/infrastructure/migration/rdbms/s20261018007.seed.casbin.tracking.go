@@ -0,0 +1,15 @@
+package rdbms
+
+import (
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+)
+
+// NewSeed20261018007InitCasbinTracking constructor, casbin policies of the tracking features (click statistics)
+func NewSeed20261018007InitCasbinTracking(h *handler.Handler) (migRDBMS.IGormMigratorRunner, error) {
+	return newCasbinSeed(h, "Seed20261018007InitCasbinTracking", []IamCasbinRule{
+		// role:admin - delivery
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/messages/:id/clicks", V2: "GET"},
+		{PType: "p", V0: "role:admin", V1: "/api/v1/email/template/:code/clicks", V2: "GET"},
+	}), nil
+}
@@ -0,0 +1,129 @@
+package rdbms
+
+import (
+	"strings"
+
+	"github.com/d3ta-go/system/system/handler"
+	migRDBMS "github.com/d3ta-go/system/system/migration/rdbms"
+	"gorm.io/gorm"
+)
+
+// CasbinRule Optimation
+
+// IamCasbinRule type
+// Original: https://github.com/casbin/gorm-adapter/blob/master/adapter.go#L31
+type IamCasbinRule struct {
+	TablePrefix string `gorm:"-"`
+	PType       string `gorm:"size:100;index;index:idx_unique,unique"`
+	V0          string `gorm:"size:100;index;index:idx_unique,unique"`
+	V1          string `gorm:"size:100;index;index:idx_unique,unique"`
+	V2          string `gorm:"size:100;index;index:idx_unique,unique"`
+	V3          string `gorm:"size:100;index;index:idx_unique,unique"`
+	V4          string `gorm:"size:100;index;index:idx_unique,unique"`
+	V5          string `gorm:"size:100;index;index:idx_unique,unique"`
+}
+
+// TableName func
+func (c *IamCasbinRule) TableName() string {
+	return "iam_casbin_rule"
+}
+
+// casbinSeed seed of the casbin policies (rules) of a feature area,
+// each role of the policies is granted to its group (group:<name> -> role:<name>, for flexibility)
+type casbinSeed struct {
+	migRDBMS.BaseGormMigratorRunner
+	cPs []IamCasbinRule
+}
+
+// newCasbinSeed new casbinSeed
+func newCasbinSeed(h *handler.Handler, id string, cPs []IamCasbinRule) migRDBMS.IGormMigratorRunner {
+	gmr := &casbinSeed{cPs: cPs}
+	gmr.SetHandler(h)
+	gmr.SetID(id)
+	return gmr
+}
+
+// Run run casbinSeed
+func (dmr *casbinSeed) Run(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if err := dmr.GetGorm().AutoMigrate(&IamCasbinRule{}); err != nil {
+			return err
+		}
+		if err := seedCasbinRules(dmr.GetGorm(), dmr.cPs, dmr.vGs()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RollBack rollback casbinSeed
+func (dmr *casbinSeed) RollBack(h *handler.Handler, dbGorm *gorm.DB) error {
+	if dbGorm != nil {
+		dmr.SetGorm(dbGorm)
+	}
+	if dmr.GetGorm() != nil {
+		if err := unSeedCasbinRules(dmr.GetGorm(), dmr.cPs, dmr.vGs()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// vGs group -> role rules of the roles of the policies
+func (dmr *casbinSeed) vGs() []IamCasbinRule {
+	var vGs []IamCasbinRule
+	seen := make(map[string]bool)
+	for _, v := range dmr.cPs {
+		if seen[v.V0] || !strings.HasPrefix(v.V0, "role:") {
+			continue
+		}
+		seen[v.V0] = true
+		vGs = append(vGs, IamCasbinRule{PType: "g", V0: "group:" + strings.TrimPrefix(v.V0, "role:"), V1: v.V0})
+	}
+	return vGs
+}
+
+// seedCasbinRules create the policies and group rules, existing rules are kept
+func seedCasbinRules(db *gorm.DB, cPs []IamCasbinRule, vGs []IamCasbinRule) error {
+	if db.Migrator().HasTable(&IamCasbinRule{}) {
+		for _, v := range append(append([]IamCasbinRule{}, cPs...), vGs...) {
+			var ett IamCasbinRule
+			result := db.Unscoped().Where(v).Limit(1).Find(&ett)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected < 1 {
+				if err := db.Create(&v).Error; err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// unSeedCasbinRules delete the policies, and the group rules of the roles without policies left
+func unSeedCasbinRules(db *gorm.DB, cPs []IamCasbinRule, vGs []IamCasbinRule) error {
+	if db.Migrator().HasTable(&IamCasbinRule{}) {
+
+		for _, v := range cPs {
+			if err := db.Unscoped().Where(&v).Delete(&IamCasbinRule{}).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, v := range vGs {
+			var ett IamCasbinRule
+			result := db.Unscoped().Where(&IamCasbinRule{PType: "p", V0: v.V1}).First(&ett)
+			if result.RowsAffected < 1 {
+				if err := db.Where(&v).Delete(&IamCasbinRule{}).Error; err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/bounce"
+	domSchemaMessage "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+	domSchemaSuppression "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/suppression"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+	"github.com/d3ta-go/system/system/utils"
+	"gorm.io/gorm"
+)
+
+// NewBounceRepo new BounceRepo
+func NewBounceRepo(h *handler.Handler) (domRepo.IBounceRepo, error) {
+
+	repo := new(BounceRepo)
+	repo.handler = h
+
+	cfg, err := h.GetDefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+	repo.SetDBConnectionName(cfg.Databases.EmailDB.ConnectionName)
+
+	return repo, nil
+}
+
+// bounceSuppressionReasons bounce types that suppress the recipient address
+var bounceSuppressionReasons = map[domSchema.Type]domSchemaSuppression.Reason{
+	domSchema.HardBounce: domSchemaSuppression.HardBounceReason,
+	domSchema.Complaint:  domSchemaSuppression.ComplaintReason,
+}
+
+// BounceRepo type Implement IBounceRepo
+type BounceRepo struct {
+	BaseRepo
+}
+
+// Ingest record bounces (or complaints) of a report, matched to the original message by Message-ID.
+// A hard bounce of the message recipient (to) sets the message record to BOUNCED,
+// hard bounced and complaining addresses are added to the suppression list.
+func (r *BounceRepo) Ingest(req *domSchema.IngestBounceRequest, i identity.Identity) (*domSchema.IngestBounceResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	var msgEtt *domEntity.EmailMessageEntity
+	if req.MessageIDHeader != "" {
+		msgEtt = new(domEntity.EmailMessageEntity)
+		res := dbCon.Where("message_id_header = ?", req.MessageIDHeader).Limit(1).Find(msgEtt)
+		if res.Error != nil {
+			return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: res.Error}
+		}
+		if res.RowsAffected == 0 {
+			msgEtt = nil
+		}
+	}
+
+	now := time.Now()
+	createdBy := fmt.Sprintf("%s@%s", i.Claims.Username, i.ClientDevices.IPAddress)
+	var suppressed []string
+	if err := dbCon.Transaction(func(tx *gorm.DB) error {
+		var hardBounce *domSchema.Bounce
+		for _, b := range req.Bounces {
+			bEtt := domEntity.EmailBounceEntity{
+				UUID:            utils.GenerateUUID(),
+				MessageIDHeader: req.MessageIDHeader,
+				Recipient:       b.Recipient,
+				ReportType:      string(req.ReportType),
+				Type:            string(b.Type),
+				Action:          b.Action,
+				Status:          b.Status,
+				DiagnosticCode:  b.DiagnosticCode,
+				FeedbackType:    req.FeedbackType,
+				ReceivedAt:      now,
+			}
+			if msgEtt != nil {
+				bEtt.MessageID = msgEtt.ID
+				if b.Type == domSchema.HardBounce && strings.EqualFold(b.Recipient, msgEtt.ToEmail) {
+					hardBounce = b
+				}
+			}
+			bEtt.CreatedBy = createdBy
+			if err := tx.Create(&bEtt).Error; err != nil {
+				return err
+			}
+
+			if reason, ok := bounceSuppressionReasons[b.Type]; ok {
+				added, err := addSuppression(tx, b.Recipient, reason, bEtt.UUID, createdBy)
+				if err != nil {
+					return err
+				}
+				if added {
+					suppressed = append(suppressed, b.Recipient)
+				}
+			}
+		}
+
+		if hardBounce != nil && msgEtt.Status != string(domSchemaMessage.BouncedStatus) {
+			return setMessageStatus(tx, msgEtt, domSchemaMessage.BouncedStatus, r.smtpResponse(hardBounce), nil)
+		}
+		return nil
+	}); err != nil {
+		if sysErr, ok := err.(*sysError.SystemError); ok {
+			return nil, sysErr
+		}
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := &domSchema.IngestBounceResponse{
+		ReportType:      req.ReportType,
+		MessageIDHeader: req.MessageIDHeader,
+		Matched:         msgEtt != nil,
+		Bounces:         req.Bounces,
+		Suppressed:      suppressed,
+	}
+	if msgEtt != nil {
+		resp.MessageID = msgEtt.UUID
+	}
+
+	return resp, nil
+}
+
+// smtpResponse get diagnostic code (or status) of a bounce, recorded as the last SMTP response of the message
+func (r *BounceRepo) smtpResponse(b *domSchema.Bounce) string {
+	if b.DiagnosticCode != "" {
+		return b.DiagnosticCode
+	}
+	return b.Status
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+)
+
+// NewIdempotencyKeyRepo new IdempotencyKeyRepo
+func NewIdempotencyKeyRepo(h *handler.Handler) (domRepo.IIdempotencyKeyRepo, error) {
+
+	repo := new(IdempotencyKeyRepo)
+	repo.handler = h
+
+	cfg, err := h.GetDefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+	repo.SetDBConnectionName(cfg.Databases.EmailDB.ConnectionName)
+
+	return repo, nil
+}
+
+// IdempotencyKeyRepo type Implement IIdempotencyKeyRepo
+type IdempotencyKeyRepo struct {
+	BaseRepo
+}
+
+// Begin register the Idempotency-Key of a new request (valid for window).
+// It returns the original response when the key was already completed (replay),
+// or a conflict error when the key is reused with a different payload or is still being processed.
+// A key still being processed after processingTimeout is abandoned (e.g: crash), it is registered again.
+func (r *IdempotencyKeyRepo) Begin(key *domSchema.IdempotencyKey, window, processingTimeout time.Duration) (*domSchema.SendMessageResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var ikEtt domEntity.EmailIdempotencyKeyEntity
+	res := dbCon.Where("username = ? AND idempotency_key = ?", key.Username, key.Key).Limit(1).Find(&ikEtt)
+	if res.Error != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: res.Error}
+	}
+	if res.RowsAffected > 0 {
+		abandoned := ikEtt.Status != string(domEntity.CompletedIdempotencyStatus) &&
+			(ikEtt.CreatedAt == nil || ikEtt.CreatedAt.Add(processingTimeout).Before(now))
+		if ikEtt.ExpiresAt.After(now) && !abandoned {
+			if ikEtt.Fingerprint != key.Fingerprint {
+				return nil, &sysError.SystemError{StatusCode: http.StatusConflict,
+					Err: fmt.Errorf("Idempotency-Key `%s` is already used with a different payload", key.Key)}
+			}
+			if ikEtt.Status != string(domEntity.CompletedIdempotencyStatus) {
+				return nil, &sysError.SystemError{StatusCode: http.StatusConflict,
+					Err: fmt.Errorf("A request with Idempotency-Key `%s` is still being processed", key.Key)}
+			}
+
+			resp := new(domSchema.SendMessageResponse)
+			if err := json.Unmarshal([]byte(ikEtt.Response), resp); err != nil {
+				return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+			}
+			return resp, nil
+		}
+
+		// expired (or abandoned): the key can be used again
+		if err := dbCon.Unscoped().Delete(&ikEtt).Error; err != nil {
+			return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+		}
+	}
+
+	ikEtt = domEntity.EmailIdempotencyKeyEntity{
+		Username:    key.Username,
+		Key:         key.Key,
+		Fingerprint: key.Fingerprint,
+		Status:      string(domEntity.ProcessingIdempotencyStatus),
+		ExpiresAt:   now.Add(window),
+	}
+	ikEtt.CreatedBy = key.Username
+	ikEtt.CreatedAt = &now
+
+	if err := dbCon.Create(&ikEtt).Error; err != nil {
+		if strings.Index(err.Error(), "Error 1062: Duplicate entry") > -1 {
+			// concurrent request with the same key
+			return nil, &sysError.SystemError{StatusCode: http.StatusConflict,
+				Err: fmt.Errorf("A request with Idempotency-Key `%s` is still being processed", key.Key)}
+		}
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	return nil, nil
+}
+
+// Complete store the response of the request, to be replayed
+func (r *IdempotencyKeyRepo) Complete(key *domSchema.IdempotencyKey, resp *domSchema.SendMessageResponse) error {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return err
+	}
+
+	if err := dbCon.Model(&domEntity.EmailIdempotencyKeyEntity{}).
+		Where("username = ? AND idempotency_key = ?", key.Username, key.Key).
+		Updates(map[string]interface{}{
+			"status":         domEntity.CompletedIdempotencyStatus,
+			"response":       string(resp.ToJSON()),
+			"sys_updated_by": key.Username,
+			"sys_updated_at": time.Now(),
+		}).Error; err != nil {
+		return &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	return nil
+}
+
+// Release release the key of a failed request, so the request can be retried with the same key
+func (r *IdempotencyKeyRepo) Release(key *domSchema.IdempotencyKey) error {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return err
+	}
+
+	if err := dbCon.Unscoped().
+		Where("username = ? AND idempotency_key = ? AND status = ?", key.Username, key.Key, domEntity.ProcessingIdempotencyStatus).
+		Delete(&domEntity.EmailIdempotencyKeyEntity{}).Error; err != nil {
+		return &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	return nil
+}
+
+// PurgeExpired delete expired keys, returns number of deleted keys
+func (r *IdempotencyKeyRepo) PurgeExpired() (int64, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return 0, err
+	}
+
+	res := dbCon.Unscoped().Where("expires_at < ?", time.Now()).Delete(&domEntity.EmailIdempotencyKeyEntity{})
+	if res.Error != nil {
+		return 0, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: res.Error}
+	}
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,834 @@
+package repository
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/textproto"
+	"strings"
+	textTemplate "text/template"
+	"time"
+
+	domEntityEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/entity"
+	domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+	domSchemaTS "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/template_setting"
+	domSchemaTracking "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/tracking"
+	domSchemaUnsubscribe "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/unsubscribe"
+	domSchemaWebhook "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/webhook"
+	"github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/service/calendar"
+	"github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/service/cssinline"
+	"github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/service/links"
+	"github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/service/mailer"
+	"github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/service/plaintext"
+	"github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/service/tracking"
+	appConfig "github.com/d3ta-go/ms-email-restapi/system/config"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+	"github.com/d3ta-go/system/system/utils"
+	"gorm.io/gorm"
+)
+
+// NewMessageRepo new MessageRepo
+func NewMessageRepo(h *handler.Handler) (domRepo.IMessageRepo, error) {
+
+	repo := new(MessageRepo)
+	repo.handler = h
+
+	cfg, err := h.GetDefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+	repo.SetDBConnectionName(cfg.Databases.EmailDB.ConnectionName)
+
+	repo.smtp, err = mailer.NewSMTPSender(h)
+	if err != nil {
+		return nil, err
+	}
+	repo.links, err = links.NewLinks(h)
+	if err != nil {
+		return nil, err
+	}
+
+	appCfg, err := appConfig.GetConfig(h)
+	if err != nil {
+		return nil, err
+	}
+	repo.sandbox = &domSchema.Sandbox{
+		Stage:     appCfg.Environment.Stage,
+		CatchAll:  appCfg.Delivery.Sandbox.CatchAll,
+		Allowlist: appCfg.Delivery.Sandbox.Allowlist,
+	}
+
+	return repo, nil
+}
+
+// MessageRepo type Implement IMessageRepo
+type MessageRepo struct {
+	BaseRepo
+	smtp    *mailer.SMTPSender
+	links   *links.Links
+	sandbox *domSchema.Sandbox
+}
+
+// Send send Message (email template with attachments), the message record (req.MessageID) is created when it does not exist yet
+func (r *MessageRepo) Send(req *domSchema.SendMessageRequest, i identity.Identity) (*domSchema.SendMessageResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	// message record
+	msgEtt, err := r.startSending(dbCon, req, i)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := r.render(dbCon, req, msgEtt)
+	if err != nil {
+		return nil, err
+	}
+	subjEmail, bodyEmail := msg.Subject, []byte(msg.Body)
+
+	// ASYNC messages are delivered by the outbox dispatcher, so the send itself is always synchronous
+	smtpResp, err := r.smtp.Send(msg)
+	req.RemoveSpooledAttachments()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setMessageStatus(dbCon, msgEtt, domSchema.SentStatus, smtpResp, map[string]interface{}{
+		"subject": subjEmail,
+		"body":    string(bodyEmail),
+	}); err != nil {
+		return nil, err
+	}
+
+	// save email to db
+	emailEtt := domEntityEmail.EmailEntity{
+		TemplateID: req.Template.ID,
+		UUID:       utils.GenerateUUID(),
+		From:       req.From.Email,
+		FromName:   req.From.Name,
+		To:         req.To.Email,
+		ToName:     req.To.Name,
+		CC:         r.compileEmail(req.CC),
+		BCC:        r.compileEmail(req.BCC),
+		Subject:    subjEmail,
+		Body:       bodyEmail,
+		Status:     fmt.Sprintf("SENT.%s", req.ProcessingType),
+	}
+	emailEtt.SentBy = i.Claims.Username
+	emailEtt.CreatedBy = fmt.Sprintf("%s@%s", i.Claims.Username, i.ClientDevices.IPAddress)
+
+	if err := dbCon.Create(&emailEtt).Error; err != nil {
+		if strings.Index(err.Error(), "Error 1062: Duplicate entry") > -1 {
+			return nil, &sysError.SystemError{StatusCode: http.StatusConflict, Err: err}
+		}
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.SendMessageResponse)
+	resp.TemplateCode = req.TemplateCode
+	resp.MessageID = msgEtt.UUID
+	resp.MessageIDHeader = msgEtt.MessageIDHeader
+	resp.Status = emailEtt.Status
+
+	return resp, nil
+}
+
+// Preview render the message of the request without sending it (dry run), nothing is recorded:
+// the links (unsubscribe, tracking) are signed for a message ID that does not exist
+func (r *MessageRepo) Preview(req *domSchema.SendMessageRequest, i identity.Identity) (*domSchema.PreviewMessageResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	msgEtt := &domEntity.EmailMessageEntity{UUID: utils.GenerateUUID()}
+	msgEtt.MessageIDHeader = r.smtp.MessageID(msgEtt.UUID)
+
+	msg, err := r.render(dbCon, req, msgEtt)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := msg.Bytes()
+	req.RemoveSpooledAttachments()
+	if err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.PreviewMessageResponse)
+	resp.TemplateCode = req.TemplateCode
+	resp.MessageIDHeader = msgEtt.MessageIDHeader
+	resp.Format = string(msg.Format)
+	resp.Subject = msg.Subject
+	resp.Body = msg.Body
+	resp.TextBody = msg.TextBody
+	resp.Raw = string(raw)
+
+	return resp, nil
+}
+
+// Create create message record (QUEUED) of a send request delivered later by the outbox, assign req.MessageID
+func (r *MessageRepo) Create(req *domSchema.SendMessageRequest, i identity.Identity) (*domSchema.Message, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	msgEtt, err := r.create(dbCon, req, domSchema.QueuedStatus, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toMessage(msgEtt), nil
+}
+
+// SetStatus set delivery status of message record (with the last SMTP response, if any)
+func (r *MessageRepo) SetStatus(id string, status domSchema.MessageStatus, smtpResponse string) error {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return err
+	}
+
+	msgEtt, err := r.findMessage(dbCon, id)
+	if err != nil {
+		return err
+	}
+
+	return setMessageStatus(dbCon, msgEtt, status, smtpResponse, nil)
+}
+
+// FindByID find message record by ID, with its status transitions
+func (r *MessageRepo) FindByID(req *domSchema.FindMessageRequest, i identity.Identity) (*domSchema.FindMessageResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	msgEtt, err := r.findMessage(dbCon, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var evEtts []domEntity.EmailMessageEventEntity
+	if err := dbCon.Where("message_id = ?", msgEtt.ID).Order("occurred_at, id").Find(&evEtts).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	msg := r.toMessage(msgEtt)
+	for _, v := range evEtts {
+		msg.Events = append(msg.Events, &domSchema.MessageEvent{
+			Status:       v.Status,
+			SMTPResponse: v.SMTPResponse,
+			OccurredAt:   v.OccurredAt,
+		})
+	}
+
+	// response
+	resp := new(domSchema.FindMessageResponse)
+	resp.Query = *req
+	resp.Data = *msg
+
+	return resp, nil
+}
+
+// FindBodyByID find rendered subject and body of message record by ID
+func (r *MessageRepo) FindBodyByID(req *domSchema.FindMessageBodyRequest, i identity.Identity) (*domSchema.FindMessageBodyResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	msgEtt, err := r.findMessage(dbCon, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// response
+	resp := new(domSchema.FindMessageBodyResponse)
+	resp.Query = *req
+	resp.Data = domSchema.MessageBody{
+		ID:      msgEtt.UUID,
+		Subject: msgEtt.Subject,
+		Body:    msgEtt.Body,
+	}
+
+	return resp, nil
+}
+
+// List list message records (summary), newest first, with cursor pagination
+func (r *MessageRepo) List(req *domSchema.ListMessageRequest, i identity.Identity) (*domSchema.ListMessageResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := req.GetCursor()
+	if err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusBadRequest, Err: err}
+	}
+	limit := req.GetLimit()
+
+	query := dbCon.Model(&domEntity.EmailMessageEntity{}).Omit("body").Order("id DESC").Limit(limit + 1)
+	if cursor > 0 {
+		query = query.Where("id < ?", cursor)
+	}
+	if req.Recipient != "" {
+		// cc and bcc are stored as `Name <email>,...`
+		like := fmt.Sprintf("%%<%s>%%", req.Recipient)
+		query = query.Where("(to_email = ? OR cc LIKE ? OR bcc LIKE ?)", req.Recipient, like, like)
+	}
+	if req.TemplateCode != "" {
+		query = query.Where("template_code = ?", req.TemplateCode)
+	}
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+	if req.SentBy != "" {
+		query = query.Where("sent_by = ?", req.SentBy)
+	}
+	if since := req.GetSince(); !since.IsZero() {
+		query = query.Where("sys_created_at >= ?", since)
+	}
+	if until := req.GetUntil(); !until.IsZero() {
+		query = query.Where("sys_created_at < ?", until)
+	}
+
+	var msgEtts []domEntity.EmailMessageEntity
+	if err := query.Find(&msgEtts).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.ListMessageResponse)
+	resp.Query = *req
+	if len(msgEtts) > limit {
+		msgEtts = msgEtts[:limit]
+		resp.Data.NextCursor = domSchema.EncodeCursor(msgEtts[limit-1].ID)
+	}
+	resp.Data.Count = int64(len(msgEtts))
+	resp.Data.Messages = []*domSchema.MessageSummary{}
+	for _, v := range msgEtts {
+		resp.Data.Messages = append(resp.Data.Messages, &domSchema.MessageSummary{
+			ID:             v.UUID,
+			TemplateCode:   v.TemplateCode,
+			ToEmail:        v.ToEmail,
+			Subject:        v.Subject,
+			ProcessingType: v.ProcessingType,
+			Status:         v.Status,
+			Attempts:       v.Attempts,
+			SentBy:         v.SentBy,
+			CreatedAt:      v.CreatedAt,
+			SentAt:         v.SentAt,
+		})
+	}
+
+	return resp, nil
+}
+
+// startSending create (or find) message record of the request, and set it to SENDING
+func (r *MessageRepo) startSending(dbCon *gorm.DB, req *domSchema.SendMessageRequest, i identity.Identity) (*domEntity.EmailMessageEntity, error) {
+	if req.MessageID == "" {
+		return r.create(dbCon, req, domSchema.SendingStatus, i)
+	}
+
+	msgEtt, err := r.findMessage(dbCon, req.MessageID)
+	if err != nil {
+		return nil, err
+	}
+	if err := setMessageStatus(dbCon, msgEtt, domSchema.SendingStatus, "", map[string]interface{}{
+		"attempts": gorm.Expr("attempts + 1"),
+	}); err != nil {
+		return nil, err
+	}
+	msgEtt.Attempts++
+
+	return msgEtt, nil
+}
+
+func (r *MessageRepo) create(dbCon *gorm.DB, req *domSchema.SendMessageRequest, status domSchema.MessageStatus, i identity.Identity) (*domEntity.EmailMessageEntity, error) {
+	now := time.Now()
+
+	msgEtt := &domEntity.EmailMessageEntity{
+		UUID:           utils.GenerateUUID(),
+		TemplateCode:   req.TemplateCode,
+		CC:             r.compileEmail(req.CC),
+		BCC:            r.compileEmail(req.BCC),
+		ProcessingType: req.ProcessingType,
+		Status:         string(status),
+		SentBy:         i.Claims.Username,
+		IPAddress:      i.ClientDevices.IPAddress,
+	}
+	msgEtt.MessageIDHeader = r.smtp.MessageID(msgEtt.UUID)
+	if req.From != nil {
+		msgEtt.FromEmail, msgEtt.FromName = req.From.Email, req.From.Name
+	}
+	if req.To != nil {
+		msgEtt.ToEmail, msgEtt.ToName = req.To.Email, req.To.Name
+	}
+	if sendAt := req.GetSendAt(); !sendAt.IsZero() {
+		msgEtt.SendAt = &sendAt
+	}
+	switch status {
+	case domSchema.QueuedStatus:
+		msgEtt.QueuedAt = &now
+	case domSchema.SendingStatus:
+		msgEtt.SendingAt = &now
+		msgEtt.Attempts = 1
+	}
+	msgEtt.CreatedBy = fmt.Sprintf("%s@%s", i.Claims.Username, i.ClientDevices.IPAddress)
+
+	if err := dbCon.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(msgEtt).Error; err != nil {
+			return err
+		}
+		return tx.Create(&domEntity.EmailMessageEventEntity{
+			MessageID:  msgEtt.ID,
+			Status:     string(status),
+			OccurredAt: now,
+		}).Error
+	}); err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	req.MessageID = msgEtt.UUID
+	return msgEtt, nil
+}
+
+// setMessageStatus set message record status (and the status timestamp), and record the status transition
+func setMessageStatus(dbCon *gorm.DB, msgEtt *domEntity.EmailMessageEntity, status domSchema.MessageStatus, smtpResponse string, values map[string]interface{}) error {
+	now := time.Now()
+	if values == nil {
+		values = make(map[string]interface{})
+	}
+	values["status"] = status
+	if col, ok := messageStatusTimeColumns[status]; ok {
+		values[col] = now
+	}
+	if smtpResponse != "" {
+		values["last_smtp_response"] = smtpResponse
+	}
+	values["sys_updated_by"] = "system.delivery"
+	values["sys_updated_at"] = now
+
+	if err := dbCon.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domEntity.EmailMessageEntity{}).Where("id = ?", msgEtt.ID).Updates(values).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&domEntity.EmailMessageEventEntity{
+			MessageID:    msgEtt.ID,
+			Status:       string(status),
+			SMTPResponse: smtpResponse,
+			OccurredAt:   now,
+		}).Error; err != nil {
+			return err
+		}
+		if event, ok := messageStatusWebhookEvents[status]; ok {
+			// message events are only published to the webhook endpoints of the sender
+			return publishWebhookEvent(tx, event, msgEtt.SentBy, domSchemaWebhook.MessageEventData{
+				ID:              msgEtt.UUID,
+				MessageIDHeader: msgEtt.MessageIDHeader,
+				TemplateCode:    msgEtt.TemplateCode,
+				ToEmail:         msgEtt.ToEmail,
+				Status:          string(status),
+				SMTPResponse:    smtpResponse,
+				SentBy:          msgEtt.SentBy,
+			})
+		}
+		return nil
+	}); err != nil {
+		return &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	msgEtt.Status = string(status)
+	return nil
+}
+
+var messageStatusTimeColumns = map[domSchema.MessageStatus]string{
+	domSchema.QueuedStatus:  "queued_at",
+	domSchema.SendingStatus: "sending_at",
+	domSchema.SentStatus:    "sent_at",
+	domSchema.FailedStatus:  "failed_at",
+	domSchema.BouncedStatus: "bounced_at",
+}
+
+var messageStatusWebhookEvents = map[domSchema.MessageStatus]domSchemaWebhook.EventType{
+	domSchema.SentStatus:    domSchemaWebhook.MessageSentEvent,
+	domSchema.FailedStatus:  domSchemaWebhook.MessageFailedEvent,
+	domSchema.BouncedStatus: domSchemaWebhook.MessageBouncedEvent,
+}
+
+func (r *MessageRepo) findMessage(dbCon *gorm.DB, id string) (*domEntity.EmailMessageEntity, error) {
+	msgEtt := new(domEntity.EmailMessageEntity)
+	res := dbCon.Where("uuid = ?", id).Limit(1).Find(msgEtt)
+	if res.Error != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: res.Error}
+	}
+	if res.RowsAffected == 0 {
+		return nil, &sysError.SystemError{StatusCode: http.StatusNotFound, Err: fmt.Errorf("Message `%s` not found", id)}
+	}
+	return msgEtt, nil
+}
+
+func (r *MessageRepo) toMessage(v *domEntity.EmailMessageEntity) *domSchema.Message {
+	return &domSchema.Message{
+		ID:               v.UUID,
+		MessageIDHeader:  v.MessageIDHeader,
+		TemplateCode:     v.TemplateCode,
+		FromEmail:        v.FromEmail,
+		FromName:         v.FromName,
+		ToEmail:          v.ToEmail,
+		ToName:           v.ToName,
+		CC:               v.CC,
+		BCC:              v.BCC,
+		Subject:          v.Subject,
+		ProcessingType:   v.ProcessingType,
+		Status:           v.Status,
+		Attempts:         v.Attempts,
+		LastSMTPResponse: v.LastSMTPResponse,
+		SendAt:           v.SendAt,
+		QueuedAt:         v.QueuedAt,
+		SendingAt:        v.SendingAt,
+		SentAt:           v.SentAt,
+		FailedAt:         v.FailedAt,
+		BouncedAt:        v.BouncedAt,
+		FirstOpenedAt:    v.FirstOpenedAt,
+		OpenCount:        v.OpenCount,
+		SentBy:           v.SentBy,
+		CreatedAt:        v.CreatedAt,
+	}
+}
+
+func (r *MessageRepo) composeMessage(req *domSchema.SendMessageRequest, subject, body string, assets []domEntity.EmailTemplateAssetEntity) *mailer.Message {
+	msg := &mailer.Message{
+		From:    r.toAddress(req.From),
+		To:      []*mailer.Address{r.toAddress(req.To)},
+		CC:      r.toAddresses(req.CC),
+		BCC:     r.toAddresses(req.BCC),
+		Subject: subject,
+		Body:    body,
+		Format:  mailer.Format(req.Template.EmailFormat),
+	}
+	if req.ReplyTo != nil {
+		msg.ReplyTo = r.toAddress(req.ReplyTo)
+	}
+	msg.Priority = mailer.Priority(req.Priority)
+	// custom (`X-`) headers
+	if len(req.Headers) > 0 {
+		msg.Headers = make(textproto.MIMEHeader, len(req.Headers))
+		for k, v := range req.Headers {
+			msg.Headers.Set(k, mime.QEncoding.Encode("utf-8", v))
+		}
+	}
+	// template assets, unless the request sends an inline attachment with the same content id
+	inlines := make(map[string]bool)
+	for _, a := range req.Attachments {
+		if a.ContentID != "" {
+			inlines[a.ContentID] = true
+		}
+	}
+	for _, v := range assets {
+		if inlines[v.ContentID] {
+			continue
+		}
+		msg.Attachments = append(msg.Attachments, &mailer.Attachment{
+			Filename:    v.Filename,
+			ContentType: v.ContentType,
+			ContentID:   v.ContentID,
+			Content:     v.Content,
+		})
+	}
+	for _, a := range req.Attachments {
+		msg.Attachments = append(msg.Attachments, &mailer.Attachment{
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			ContentID:   a.ContentID,
+			Content:     a.Content,
+			FilePath:    a.FilePath,
+		})
+	}
+	return msg
+}
+
+func (r *MessageRepo) findTemplateAssets(dbCon *gorm.DB, templateVersionID uint64) ([]domEntity.EmailTemplateAssetEntity, error) {
+	var assetEtts []domEntity.EmailTemplateAssetEntity
+	if err := dbCon.Where("template_version_id = ?", templateVersionID).Order("content_id").Find(&assetEtts).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	return assetEtts, nil
+}
+
+func (r *MessageRepo) toAddress(m *domSchemaEmail.MailAddress) *mailer.Address {
+	return &mailer.Address{Email: m.Email, Name: m.Name}
+}
+
+func (r *MessageRepo) toAddresses(ms []*domSchemaEmail.MailAddress) []*mailer.Address {
+	var as []*mailer.Address
+	for _, m := range ms {
+		as = append(as, r.toAddress(m))
+	}
+	return as
+}
+
+func (r *MessageRepo) compileEmail(mailAddresses []*domSchemaEmail.MailAddress) string {
+	var str string
+	for _, v := range mailAddresses {
+		if str != "" {
+			str = fmt.Sprintf("%s,", str)
+		}
+		str = fmt.Sprintf("%s%s <%s>", str, v.Name, v.Email)
+	}
+	return str
+}
+
+// render render the message of the request (template, unsubscribe link, tracking and template assets), ready to be sent
+func (r *MessageRepo) render(dbCon *gorm.DB, req *domSchema.SendMessageRequest, msgEtt *domEntity.EmailMessageEntity) (*mailer.Message, error) {
+	// marketing emails: unsubscribe link (template data `Unsubscribe.URL`) and List-Unsubscribe headers
+	templateData, headers, err := r.unsubscribeLink(req, msgEtt)
+	if err != nil {
+		return nil, err
+	}
+
+	tpl, err := req.GetCompiledTemplate()
+	if err != nil {
+		return nil, err
+	}
+	subjEmail, err := tpl.ExecuteSubject(templateData)
+	if err != nil {
+		return nil, err
+	}
+	bodyEmail, err := tpl.ExecuteBody(templateData)
+	if err != nil {
+		return nil, err
+	}
+	bodyEmail, err = r.inlineCSS(req, bodyEmail)
+	if err != nil {
+		return nil, err
+	}
+	bodyEmail, err = r.trackClicks(req, msgEtt, bodyEmail)
+	if err != nil {
+		return nil, err
+	}
+	bodyEmail, err = r.trackOpens(req, msgEtt, bodyEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	// inline images (assets) of the template version
+	assets, err := r.findTemplateAssets(dbCon, req.Template.DefaultTemplateVersion.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := r.composeMessage(req, subjEmail, string(bodyEmail), assets)
+	msg.MessageID = msgEtt.MessageIDHeader
+	if msg.TextBody, err = r.compileTextBody(req, templateData, msg.Body); err != nil {
+		return nil, err
+	}
+	for k, vs := range headers {
+		if msg.Headers == nil {
+			msg.Headers = make(textproto.MIMEHeader)
+		}
+		msg.Headers[k] = vs
+	}
+
+	// meeting invite
+	if req.CalendarEvent != nil {
+		if msg.Calendar, err = r.calendarInvite(req, subjEmail); err != nil {
+			return nil, err
+		}
+	}
+
+	// non-PRODUCTION stages
+	if err := r.sandboxMessage(msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// calendarInvite build calendar invite (iCalendar) of the request calendar event, the organizer defaults to the sender (from),
+// the attendees to the recipient (to) and cc, and the summary to the subject
+func (r *MessageRepo) calendarInvite(req *domSchema.SendMessageRequest, subject string) (*mailer.Calendar, error) {
+	ev := req.CalendarEvent
+	start, end, err := ev.Times()
+	if err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusBadRequest, Err: err}
+	}
+
+	e := &calendar.Event{
+		UID:         ev.UID,
+		Method:      string(ev.GetMethod()),
+		Sequence:    ev.Sequence,
+		Summary:     ev.Summary,
+		Description: ev.Description,
+		Location:    ev.Location,
+		Start:       start,
+		End:         end,
+	}
+	if e.Summary == "" {
+		e.Summary = subject
+	}
+	organizer := ev.Organizer
+	if organizer == nil {
+		organizer = req.From
+	}
+	e.Organizer = &calendar.Address{Email: organizer.Email, Name: organizer.Name}
+	attendees := ev.Attendees
+	if len(attendees) == 0 {
+		attendees = append([]*domSchemaEmail.MailAddress{req.To}, req.CC...)
+	}
+	for _, a := range attendees {
+		if a != nil {
+			e.Attendees = append(e.Attendees, &calendar.Address{Email: a.Email, Name: a.Name})
+		}
+	}
+
+	return &mailer.Calendar{Method: e.Method, Content: e.Bytes()}, nil
+}
+
+// sandboxMessage redirect (or drop) the recipients of the message by the sandbox policy of non-PRODUCTION stages,
+// the subject is prefixed with the stage and the original recipients are kept in X-Original-To/Cc/Bcc headers
+func (r *MessageRepo) sandboxMessage(msg *mailer.Message) error {
+	if !r.sandbox.IsEnabled() {
+		return nil
+	}
+	if msg.Headers == nil {
+		msg.Headers = make(textproto.MIMEHeader)
+	}
+
+	seen := make(map[string]bool)
+	rewrite := func(header string, as []*mailer.Address) []*mailer.Address {
+		var kept, original []*mailer.Address
+		for _, a := range as {
+			original = append(original, a)
+			email := r.sandbox.Recipient(a.Email)
+			if email == "" || seen[strings.ToLower(email)] {
+				continue
+			}
+			seen[strings.ToLower(email)] = true
+			kept = append(kept, &mailer.Address{Email: email, Name: a.Name})
+		}
+		if len(original) > 0 {
+			var vs []string
+			for _, a := range original {
+				vs = append(vs, a.String())
+			}
+			msg.Headers.Set(header, strings.Join(vs, ", "))
+		}
+		return kept
+	}
+	to := msg.To
+	msg.To = rewrite("X-Original-To", msg.To)
+	msg.CC = rewrite("X-Original-Cc", msg.CC)
+	msg.BCC = rewrite("X-Original-Bcc", msg.BCC)
+	if len(msg.To) == 0 && len(to) > 0 {
+		return fmt.Errorf("%w (%s): %s", domSchema.ErrRecipientSandboxed, r.sandbox.Stage, to[0].Email)
+	}
+
+	msg.Subject = r.sandbox.Subject(msg.Subject)
+	return nil
+}
+
+// unsubscribeLink get template data and additional headers of a marketing message, with the signed unsubscribe link
+// of the recipient (to), as RFC 8058 one-click List-Unsubscribe
+func (r *MessageRepo) unsubscribeLink(req *domSchema.SendMessageRequest, msgEtt *domEntity.EmailMessageEntity) (map[string]interface{}, textproto.MIMEHeader, error) {
+	if !req.Setting.IsMarketing() {
+		return req.TemplateData, nil, nil
+	}
+
+	url, err := r.links.URL(links.UnsubscribePath, domSchemaUnsubscribe.Token{MessageID: msgEtt.UUID, Email: req.To.Email})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := make(map[string]interface{}, len(req.TemplateData)+1)
+	for k, v := range req.TemplateData {
+		data[k] = v
+	}
+	data[domSchemaUnsubscribe.URLTemplateData] = url
+
+	headers := textproto.MIMEHeader{}
+	headers.Set("List-Unsubscribe", fmt.Sprintf("<%s>", url))
+	headers.Set("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+
+	return data, headers, nil
+}
+
+// inlineCSS inline the CSS rules of the <style> blocks of the HTML body into style attributes, when CSS inlining is on (template settings)
+func (r *MessageRepo) inlineCSS(req *domSchema.SendMessageRequest, body []byte) ([]byte, error) {
+	if !req.IsInlineCSS() || mailer.Format(req.Template.EmailFormat) != mailer.HTMLFormat {
+		return body, nil
+	}
+
+	inlined, err := cssinline.Inline(string(body))
+	if err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	return []byte(inlined), nil
+}
+
+// trackClicks rewrite the links of the HTML body through the signed click redirect, when click tracking is on (template settings),
+// links of this service (e.g: the unsubscribe link) are kept
+func (r *MessageRepo) trackClicks(req *domSchema.SendMessageRequest, msgEtt *domEntity.EmailMessageEntity, body []byte) ([]byte, error) {
+	if !req.IsTrackClicks() || mailer.Format(req.Template.EmailFormat) != mailer.HTMLFormat {
+		return body, nil
+	}
+
+	var err error
+	res := tracking.RewriteLinks(string(body), func(index int, url string) string {
+		if err != nil || r.links.IsLink(url) {
+			return ""
+		}
+		var clickURL string
+		clickURL, err = r.links.URL(links.ClickPath, domSchemaTracking.ClickToken{MessageID: msgEtt.UUID, LinkIndex: index, URL: url})
+		return clickURL
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(res), nil
+}
+
+// trackOpens inject the signed open tracking pixel into the HTML body, when open tracking is on (per send or by template settings)
+func (r *MessageRepo) trackOpens(req *domSchema.SendMessageRequest, msgEtt *domEntity.EmailMessageEntity, body []byte) ([]byte, error) {
+	if !req.IsTrackOpens() || mailer.Format(req.Template.EmailFormat) != mailer.HTMLFormat {
+		return body, nil
+	}
+
+	url, err := r.links.URL(links.OpenPath, domSchemaTracking.OpenToken{MessageID: msgEtt.UUID})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(tracking.InjectPixel(string(body), url)), nil
+}
+
+// compileTextBody compile text/plain alternative of the HTML email: the text body template of the template settings,
+// or generated from the (final) HTML body
+func (r *MessageRepo) compileTextBody(req *domSchema.SendMessageRequest, data map[string]interface{}, body string) (string, error) {
+	if mailer.Format(req.Template.EmailFormat) != mailer.HTMLFormat {
+		return "", nil
+	}
+	tpl := req.GetTextBodyTpl()
+	if tpl == "" {
+		return plaintext.FromHTML(body), nil
+	}
+
+	t, err := textTemplate.New(domSchemaTS.TextTemplateName).Parse(tpl)
+	if err != nil {
+		return "", err
+	}
+	buf := new(bytes.Buffer)
+	if err := t.ExecuteTemplate(buf, domSchemaTS.TextTemplateName, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
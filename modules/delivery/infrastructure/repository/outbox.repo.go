@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	domSchemaEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/schema/email"
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+	"github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/service/mailer"
+	"github.com/d3ta-go/ms-email-restapi/modules/delivery/infrastructure/service/queue"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+	"github.com/d3ta-go/system/system/utils"
+	"gorm.io/gorm"
+)
+
+// NewOutboxRepo new OutboxRepo
+func NewOutboxRepo(h *handler.Handler) (domRepo.IOutboxRepo, error) {
+
+	repo := new(OutboxRepo)
+	repo.handler = h
+
+	cfg, err := h.GetDefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+	repo.SetDBConnectionName(cfg.Databases.EmailDB.ConnectionName)
+
+	repo.queue, err = queue.NewQueue(h)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// OutboxRepo type Implement IOutboxRepo
+type OutboxRepo struct {
+	BaseRepo
+	queue queue.Queue
+}
+
+// Enqueue persist send request into the outbox, to be delivered at SendAt (ASYNC: now)
+func (r *OutboxRepo) Enqueue(req *domSchema.SendMessageRequest, maxAttempts int, i identity.Identity) (*domSchema.SendMessageResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	// attachments (incl. spooled files) are persisted with the request
+	defer req.RemoveSpooledAttachments()
+	if err := req.EncodeAttachments(); err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	kind := domEntity.ScheduledKind
+	sendAt := req.GetSendAt()
+	if sendAt.IsZero() {
+		kind = domEntity.AsyncKind
+		sendAt = time.Now()
+	}
+
+	obEtt := domEntity.EmailOutboxMessageEntity{
+		UUID:          utils.GenerateUUID(),
+		Kind:          string(kind),
+		TemplateCode:  req.TemplateCode,
+		SendAt:        sendAt,
+		NextAttemptAt: sendAt,
+		Status:        string(domEntity.PendingStatus),
+		Payload:       string(payload),
+		MaxAttempts:   maxAttempts,
+		ScheduledBy:   i.Claims.Username,
+		IPAddress:     i.ClientDevices.IPAddress,
+	}
+	obEtt.CreatedBy = fmt.Sprintf("%s@%s", i.Claims.Username, i.ClientDevices.IPAddress)
+
+	if err := dbCon.Create(&obEtt).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.SendMessageResponse)
+	resp.TemplateCode = req.TemplateCode
+	if kind == domEntity.ScheduledKind {
+		resp.Status = string(domEntity.ScheduledKind)
+		resp.ScheduleID = obEtt.UUID
+		resp.SendAt = obEtt.SendAt.Format(time.RFC3339)
+	} else {
+		resp.Status = fmt.Sprintf("QUEUED.%s", domSchemaEmail.ASYNCProcess)
+		// the message is already persisted, a failed push is picked up by the outbox polling
+		if r.queue != nil {
+			r.queue.Push(context.Background(), obEtt.UUID)
+		}
+	}
+
+	return resp, nil
+}
+
+// ClaimDue claim due outbox messages (and messages whose claim lease has expired, e.g: after a crash).
+// A message is claimed by one dispatcher only, even when several instances are running.
+func (r *OutboxRepo) ClaimDue(limit int, leaseTimeout time.Duration) ([]*domSchema.OutboxMessage, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var obEtts []domEntity.EmailOutboxMessageEntity
+	if err := dbCon.
+		Where("status = ? AND next_attempt_at <= ?", domEntity.PendingStatus, now).
+		Or("status = ? AND claimed_at < ?", domEntity.SendingStatus, now.Add(-leaseTimeout)).
+		Order("next_attempt_at").Limit(limit).
+		Find(&obEtts).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	var claimed []*domSchema.OutboxMessage
+	for _, v := range obEtts {
+		msg, err := r.claim(dbCon, v, now)
+		if err != nil {
+			return claimed, err
+		}
+		if msg != nil {
+			claimed = append(claimed, msg)
+		}
+	}
+
+	return claimed, nil
+}
+
+// ClaimNext wait (up to timeout) for a queued outbox message and claim it,
+// returns nil message when the queue stays empty, or the message is not claimable anymore (e.g: claimed by the outbox polling)
+func (r *OutboxRepo) ClaimNext(ctx context.Context, timeout, leaseTimeout time.Duration) (*domSchema.OutboxMessage, error) {
+	if r.queue == nil {
+		return nil, fmt.Errorf("Delivery queue is not enabled")
+	}
+
+	id, err := r.queue.Pop(ctx, timeout)
+	if err != nil || id == "" {
+		return nil, err
+	}
+
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var obEtt domEntity.EmailOutboxMessageEntity
+	res := dbCon.
+		Where("uuid = ? AND ((status = ? AND next_attempt_at <= ?) OR (status = ? AND claimed_at < ?))", id,
+			domEntity.PendingStatus, now, domEntity.SendingStatus, now.Add(-leaseTimeout)).
+		Limit(1).Find(&obEtt)
+	if res.Error != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: res.Error}
+	}
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return r.claim(dbCon, obEtt, now)
+}
+
+// claim optimistic claim: only one dispatcher can move `attempts` forward, returns nil message when already claimed
+func (r *OutboxRepo) claim(dbCon *gorm.DB, v domEntity.EmailOutboxMessageEntity, now time.Time) (*domSchema.OutboxMessage, error) {
+	res := dbCon.Model(&domEntity.EmailOutboxMessageEntity{}).
+		Where("id = ? AND status = ? AND attempts = ?", v.ID, v.Status, v.Attempts).
+		Updates(map[string]interface{}{
+			"status":         domEntity.SendingStatus,
+			"attempts":       gorm.Expr("attempts + 1"),
+			"claimed_at":     now,
+			"sys_updated_by": "system.dispatcher",
+			"sys_updated_at": now,
+		})
+	if res.Error != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: res.Error}
+	}
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	msg := &domSchema.OutboxMessage{
+		ID:          v.UUID,
+		Request:     new(domSchema.SendMessageRequest),
+		Attempts:    v.Attempts + 1,
+		MaxAttempts: v.MaxAttempts,
+		ScheduledBy: v.ScheduledBy,
+		IPAddress:   v.IPAddress,
+	}
+	if err := json.Unmarshal([]byte(v.Payload), msg.Request); err != nil {
+		return nil, r.dead(dbCon, v.UUID, fmt.Errorf("Invalid outbox message payload: %s", err.Error()))
+	}
+
+	return msg, nil
+}
+
+// Complete set status of a claimed outbox message: SENT, PENDING (retry with backoff) or FAILED (dead-letter),
+// returns the resulting message status (SENT, QUEUED or FAILED)
+func (r *OutboxRepo) Complete(msg *domSchema.OutboxMessage, sendErr error, policy domSchema.RetryPolicy) (domSchema.MessageStatus, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return "", err
+	}
+
+	if sendErr == nil {
+		return domSchema.SentStatus, r.finish(dbCon, msg.ID, domEntity.SentStatus, nil)
+	}
+
+	if msg.MaxAttempts > 0 {
+		policy.MaxAttempts = msg.MaxAttempts
+	}
+	if mailer.IsPermanentError(sendErr) || errors.Is(sendErr, domSchema.ErrRecipientSuppressed) || errors.Is(sendErr, domSchema.ErrRecipientSandboxed) || !policy.CanRetry(msg.Attempts) {
+		return domSchema.FailedStatus, r.dead(dbCon, msg.ID, sendErr)
+	}
+
+	// retry
+	now := time.Now()
+	if err := dbCon.Model(&domEntity.EmailOutboxMessageEntity{}).Where("uuid = ?", msg.ID).
+		Updates(map[string]interface{}{
+			"status":          domEntity.PendingStatus,
+			"next_attempt_at": now.Add(policy.Backoff(msg.Attempts)),
+			"last_error":      sendErr.Error(),
+			"sys_updated_by":  "system.dispatcher",
+			"sys_updated_at":  now,
+		}).Error; err != nil {
+		return "", &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	return domSchema.QueuedStatus, nil
+}
+
+// dead move outbox message into the dead-letter (FAILED) state
+func (r *OutboxRepo) dead(dbCon *gorm.DB, id string, sendErr error) error {
+	return r.finish(dbCon, id, domEntity.FailedStatus, sendErr)
+}
+
+func (r *OutboxRepo) finish(dbCon *gorm.DB, id string, status domEntity.OutboxMessageStatus, sendErr error) error {
+	now := time.Now()
+	values := map[string]interface{}{
+		"status":         status,
+		"processed_at":   now,
+		"sys_updated_by": "system.dispatcher",
+		"sys_updated_at": now,
+	}
+	if sendErr != nil {
+		values["last_error"] = sendErr.Error()
+	}
+
+	if err := dbCon.Model(&domEntity.EmailOutboxMessageEntity{}).Where("uuid = ?", id).Updates(values).Error; err != nil {
+		return &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,387 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	domSchemaMessage "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/schedule"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+	"github.com/d3ta-go/system/system/utils"
+	"github.com/golang/glog"
+	"gorm.io/gorm"
+)
+
+const defaultScheduleRunLimit = 50
+
+// NewRecurringScheduleRepo new RecurringScheduleRepo
+func NewRecurringScheduleRepo(h *handler.Handler) (domRepo.IRecurringScheduleRepo, error) {
+
+	repo := new(RecurringScheduleRepo)
+	repo.handler = h
+
+	cfg, err := h.GetDefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+	repo.SetDBConnectionName(cfg.Databases.EmailDB.ConnectionName)
+
+	return repo, nil
+}
+
+// RecurringScheduleRepo type Implement IRecurringScheduleRepo
+type RecurringScheduleRepo struct {
+	BaseRepo
+}
+
+// Create create recurring Schedule
+func (r *RecurringScheduleRepo) Create(req *domSchema.RSCreateRequest, i identity.Identity) (*domSchema.RSCreateResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	nextRunAt, err := domSchema.NextRun(req.CronExpression, req.Timezone, time.Now())
+	if err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusBadRequest, Err: err}
+	}
+	payload, err := json.Marshal(domSchema.Payload{
+		From:         req.From,
+		To:           req.To,
+		CC:           req.CC,
+		BCC:          req.BCC,
+		TemplateData: req.TemplateData,
+	})
+	if err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	rsEtt := domEntity.EmailRecurringScheduleEntity{
+		UUID:           utils.GenerateUUID(),
+		Name:           req.Name,
+		CronExpression: req.CronExpression,
+		Timezone:       req.Timezone,
+		TemplateCode:   req.TemplateCode,
+		Payload:        string(payload),
+		Status:         string(domSchema.ActiveStatus),
+		NextRunAt:      &nextRunAt,
+		IPAddress:      i.ClientDevices.IPAddress,
+		OwnedBy:        i.Claims.Username,
+	}
+	rsEtt.CreatedBy = fmt.Sprintf("%s@%s", i.Claims.Username, i.ClientDevices.IPAddress)
+
+	if err := dbCon.Create(&rsEtt).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.RSCreateResponse)
+	resp.Schedule = r.toSchedule(&rsEtt)
+
+	return resp, nil
+}
+
+// List list recurring Schedules (owned by the identity)
+func (r *RecurringScheduleRepo) List(req *domSchema.RSListRequest, i identity.Identity) (*domSchema.RSListResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	query := dbCon.Where("owned_by = ?", i.Claims.Username).Order("id")
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+	if req.TemplateCode != "" {
+		query = query.Where("template_code = ?", req.TemplateCode)
+	}
+
+	var rsEtts []domEntity.EmailRecurringScheduleEntity
+	if err := query.Find(&rsEtts).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.RSListResponse)
+	resp.Query = *req
+	resp.Data.Count = int64(len(rsEtts))
+	resp.Data.Schedules = []*domSchema.Schedule{}
+	for _, v := range rsEtts {
+		s := r.toSchedule(&v)
+		resp.Data.Schedules = append(resp.Data.Schedules, &s)
+	}
+
+	return resp, nil
+}
+
+// SetStatus pause or resume recurring Schedule
+func (r *RecurringScheduleRepo) SetStatus(req *domSchema.RSSetStatusRequest, i identity.Identity) (*domSchema.RSSetStatusResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	rsEtt, err := r.findSchedule(dbCon, req.ID, i)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsEtt.Status != req.Status {
+		rsEtt.Status = req.Status
+		rsEtt.NextRunAt = nil
+		if req.Status == string(domSchema.ActiveStatus) {
+			// resume from now, runs missed while paused are skipped
+			nextRunAt, err := domSchema.NextRun(rsEtt.CronExpression, rsEtt.Timezone, time.Now())
+			if err != nil {
+				return nil, &sysError.SystemError{StatusCode: http.StatusBadRequest, Err: err}
+			}
+			rsEtt.NextRunAt = &nextRunAt
+		}
+		rsEtt.UpdatedBy = fmt.Sprintf("%s@%s", i.Claims.Username, i.ClientDevices.IPAddress)
+
+		if err := dbCon.Model(rsEtt).Select("status", "next_run_at", "sys_updated_by").Updates(rsEtt).Error; err != nil {
+			return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+		}
+	}
+
+	// response
+	resp := new(domSchema.RSSetStatusResponse)
+	resp.Query = *req
+	resp.Data = r.toSchedule(rsEtt)
+
+	return resp, nil
+}
+
+// Delete delete recurring Schedule (the run history is kept)
+func (r *RecurringScheduleRepo) Delete(req *domSchema.RSDeleteRequest, i identity.Identity) (*domSchema.RSDeleteResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	rsEtt, err := r.findSchedule(dbCon, req.ID, i)
+	if err != nil {
+		return nil, err
+	}
+
+	rsEtt.DeletedBy = fmt.Sprintf("%s@%s", i.Claims.Username, i.ClientDevices.IPAddress)
+	if err := dbCon.Model(rsEtt).Select("sys_deleted_by").Updates(rsEtt).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	if err := dbCon.Delete(rsEtt).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.RSDeleteResponse)
+	resp.Query = *req
+	resp.Data = r.toSchedule(rsEtt)
+
+	return resp, nil
+}
+
+// ListRun list run history of a recurring Schedule (latest first)
+func (r *RecurringScheduleRepo) ListRun(req *domSchema.RSListRunRequest, i identity.Identity) (*domSchema.RSListRunResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	rsEtt, err := r.findSchedule(dbCon, req.ID, i)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultScheduleRunLimit
+	}
+	var runEtts []domEntity.EmailRecurringScheduleRunEntity
+	if err := dbCon.Where("schedule_id = ?", rsEtt.ID).Order("id DESC").Limit(limit).Find(&runEtts).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.RSListRunResponse)
+	resp.Query = *req
+	resp.Data.Schedule = r.toSchedule(rsEtt)
+	resp.Data.Count = int64(len(runEtts))
+	resp.Data.Runs = []*domSchema.Run{}
+	for _, v := range runEtts {
+		resp.Data.Runs = append(resp.Data.Runs, &domSchema.Run{
+			ID:           v.UUID,
+			ScheduledFor: v.ScheduledFor,
+			StartedAt:    v.StartedAt,
+			FinishedAt:   v.FinishedAt,
+			Status:       v.Status,
+			Error:        v.Error,
+		})
+	}
+
+	return resp, nil
+}
+
+// ClaimDue claim due (active) recurring Schedules and move them to their next run.
+// A run is claimed by one dispatcher only, even when several instances are running.
+// Runs missed while the service was down are caught up once.
+// The stored payload is validated again (e.g: the disposable domain blocklist has changed),
+// a schedule with an invalid payload is paused, without a run.
+func (r *RecurringScheduleRepo) ClaimDue(limit int, disposableDomains map[string]bool) ([]*domSchema.DueSchedule, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var rsEtts []domEntity.EmailRecurringScheduleEntity
+	if err := dbCon.Where("status = ? AND next_run_at <= ?", domSchema.ActiveStatus, now).
+		Order("next_run_at").Limit(limit).Find(&rsEtts).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	var claimed []*domSchema.DueSchedule
+	for _, v := range rsEtts {
+		values := map[string]interface{}{
+			"last_run_at":    now,
+			"sys_updated_by": "system.scheduler",
+		}
+		nextRunAt, err := domSchema.NextRun(v.CronExpression, v.Timezone, now)
+		if err != nil {
+			// cron expression can not run anymore, pause the schedule
+			values["status"] = domSchema.PausedStatus
+			values["next_run_at"] = nil
+		} else {
+			values["next_run_at"] = nextRunAt
+		}
+
+		req, payloadErr := r.toSendMessageRequest(&v, disposableDomains)
+		if payloadErr != nil {
+			values["status"] = domSchema.PausedStatus
+			values["next_run_at"] = nil
+		}
+
+		// optimistic claim: only one dispatcher can move `next_run_at` forward
+		res := dbCon.Model(&domEntity.EmailRecurringScheduleEntity{}).
+			Where("id = ? AND next_run_at = ?", v.ID, v.NextRunAt).
+			Updates(values)
+		if res.Error != nil {
+			return claimed, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: res.Error}
+		}
+		if res.RowsAffected == 0 {
+			continue
+		}
+
+		if payloadErr != nil {
+			glog.Errorf("Recurring Schedule `%s` paused, invalid payload: %s", v.UUID, payloadErr.Error())
+			continue
+		}
+		claimed = append(claimed, &domSchema.DueSchedule{
+			ID:           v.UUID,
+			ScheduledFor: *v.NextRunAt,
+			Request:      req,
+			CreatedBy:    v.OwnedBy,
+			IPAddress:    v.IPAddress,
+		})
+	}
+
+	return claimed, nil
+}
+
+// RecordRun record a run of recurring Schedule (SENT or FAILED if sendErr is not nil)
+func (r *RecurringScheduleRepo) RecordRun(id string, scheduledFor, startedAt time.Time, sendErr error) error {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return err
+	}
+
+	var rsEtt domEntity.EmailRecurringScheduleEntity
+	if err := dbCon.Unscoped().Where("uuid = ?", id).First(&rsEtt).Error; err != nil {
+		return &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	finishedAt := time.Now()
+	runEtt := domEntity.EmailRecurringScheduleRunEntity{
+		UUID:         utils.GenerateUUID(),
+		ScheduleID:   rsEtt.ID,
+		ScheduledFor: scheduledFor,
+		StartedAt:    &startedAt,
+		FinishedAt:   &finishedAt,
+		Status:       string(domSchema.RunSent),
+	}
+	if sendErr != nil {
+		runEtt.Status = string(domSchema.RunFailed)
+		runEtt.Error = sendErr.Error()
+	}
+	runEtt.CreatedBy = "system.scheduler"
+
+	if err := dbCon.Create(&runEtt).Error; err != nil {
+		return &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	return nil
+}
+
+// findSchedule find recurring Schedule owned by the identity (a schedule of another owner is not found)
+func (r *RecurringScheduleRepo) findSchedule(dbCon *gorm.DB, id string, i identity.Identity) (*domEntity.EmailRecurringScheduleEntity, error) {
+	var rsEtt domEntity.EmailRecurringScheduleEntity
+	result := dbCon.Where("uuid = ? AND owned_by = ?", id, i.Claims.Username).Limit(1).Find(&rsEtt)
+	if result.Error != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: result.Error}
+	}
+	if result.RowsAffected == 0 {
+		return nil, &sysError.SystemError{StatusCode: http.StatusNotFound, Err: fmt.Errorf("Invalid Recurring Schedule")}
+	}
+	return &rsEtt, nil
+}
+
+// toSendMessageRequest send request (of a run) of the stored payload, validated as a send request
+func (r *RecurringScheduleRepo) toSendMessageRequest(rsEtt *domEntity.EmailRecurringScheduleEntity, disposableDomains map[string]bool) (*domSchemaMessage.SendMessageRequest, error) {
+	var payload domSchema.Payload
+	if err := json.Unmarshal([]byte(rsEtt.Payload), &payload); err != nil {
+		return nil, err
+	}
+
+	req := domSchema.RSCreateRequest{
+		TemplateCode: rsEtt.TemplateCode,
+		From:         payload.From,
+		To:           payload.To,
+		CC:           payload.CC,
+		BCC:          payload.BCC,
+		TemplateData: payload.TemplateData,
+	}
+	msgReq := req.ToSendMessageRequest()
+	if err := msgReq.Validate(); err != nil {
+		return nil, err
+	}
+	if err := msgReq.ValidateAddresses(disposableDomains); err != nil {
+		return nil, err
+	}
+	return msgReq, nil
+}
+
+func (r *RecurringScheduleRepo) toSchedule(rsEtt *domEntity.EmailRecurringScheduleEntity) domSchema.Schedule {
+	var payload domSchema.Payload
+	json.Unmarshal([]byte(rsEtt.Payload), &payload)
+
+	return domSchema.Schedule{
+		ID:             rsEtt.UUID,
+		Name:           rsEtt.Name,
+		CronExpression: rsEtt.CronExpression,
+		Timezone:       rsEtt.Timezone,
+		TemplateCode:   rsEtt.TemplateCode,
+		From:           payload.From,
+		To:             payload.To,
+		CC:             payload.CC,
+		BCC:            payload.BCC,
+		TemplateData:   payload.TemplateData,
+		Status:         rsEtt.Status,
+		NextRunAt:      rsEtt.NextRunAt,
+		LastRunAt:      rsEtt.LastRunAt,
+		CreatedBy:      rsEtt.OwnedBy,
+	}
+}
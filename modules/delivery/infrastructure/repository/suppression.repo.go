@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/suppression"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+	"github.com/d3ta-go/system/system/utils"
+	"gorm.io/gorm"
+)
+
+// NewSuppressionRepo new SuppressionRepo
+func NewSuppressionRepo(h *handler.Handler) (domRepo.ISuppressionRepo, error) {
+
+	repo := new(SuppressionRepo)
+	repo.handler = h
+
+	cfg, err := h.GetDefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+	repo.SetDBConnectionName(cfg.Databases.EmailDB.ConnectionName)
+
+	return repo, nil
+}
+
+// SuppressionRepo type Implement ISuppressionRepo
+type SuppressionRepo struct {
+	BaseRepo
+}
+
+// List list suppressed addresses (latest first, with cursor pagination)
+func (r *SuppressionRepo) List(req *domSchema.SPListRequest, i identity.Identity) (*domSchema.SPListResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := req.GetCursor()
+	if err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusBadRequest, Err: err}
+	}
+	limit := req.GetLimit()
+
+	query := dbCon.Model(&domEntity.EmailSuppressionEntity{}).Order("id DESC").Limit(limit + 1)
+	if cursor > 0 {
+		query = query.Where("id < ?", cursor)
+	}
+	if req.Email != "" {
+		query = query.Where("email LIKE ?", fmt.Sprintf("%%%s%%", domSchema.NormalizeEmail(req.Email)))
+	}
+	if req.Reason != "" {
+		query = query.Where("reason = ?", req.Reason)
+	}
+
+	var spEtts []domEntity.EmailSuppressionEntity
+	if err := query.Find(&spEtts).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.SPListResponse)
+	resp.Query = *req
+	if len(spEtts) > limit {
+		spEtts = spEtts[:limit]
+		resp.Data.NextCursor = domSchema.EncodeCursor(spEtts[limit-1].ID)
+	}
+	resp.Data.Count = int64(len(spEtts))
+	resp.Data.Suppressions = []*domSchema.Suppression{}
+	for _, v := range spEtts {
+		sp := r.toSuppression(&v)
+		resp.Data.Suppressions = append(resp.Data.Suppressions, &sp)
+	}
+
+	return resp, nil
+}
+
+// Add add address to the suppression list
+func (r *SuppressionRepo) Add(req *domSchema.SPAddRequest, i identity.Identity) (*domSchema.SPAddResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	spEtt := domEntity.EmailSuppressionEntity{
+		UUID:   utils.GenerateUUID(),
+		Email:  domSchema.NormalizeEmail(req.Email),
+		Reason: string(req.GetReason()),
+		Source: i.Claims.Username,
+		Note:   req.Note,
+	}
+	spEtt.CreatedBy = fmt.Sprintf("%s@%s", i.Claims.Username, i.ClientDevices.IPAddress)
+
+	if err := dbCon.Create(&spEtt).Error; err != nil {
+		if strings.Index(err.Error(), "Error 1062: Duplicate entry") > -1 {
+			return nil, &sysError.SystemError{StatusCode: http.StatusConflict, Err: fmt.Errorf("Address `%s` is already suppressed", spEtt.Email)}
+		}
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.SPAddResponse)
+	resp.Query = *req
+	resp.Data = r.toSuppression(&spEtt)
+
+	return resp, nil
+}
+
+// Remove remove address from the suppression list (sending to the address is allowed again)
+func (r *SuppressionRepo) Remove(req *domSchema.SPRemoveRequest, i identity.Identity) (*domSchema.SPRemoveResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	spEtt, err := r.findSuppression(dbCon, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if spEtt == nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusNotFound, Err: fmt.Errorf("Address `%s` is not suppressed", req.Email)}
+	}
+
+	// hard delete: the address can be suppressed again
+	if err := dbCon.Unscoped().Delete(spEtt).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.SPRemoveResponse)
+	resp.Query = *req
+	resp.Data = r.toSuppression(spEtt)
+
+	return resp, nil
+}
+
+// Check check whether an address is suppressed
+func (r *SuppressionRepo) Check(req *domSchema.SPCheckRequest, i identity.Identity) (*domSchema.SPCheckResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	spEtt, err := r.findSuppression(dbCon, req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	// response
+	resp := new(domSchema.SPCheckResponse)
+	resp.Query = *req
+	resp.Data.Email = domSchema.NormalizeEmail(req.Email)
+	if spEtt != nil {
+		sp := r.toSuppression(spEtt)
+		resp.Data.Suppressed = true
+		resp.Data.Suppression = &sp
+	}
+
+	return resp, nil
+}
+
+// FindSuppressed find suppressed addresses, mapped by normalized email address
+func (r *SuppressionRepo) FindSuppressed(emails []string) (map[string]*domSchema.Suppression, error) {
+	suppressed := map[string]*domSchema.Suppression{}
+	if len(emails) == 0 {
+		return suppressed, nil
+	}
+
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := make([]string, 0, len(emails))
+	for _, v := range emails {
+		normalized = append(normalized, domSchema.NormalizeEmail(v))
+	}
+
+	var spEtts []domEntity.EmailSuppressionEntity
+	if err := dbCon.Where("email IN ?", normalized).Find(&spEtts).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	for _, v := range spEtts {
+		sp := r.toSuppression(&v)
+		suppressed[v.Email] = &sp
+	}
+
+	return suppressed, nil
+}
+
+// findSuppression find suppression of an address, nil when not suppressed
+func (r *SuppressionRepo) findSuppression(dbCon *gorm.DB, email string) (*domEntity.EmailSuppressionEntity, error) {
+	spEtt := new(domEntity.EmailSuppressionEntity)
+	res := dbCon.Where("email = ?", domSchema.NormalizeEmail(email)).Limit(1).Find(spEtt)
+	if res.Error != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: res.Error}
+	}
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+	return spEtt, nil
+}
+
+func (r *SuppressionRepo) toSuppression(v *domEntity.EmailSuppressionEntity) domSchema.Suppression {
+	return domSchema.Suppression{
+		ID:        v.UUID,
+		Email:     v.Email,
+		Reason:    domSchema.Reason(v.Reason),
+		Source:    v.Source,
+		Note:      v.Note,
+		CreatedBy: v.CreatedBy,
+		CreatedAt: v.CreatedAt,
+	}
+}
+
+// addSuppression add address to the suppression list (within a transaction), if not suppressed yet,
+// returns whether the address was added
+func addSuppression(tx *gorm.DB, email string, reason domSchema.Reason, source, createdBy string) (bool, error) {
+	email = domSchema.NormalizeEmail(email)
+
+	var count int64
+	if err := tx.Model(&domEntity.EmailSuppressionEntity{}).Where("email = ?", email).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return false, nil
+	}
+
+	spEtt := domEntity.EmailSuppressionEntity{
+		UUID:   utils.GenerateUUID(),
+		Email:  email,
+		Reason: string(reason),
+		Source: source,
+	}
+	spEtt.CreatedBy = createdBy
+	if err := tx.Create(&spEtt).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
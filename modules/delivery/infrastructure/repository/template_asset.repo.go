@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	domEntityEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/entity"
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	domSchemaTA "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/template_asset"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+	"github.com/d3ta-go/system/system/utils"
+	"gorm.io/gorm"
+)
+
+// NewTemplateAssetRepo new TemplateAssetRepo
+func NewTemplateAssetRepo(h *handler.Handler) (domRepo.ITemplateAssetRepo, error) {
+
+	repo := new(TemplateAssetRepo)
+	repo.handler = h
+
+	cfg, err := h.GetDefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+	repo.SetDBConnectionName(cfg.Databases.EmailDB.ConnectionName)
+
+	return repo, nil
+}
+
+// TemplateAssetRepo type Implement ITemplateAssetRepo
+type TemplateAssetRepo struct {
+	BaseRepo
+}
+
+// Add add asset (inline image) to the current (default) version of Email Template
+func (r *TemplateAssetRepo) Add(req *domSchemaTA.TAAddRequest, i identity.Identity) (*domSchemaTA.TAAddResponse, error) {
+	// select db
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	tplVerEtt, err := r.findVersion(dbCon, req.TemplateCode, "")
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := req.DecodeContent()
+	if err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusBadRequest, Err: err}
+	}
+
+	assetEtt := domEntity.EmailTemplateAssetEntity{
+		UUID:              utils.GenerateUUID(),
+		TemplateVersionID: tplVerEtt.ID,
+		ContentID:         req.ContentID,
+		Filename:          req.Filename,
+		ContentType:       req.ContentType,
+		Size:              int64(len(content)),
+		Content:           content,
+	}
+	assetEtt.CreatedBy = fmt.Sprintf("%s@%s", i.Claims.Username, i.ClientDevices.IPAddress)
+
+	if err := dbCon.Create(&assetEtt).Error; err != nil {
+		if strings.Index(err.Error(), "Error 1062: Duplicate entry") > -1 {
+			return nil, &sysError.SystemError{StatusCode: http.StatusConflict, Err: fmt.Errorf("Asset `%s` already exist on template version %s", req.ContentID, tplVerEtt.Version)}
+		}
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchemaTA.TAAddResponse)
+	resp.TemplateCode = req.TemplateCode
+	resp.Version = tplVerEtt.Version
+	resp.Asset = r.toTemplateAsset(&assetEtt)
+
+	return resp, nil
+}
+
+// List list assets of Email Template version (default: current version)
+func (r *TemplateAssetRepo) List(req *domSchemaTA.TAListRequest, i identity.Identity) (*domSchemaTA.TAListResponse, error) {
+	// select db
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	tplVerEtt, err := r.findVersion(dbCon, req.TemplateCode, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	var assetEtts []domEntity.EmailTemplateAssetEntity
+	if err := dbCon.Omit("content").Where("template_version_id = ?", tplVerEtt.ID).Order("content_id").Find(&assetEtts).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchemaTA.TAListResponse)
+	resp.Query = *req
+	resp.Data.TemplateCode = req.TemplateCode
+	resp.Data.Version = tplVerEtt.Version
+	resp.Data.Count = int64(len(assetEtts))
+	resp.Data.Assets = []*domSchemaTA.TemplateAsset{}
+	for _, v := range assetEtts {
+		a := r.toTemplateAsset(&v)
+		resp.Data.Assets = append(resp.Data.Assets, &a)
+	}
+
+	return resp, nil
+}
+
+// Delete delete asset from the current (default) version of Email Template (older versions keep their assets)
+func (r *TemplateAssetRepo) Delete(req *domSchemaTA.TADeleteRequest, i identity.Identity) (*domSchemaTA.TADeleteResponse, error) {
+	// select db
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	tplVerEtt, err := r.findVersion(dbCon, req.TemplateCode, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var assetEtt domEntity.EmailTemplateAssetEntity
+	var count int64
+	if err := dbCon.Omit("content").Where("template_version_id = ? AND content_id = ?", tplVerEtt.ID, req.ContentID).Find(&assetEtt).Count(&count).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	if count == 0 {
+		return nil, &sysError.SystemError{StatusCode: http.StatusNotFound, Err: fmt.Errorf("Invalid Email Template Asset")}
+	}
+
+	// hard delete, so the same content id can be added again
+	if err := dbCon.Unscoped().Delete(&assetEtt).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchemaTA.TADeleteResponse)
+	resp.Query = *req
+	resp.Data = r.toTemplateAsset(&assetEtt)
+
+	return resp, nil
+}
+
+// CarryOver copy assets of the previous Email Template version into the current (default) version,
+// (new template version is created on template update)
+func (r *TemplateAssetRepo) CarryOver(req *domSchemaTA.TACarryOverRequest, i identity.Identity) (*domSchemaTA.TAListResponse, error) {
+	// select db
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	tplVerEtt, err := r.findVersion(dbCon, req.TemplateCode, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if err := dbCon.Model(&domEntity.EmailTemplateAssetEntity{}).Where("template_version_id = ?", tplVerEtt.ID).Count(&count).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	if count == 0 {
+		// immediately previous version (of the same template): its assets only,
+		// assets deleted from the previous version are not brought back from older versions
+		var prevVerEtt domEntityEmail.EmailTemplateVersionEntity
+		result := dbCon.Where("email_template_id = ? AND id < ?", tplVerEtt.EmailTemplateID, tplVerEtt.ID).
+			Order("id DESC").Limit(1).Find(&prevVerEtt)
+		if result.Error != nil {
+			return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: result.Error}
+		}
+
+		var prevAssetEtts []domEntity.EmailTemplateAssetEntity
+		if result.RowsAffected > 0 {
+			if err := dbCon.Where("template_version_id = ?", prevVerEtt.ID).Find(&prevAssetEtts).Error; err != nil {
+				return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+			}
+		}
+
+		if len(prevAssetEtts) > 0 {
+			err := dbCon.Transaction(func(tx *gorm.DB) error {
+				for _, v := range prevAssetEtts {
+					newEtt := domEntity.EmailTemplateAssetEntity{
+						UUID:              utils.GenerateUUID(),
+						TemplateVersionID: tplVerEtt.ID,
+						ContentID:         v.ContentID,
+						Filename:          v.Filename,
+						ContentType:       v.ContentType,
+						Size:              v.Size,
+						Content:           v.Content,
+					}
+					newEtt.CreatedBy = fmt.Sprintf("%s@%s", i.Claims.Username, i.ClientDevices.IPAddress)
+					if err := tx.Create(&newEtt).Error; err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+			}
+		}
+	}
+
+	return r.List(&domSchemaTA.TAListRequest{TemplateCode: req.TemplateCode, Version: tplVerEtt.Version}, i)
+}
+
+// findVersion find Email Template version by template code and version (empty: current/default version)
+func (r *TemplateAssetRepo) findVersion(dbCon *gorm.DB, code, version string) (*domEntityEmail.EmailTemplateVersionEntity, error) {
+	var emailTplEtt domEntityEmail.EmailTemplateEntity
+	var emailTplVersionEtt domEntityEmail.EmailTemplateVersionEntity
+	var count int64
+
+	q := dbCon.Where("et.code = ?", code)
+	if version == "" {
+		q = q.Joins(fmt.Sprintf(`JOIN %s et ON et.default_version_id = %s.id`, emailTplEtt.TableName(), emailTplVersionEtt.TableName()))
+	} else {
+		q = q.Where(fmt.Sprintf("%s.version = ?", emailTplVersionEtt.TableName()), version).
+			Joins(fmt.Sprintf(`JOIN %s et ON et.id = %s.email_template_id`, emailTplEtt.TableName(), emailTplVersionEtt.TableName()))
+	}
+	if err := q.Find(&emailTplVersionEtt).Count(&count).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	if count == 0 {
+		return nil, &sysError.SystemError{StatusCode: http.StatusNotFound, Err: fmt.Errorf("Invalid Email Template Code or Version")}
+	}
+
+	return &emailTplVersionEtt, nil
+}
+
+func (r *TemplateAssetRepo) toTemplateAsset(ett *domEntity.EmailTemplateAssetEntity) domSchemaTA.TemplateAsset {
+	return domSchemaTA.TemplateAsset{
+		UUID:        ett.UUID,
+		ContentID:   ett.ContentID,
+		Filename:    ett.Filename,
+		ContentType: ett.ContentType,
+		Size:        ett.Size,
+	}
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	domEntityEmail "github.com/d3ta-go/ddd-mod-email/modules/email/domain/entity"
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/template_setting"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+	"gorm.io/gorm"
+)
+
+// NewTemplateSettingRepo new TemplateSettingRepo
+func NewTemplateSettingRepo(h *handler.Handler) (domRepo.ITemplateSettingRepo, error) {
+
+	repo := new(TemplateSettingRepo)
+	repo.handler = h
+
+	cfg, err := h.GetDefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+	repo.SetDBConnectionName(cfg.Databases.EmailDB.ConnectionName)
+
+	return repo, nil
+}
+
+// TemplateSettingRepo type Implement ITemplateSettingRepo
+type TemplateSettingRepo struct {
+	BaseRepo
+}
+
+// Find find delivery settings of Email Template (default settings when not set)
+func (r *TemplateSettingRepo) Find(req *domSchema.TSFindRequest, i identity.Identity) (*domSchema.TSFindResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	etEtt, err := r.findTemplate(dbCon, req.TemplateCode)
+	if err != nil {
+		return nil, err
+	}
+	ts, err := r.findByCode(dbCon, req.TemplateCode)
+	if err != nil {
+		return nil, err
+	}
+	ts.TextBodyOutdated = ts.IsTextBodyOutdated(etEtt.DefaultVersionID)
+
+	// response
+	resp := new(domSchema.TSFindResponse)
+	resp.Query = *req
+	resp.Data = *ts
+
+	return resp, nil
+}
+
+// Update set delivery settings of Email Template
+func (r *TemplateSettingRepo) Update(req *domSchema.TSUpdateRequest, i identity.Identity) (*domSchema.TSUpdateResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	etEtt, err := r.findTemplate(dbCon, req.TemplateCode)
+	if err != nil {
+		return nil, err
+	}
+
+	var tsEtt domEntity.EmailTemplateSettingEntity
+	res := dbCon.Where("template_code = ?", req.TemplateCode).Limit(1).Find(&tsEtt)
+	if res.Error != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: res.Error}
+	}
+
+	now := time.Now()
+	userIP := fmt.Sprintf("%s@%s", i.Claims.Username, i.ClientDevices.IPAddress)
+	tsEtt.TemplateCode = req.TemplateCode
+	if req.Category != "" {
+		tsEtt.Category = req.Category
+	}
+	if tsEtt.Category == "" {
+		tsEtt.Category = string(domSchema.TransactionalCategory)
+	}
+	if req.TrackOpens != nil {
+		tsEtt.TrackOpens = *req.TrackOpens
+	}
+	if req.TrackClicks != nil {
+		tsEtt.TrackClicks = *req.TrackClicks
+	}
+	if req.TextBodyTpl != nil {
+		// set for the current (default) template version, a new version generates the text body from the HTML body again
+		tsEtt.TextBodyTpl = *req.TextBodyTpl
+		tsEtt.TextBodyVersionID = etEtt.DefaultVersionID
+	}
+	if req.InlineCSS != nil {
+		tsEtt.InlineCSS = *req.InlineCSS
+	}
+	tsEtt.UpdatedBy = userIP
+	tsEtt.UpdatedAt = &now
+	if res.RowsAffected == 0 {
+		tsEtt.CreatedBy = userIP
+		err = dbCon.Create(&tsEtt).Error
+	} else {
+		err = dbCon.Save(&tsEtt).Error
+	}
+	if err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.TSUpdateResponse)
+	resp.Query = *req
+	resp.Data = *r.toTemplateSetting(&tsEtt)
+	resp.Data.TextBodyOutdated = resp.Data.IsTextBodyOutdated(etEtt.DefaultVersionID)
+
+	return resp, nil
+}
+
+// FindByCode find delivery settings of Email Template (default settings when not set)
+func (r *TemplateSettingRepo) FindByCode(templateCode string) (*domSchema.TemplateSetting, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+	return r.findByCode(dbCon, templateCode)
+}
+
+func (r *TemplateSettingRepo) findByCode(dbCon *gorm.DB, templateCode string) (*domSchema.TemplateSetting, error) {
+	var tsEtt domEntity.EmailTemplateSettingEntity
+	res := dbCon.Where("template_code = ?", templateCode).Limit(1).Find(&tsEtt)
+	if res.Error != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: res.Error}
+	}
+	if res.RowsAffected == 0 {
+		return domSchema.NewTemplateSetting(templateCode), nil
+	}
+	return r.toTemplateSetting(&tsEtt), nil
+}
+
+// findTemplate find Email Template (must exist)
+func (r *TemplateSettingRepo) findTemplate(dbCon *gorm.DB, templateCode string) (*domEntityEmail.EmailTemplateEntity, error) {
+	etEtt := new(domEntityEmail.EmailTemplateEntity)
+	res := dbCon.Where("code = ?", templateCode).Limit(1).Find(etEtt)
+	if res.Error != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: res.Error}
+	}
+	if res.RowsAffected == 0 {
+		return nil, &sysError.SystemError{StatusCode: http.StatusNotFound, Err: fmt.Errorf("Invalid Email Template Code")}
+	}
+	return etEtt, nil
+}
+
+func (r *TemplateSettingRepo) toTemplateSetting(v *domEntity.EmailTemplateSettingEntity) *domSchema.TemplateSetting {
+	return &domSchema.TemplateSetting{
+		TemplateCode:      v.TemplateCode,
+		Category:          domSchema.Category(v.Category),
+		TrackOpens:        v.TrackOpens,
+		TrackClicks:       v.TrackClicks,
+		TextBodyTpl:       v.TextBodyTpl,
+		TextBodyVersionID: v.TextBodyVersionID,
+		InlineCSS:         v.InlineCSS,
+		UpdatedBy:         v.UpdatedBy,
+		UpdatedAt:         v.UpdatedAt,
+	}
+}
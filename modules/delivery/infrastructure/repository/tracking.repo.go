@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/tracking"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+	"gorm.io/gorm"
+)
+
+// NewTrackingRepo new TrackingRepo
+func NewTrackingRepo(h *handler.Handler) (domRepo.ITrackingRepo, error) {
+
+	repo := new(TrackingRepo)
+	repo.handler = h
+
+	cfg, err := h.GetDefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+	repo.SetDBConnectionName(cfg.Databases.EmailDB.ConnectionName)
+
+	return repo, nil
+}
+
+// TrackingRepo type Implement ITrackingRepo
+type TrackingRepo struct {
+	BaseRepo
+}
+
+// RecordOpen record an open (tracking pixel) of a message: first open time and open count
+func (r *TrackingRepo) RecordOpen(token *domSchema.OpenToken, i identity.Identity) (*domSchema.RecordOpenResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	// counters only, the message record itself is not updated (sys_updated_*)
+	res := dbCon.Model(&domEntity.EmailMessageEntity{}).Where("uuid = ?", token.MessageID).
+		UpdateColumns(map[string]interface{}{
+			"open_count":      gorm.Expr("open_count + 1"),
+			"first_opened_at": gorm.Expr("COALESCE(first_opened_at, ?)", time.Now()),
+		})
+	if res.Error != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: res.Error}
+	}
+	if res.RowsAffected == 0 {
+		return nil, &sysError.SystemError{StatusCode: http.StatusNotFound, Err: fmt.Errorf("Invalid or expired link")}
+	}
+
+	msgEtt := new(domEntity.EmailMessageEntity)
+	if err := dbCon.Select("uuid", "first_opened_at", "open_count").Where("uuid = ?", token.MessageID).Limit(1).Find(msgEtt).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.RecordOpenResponse)
+	resp.MessageID = msgEtt.UUID
+	resp.FirstOpenedAt = msgEtt.FirstOpenedAt
+	resp.OpenCount = msgEtt.OpenCount
+
+	return resp, nil
+}
+
+// RecordClick record a click of a tracked link of a message
+func (r *TrackingRepo) RecordClick(token *domSchema.ClickToken, i identity.Identity) (*domSchema.RecordClickResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	msgEtt, err := r.findMessage(dbCon, token.MessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	userAgent := i.ClientDevices.UserAgent
+	if len(userAgent) > 512 {
+		userAgent = userAgent[:512]
+	}
+	clickEtt := domEntity.EmailClickEntity{
+		MessageID:    msgEtt.ID,
+		TemplateCode: msgEtt.TemplateCode,
+		LinkIndex:    token.LinkIndex,
+		URL:          token.URL,
+		ClickedAt:    time.Now(),
+		IPAddress:    i.ClientDevices.IPAddress,
+		UserAgent:    userAgent,
+	}
+	clickEtt.CreatedBy = fmt.Sprintf("public@%s", i.ClientDevices.IPAddress)
+
+	if err := dbCon.Create(&clickEtt).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.RecordClickResponse)
+	resp.MessageID = msgEtt.UUID
+	resp.LinkIndex = clickEtt.LinkIndex
+	resp.URL = clickEtt.URL
+	resp.ClickedAt = clickEtt.ClickedAt
+
+	return resp, nil
+}
+
+// FindMessageClicks find click counts (per link) of a message
+func (r *TrackingRepo) FindMessageClicks(req *domSchema.MessageClicksRequest, i identity.Identity) (*domSchema.MessageClicksResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	msgEtt, err := r.findMessage(dbCon, req.MessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := r.clickStats(dbCon.Where("message_id = ?", msgEtt.ID))
+	if err != nil {
+		return nil, err
+	}
+	stats.MessageID = msgEtt.UUID
+	stats.TemplateCode = msgEtt.TemplateCode
+
+	// response
+	resp := new(domSchema.MessageClicksResponse)
+	resp.Query = *req
+	resp.Data = *stats
+
+	return resp, nil
+}
+
+// FindTemplateClicks find click counts (per link) of the messages of a template
+func (r *TrackingRepo) FindTemplateClicks(req *domSchema.TemplateClicksRequest, i identity.Identity) (*domSchema.TemplateClicksResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	query := dbCon.Where("template_code = ?", req.TemplateCode)
+	if since := req.GetSince(); !since.IsZero() {
+		query = query.Where("clicked_at >= ?", since)
+	}
+	if until := req.GetUntil(); !until.IsZero() {
+		query = query.Where("clicked_at < ?", until)
+	}
+
+	stats, err := r.clickStats(query)
+	if err != nil {
+		return nil, err
+	}
+	stats.TemplateCode = req.TemplateCode
+
+	// response
+	resp := new(domSchema.TemplateClicksResponse)
+	resp.Query = *req
+	resp.Data = *stats
+
+	return resp, nil
+}
+
+// clickStats count clicks (total, clicked messages and per link) matching the query conditions
+func (r *TrackingRepo) clickStats(query *gorm.DB) (*domSchema.ClickStats, error) {
+	stats := new(domSchema.ClickStats)
+
+	var totals struct {
+		TotalClicks     int64
+		ClickedMessages int64
+	}
+	if err := query.Session(&gorm.Session{WithConditions: true}).Model(&domEntity.EmailClickEntity{}).
+		Select("COUNT(*) AS total_clicks, COUNT(DISTINCT message_id) AS clicked_messages").
+		Scan(&totals).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	stats.TotalClicks = totals.TotalClicks
+	stats.ClickedMessages = totals.ClickedMessages
+
+	stats.Links = []*domSchema.LinkClicks{}
+	if err := query.Session(&gorm.Session{WithConditions: true}).Model(&domEntity.EmailClickEntity{}).
+		Select("link_index, url, COUNT(*) AS clicks, MAX(clicked_at) AS last_clicked_at").
+		Group("link_index, url").Order("link_index").
+		Scan(&stats.Links).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	return stats, nil
+}
+
+func (r *TrackingRepo) findMessage(dbCon *gorm.DB, id string) (*domEntity.EmailMessageEntity, error) {
+	msgEtt := new(domEntity.EmailMessageEntity)
+	res := dbCon.Omit("body").Where("uuid = ?", id).Limit(1).Find(msgEtt)
+	if res.Error != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: res.Error}
+	}
+	if res.RowsAffected == 0 {
+		return nil, &sysError.SystemError{StatusCode: http.StatusNotFound, Err: fmt.Errorf("Message `%s` not found", id)}
+	}
+	return msgEtt, nil
+}
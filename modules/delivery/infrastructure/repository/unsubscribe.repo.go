@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	domSchemaSuppression "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/suppression"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/unsubscribe"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+	"github.com/d3ta-go/system/system/utils"
+	"gorm.io/gorm"
+)
+
+// NewUnsubscribeRepo new UnsubscribeRepo
+func NewUnsubscribeRepo(h *handler.Handler) (domRepo.IUnsubscribeRepo, error) {
+
+	repo := new(UnsubscribeRepo)
+	repo.handler = h
+
+	cfg, err := h.GetDefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+	repo.SetDBConnectionName(cfg.Databases.EmailDB.ConnectionName)
+
+	return repo, nil
+}
+
+// UnsubscribeRepo type Implement IUnsubscribeRepo
+type UnsubscribeRepo struct {
+	BaseRepo
+}
+
+// Find find unsubscribe (opt-out) status of the recipient of an unsubscribe link
+func (r *UnsubscribeRepo) Find(token *domSchema.Token, i identity.Identity) (*domSchema.UnsubscribeResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	msgEtt, err := r.findMessage(dbCon, token.MessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if err := dbCon.Model(&domEntity.EmailUnsubscribeEntity{}).Where("email = ?", domSchemaSuppression.NormalizeEmail(token.Email)).Count(&count).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := r.toResponse(token, msgEtt)
+	resp.Unsubscribed = count > 0
+
+	return resp, nil
+}
+
+// Unsubscribe record the opt-out of the recipient of an unsubscribe link (once per email address)
+func (r *UnsubscribeRepo) Unsubscribe(token *domSchema.Token, method domSchema.Method, i identity.Identity) (*domSchema.UnsubscribeResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	msgEtt, err := r.findMessage(dbCon, token.MessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	usEtt := domEntity.EmailUnsubscribeEntity{
+		UUID:         utils.GenerateUUID(),
+		Email:        domSchemaSuppression.NormalizeEmail(token.Email),
+		MessageID:    msgEtt.ID,
+		TemplateCode: msgEtt.TemplateCode,
+		Method:       string(method),
+	}
+	usEtt.CreatedBy = fmt.Sprintf("public@%s", i.ClientDevices.IPAddress)
+
+	var count int64
+	if err := dbCon.Model(&domEntity.EmailUnsubscribeEntity{}).Where("email = ?", usEtt.Email).Count(&count).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	if count == 0 {
+		// duplicate entry: a concurrent unsubscribe (e.g: one-click and the link) is already recorded
+		if err := dbCon.Create(&usEtt).Error; err != nil && strings.Index(err.Error(), "Error 1062: Duplicate entry") == -1 {
+			return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+		}
+	}
+
+	// response
+	resp := r.toResponse(token, msgEtt)
+	resp.Unsubscribed = true
+
+	return resp, nil
+}
+
+// FindUnsubscribed find unsubscribed addresses, by normalized email address
+func (r *UnsubscribeRepo) FindUnsubscribed(emails []string) (map[string]bool, error) {
+	unsubscribed := map[string]bool{}
+	if len(emails) == 0 {
+		return unsubscribed, nil
+	}
+
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := make([]string, 0, len(emails))
+	for _, v := range emails {
+		normalized = append(normalized, domSchemaSuppression.NormalizeEmail(v))
+	}
+
+	var found []string
+	if err := dbCon.Model(&domEntity.EmailUnsubscribeEntity{}).Where("email IN ?", normalized).Pluck("email", &found).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	for _, v := range found {
+		unsubscribed[v] = true
+	}
+
+	return unsubscribed, nil
+}
+
+func (r *UnsubscribeRepo) findMessage(dbCon *gorm.DB, id string) (*domEntity.EmailMessageEntity, error) {
+	msgEtt := new(domEntity.EmailMessageEntity)
+	res := dbCon.Omit("body").Where("uuid = ?", id).Limit(1).Find(msgEtt)
+	if res.Error != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: res.Error}
+	}
+	if res.RowsAffected == 0 {
+		return nil, &sysError.SystemError{StatusCode: http.StatusNotFound, Err: fmt.Errorf("Invalid or expired link")}
+	}
+	return msgEtt, nil
+}
+
+func (r *UnsubscribeRepo) toResponse(token *domSchema.Token, msgEtt *domEntity.EmailMessageEntity) *domSchema.UnsubscribeResponse {
+	return &domSchema.UnsubscribeResponse{
+		Email:        domSchemaSuppression.NormalizeEmail(token.Email),
+		MessageID:    msgEtt.UUID,
+		TemplateCode: msgEtt.TemplateCode,
+	}
+}
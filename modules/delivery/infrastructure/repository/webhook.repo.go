@@ -0,0 +1,471 @@
+package repository
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	domEntity "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/entity"
+	domRepo "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/repository"
+	domSchemaMessage "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/webhook"
+	sysError "github.com/d3ta-go/system/system/error"
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/d3ta-go/system/system/identity"
+	"github.com/d3ta-go/system/system/utils"
+	"gorm.io/gorm"
+)
+
+const defaultWebhookDeliveryLimit = 50
+
+// NewWebhookRepo new WebhookRepo
+func NewWebhookRepo(h *handler.Handler) (domRepo.IWebhookRepo, error) {
+
+	repo := new(WebhookRepo)
+	repo.handler = h
+
+	cfg, err := h.GetDefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+	repo.SetDBConnectionName(cfg.Databases.EmailDB.ConnectionName)
+
+	return repo, nil
+}
+
+// WebhookRepo type Implement IWebhookRepo
+type WebhookRepo struct {
+	BaseRepo
+}
+
+// Create register webhook endpoint, with a new signing secret
+func (r *WebhookRepo) Create(req *domSchema.WHCreateRequest, i identity.Identity) (*domSchema.WHCreateResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := r.generateSecret()
+	if err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	whEtt := domEntity.EmailWebhookEntity{
+		UUID:    utils.GenerateUUID(),
+		Name:    req.Name,
+		URL:     req.URL,
+		Events:  strings.Join(req.Events, ","),
+		Secret:  secret,
+		OwnedBy: i.Claims.Username,
+	}
+	whEtt.CreatedBy = fmt.Sprintf("%s@%s", i.Claims.Username, i.ClientDevices.IPAddress)
+
+	if err := dbCon.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&whEtt).Error; err != nil {
+			return err
+		}
+		// subscriptions, matched exactly when an event is published
+		subscribed := make(map[string]bool)
+		for _, e := range req.Events {
+			if subscribed[e] {
+				continue
+			}
+			subscribed[e] = true
+			if err := tx.Create(&domEntity.EmailWebhookEventEntity{WebhookID: whEtt.ID, Event: e}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.WHCreateResponse)
+	resp.Webhook = r.toWebhook(&whEtt)
+	resp.Secret = whEtt.Secret
+
+	return resp, nil
+}
+
+// List list webhook endpoints
+func (r *WebhookRepo) List(req *domSchema.WHListRequest, i identity.Identity) (*domSchema.WHListResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	query := dbCon
+	if req.Event != "" {
+		query = subscribedWebhooks(dbCon, domSchema.EventType(req.Event))
+	}
+	var whEtts []domEntity.EmailWebhookEntity
+	if err := query.Order(fmt.Sprintf("%s.id", (&domEntity.EmailWebhookEntity{}).TableName())).Find(&whEtts).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.WHListResponse)
+	resp.Query = *req
+	resp.Data.Webhooks = []*domSchema.Webhook{}
+	for _, v := range whEtts {
+		wh := r.toWebhook(&v)
+		resp.Data.Webhooks = append(resp.Data.Webhooks, &wh)
+	}
+	resp.Data.Count = int64(len(resp.Data.Webhooks))
+
+	return resp, nil
+}
+
+// Delete delete webhook endpoint (the deliveries are kept)
+func (r *WebhookRepo) Delete(req *domSchema.WHDeleteRequest, i identity.Identity) (*domSchema.WHDeleteResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	whEtt, err := r.findWebhook(dbCon, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	whEtt.DeletedBy = fmt.Sprintf("%s@%s", i.Claims.Username, i.ClientDevices.IPAddress)
+	if err := dbCon.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(whEtt).Select("sys_deleted_by").Updates(whEtt).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(whEtt).Error; err != nil {
+			return err
+		}
+		return tx.Where("webhook_id = ?", whEtt.ID).Delete(&domEntity.EmailWebhookEventEntity{}).Error
+	}); err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.WHDeleteResponse)
+	resp.Query = *req
+	resp.Data = r.toWebhook(whEtt)
+
+	return resp, nil
+}
+
+// ListDelivery list (latest) deliveries of a webhook endpoint
+func (r *WebhookRepo) ListDelivery(req *domSchema.WHListDeliveryRequest, i identity.Identity) (*domSchema.WHListDeliveryResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	whEtt, err := r.findWebhook(dbCon, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultWebhookDeliveryLimit
+	}
+	query := dbCon.Where("webhook_id = ?", whEtt.ID).Order("id DESC").Limit(limit)
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+	var dlvEtts []domEntity.EmailWebhookDeliveryEntity
+	if err := query.Find(&dlvEtts).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.WHListDeliveryResponse)
+	resp.Query = *req
+	resp.Data.Webhook = r.toWebhook(whEtt)
+	resp.Data.Count = int64(len(dlvEtts))
+	resp.Data.Deliveries = []*domSchema.Delivery{}
+	for _, v := range dlvEtts {
+		resp.Data.Deliveries = append(resp.Data.Deliveries, r.toDelivery(&v))
+	}
+
+	return resp, nil
+}
+
+// Replay deliver the event of a delivery again, as a new delivery
+func (r *WebhookRepo) Replay(req *domSchema.WHReplayRequest, i identity.Identity) (*domSchema.WHReplayResponse, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	whEtt, err := r.findWebhook(dbCon, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dlvEtt := new(domEntity.EmailWebhookDeliveryEntity)
+	res := dbCon.Where("uuid = ? AND webhook_id = ?", req.DeliveryID, whEtt.ID).Limit(1).Find(dlvEtt)
+	if res.Error != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: res.Error}
+	}
+	if res.RowsAffected == 0 {
+		return nil, &sysError.SystemError{StatusCode: http.StatusNotFound, Err: fmt.Errorf("Webhook delivery `%s` not found", req.DeliveryID)}
+	}
+
+	replayEtt := newWebhookDelivery(whEtt.ID, domSchema.EventType(dlvEtt.Event), dlvEtt.Payload, time.Now())
+	replayEtt.ReplayOf = dlvEtt.UUID
+	replayEtt.CreatedBy = fmt.Sprintf("%s@%s", i.Claims.Username, i.ClientDevices.IPAddress)
+	if err := dbCon.Create(replayEtt).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	// response
+	resp := new(domSchema.WHReplayResponse)
+	resp.Query = *req
+	resp.Data = *r.toDelivery(replayEtt)
+
+	return resp, nil
+}
+
+// Publish create deliveries of an event for all subscribed webhook endpoints (of all owners)
+func (r *WebhookRepo) Publish(event domSchema.EventType, data interface{}) error {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return err
+	}
+
+	if err := publishWebhookEvent(dbCon, event, "", data); err != nil {
+		return &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	return nil
+}
+
+// ClaimDue claim due webhook deliveries (and deliveries whose claim lease has expired, e.g: after a crash)
+func (r *WebhookRepo) ClaimDue(limit int, leaseTimeout time.Duration) ([]*domSchema.DueDelivery, error) {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var dlvEtts []domEntity.EmailWebhookDeliveryEntity
+	if err := dbCon.
+		Where("status = ? AND next_attempt_at <= ?", domEntity.WebhookPendingStatus, now).
+		Or("status = ? AND claimed_at < ?", domEntity.WebhookDeliveringStatus, now.Add(-leaseTimeout)).
+		Order("next_attempt_at").Limit(limit).
+		Find(&dlvEtts).Error; err != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+
+	var claimed []*domSchema.DueDelivery
+	for _, v := range dlvEtts {
+		// optimistic claim: only one dispatcher can move `attempts` forward
+		res := dbCon.Model(&domEntity.EmailWebhookDeliveryEntity{}).
+			Where("id = ? AND status = ? AND attempts = ?", v.ID, v.Status, v.Attempts).
+			Updates(map[string]interface{}{
+				"status":         domEntity.WebhookDeliveringStatus,
+				"attempts":       gorm.Expr("attempts + 1"),
+				"claimed_at":     now,
+				"sys_updated_by": "system.dispatcher",
+				"sys_updated_at": now,
+			})
+		if res.Error != nil {
+			return claimed, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: res.Error}
+		}
+		if res.RowsAffected == 0 {
+			continue
+		}
+
+		// the webhook may have been deleted since the event was published
+		whEtt := new(domEntity.EmailWebhookEntity)
+		found := dbCon.Where("id = ?", v.WebhookID).Limit(1).Find(whEtt)
+		if found.Error != nil {
+			return claimed, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: found.Error}
+		}
+		if found.RowsAffected == 0 {
+			if err := r.finish(dbCon, v.UUID, domEntity.WebhookFailedStatus, nil, fmt.Errorf("Webhook has been deleted")); err != nil {
+				return claimed, err
+			}
+			continue
+		}
+
+		claimed = append(claimed, &domSchema.DueDelivery{
+			ID:       v.UUID,
+			URL:      whEtt.URL,
+			Secret:   whEtt.Secret,
+			Event:    v.Event,
+			Payload:  []byte(v.Payload),
+			Attempts: v.Attempts + 1,
+		})
+	}
+
+	return claimed, nil
+}
+
+// Complete set status of a claimed webhook delivery: DELIVERED, PENDING (retry with backoff) or FAILED
+func (r *WebhookRepo) Complete(d *domSchema.DueDelivery, result *domSchema.DeliveryResult, sendErr error, policy domSchemaMessage.RetryPolicy) error {
+	dbCon, err := r.handler.GetGormDB(r.dbConnectionName)
+	if err != nil {
+		return err
+	}
+
+	if sendErr == nil {
+		return r.finish(dbCon, d.ID, domEntity.WebhookDeliveredStatus, result, nil)
+	}
+	if !policy.CanRetry(d.Attempts) {
+		return r.finish(dbCon, d.ID, domEntity.WebhookFailedStatus, result, sendErr)
+	}
+
+	// retry
+	now := time.Now()
+	values := map[string]interface{}{
+		"status":          domEntity.WebhookPendingStatus,
+		"next_attempt_at": now.Add(policy.Backoff(d.Attempts)),
+		"last_error":      sendErr.Error(),
+		"sys_updated_by":  "system.dispatcher",
+		"sys_updated_at":  now,
+	}
+	r.setResult(values, result)
+	if err := dbCon.Model(&domEntity.EmailWebhookDeliveryEntity{}).Where("uuid = ?", d.ID).Updates(values).Error; err != nil {
+		return &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	return nil
+}
+
+func (r *WebhookRepo) finish(dbCon *gorm.DB, id string, status domEntity.WebhookDeliveryStatus, result *domSchema.DeliveryResult, sendErr error) error {
+	now := time.Now()
+	values := map[string]interface{}{
+		"status":         status,
+		"sys_updated_by": "system.dispatcher",
+		"sys_updated_at": now,
+	}
+	if status == domEntity.WebhookDeliveredStatus {
+		values["delivered_at"] = now
+	}
+	if sendErr != nil {
+		values["last_error"] = sendErr.Error()
+	}
+	r.setResult(values, result)
+
+	if err := dbCon.Model(&domEntity.EmailWebhookDeliveryEntity{}).Where("uuid = ?", id).Updates(values).Error; err != nil {
+		return &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: err}
+	}
+	return nil
+}
+
+func (r *WebhookRepo) setResult(values map[string]interface{}, result *domSchema.DeliveryResult) {
+	if result == nil {
+		return
+	}
+	values["response_status"] = result.StatusCode
+}
+
+func (r *WebhookRepo) findWebhook(dbCon *gorm.DB, id string) (*domEntity.EmailWebhookEntity, error) {
+	whEtt := new(domEntity.EmailWebhookEntity)
+	res := dbCon.Where("uuid = ?", id).Limit(1).Find(whEtt)
+	if res.Error != nil {
+		return nil, &sysError.SystemError{StatusCode: http.StatusInternalServerError, Err: res.Error}
+	}
+	if res.RowsAffected == 0 {
+		return nil, &sysError.SystemError{StatusCode: http.StatusNotFound, Err: fmt.Errorf("Webhook `%s` not found", id)}
+	}
+	return whEtt, nil
+}
+
+func (r *WebhookRepo) generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+func (r *WebhookRepo) toWebhook(v *domEntity.EmailWebhookEntity) domSchema.Webhook {
+	return domSchema.Webhook{
+		ID:        v.UUID,
+		Name:      v.Name,
+		URL:       v.URL,
+		Events:    strings.Split(v.Events, ","),
+		OwnedBy:   v.OwnedBy,
+		CreatedAt: v.CreatedAt,
+	}
+}
+
+func (r *WebhookRepo) toDelivery(v *domEntity.EmailWebhookDeliveryEntity) *domSchema.Delivery {
+	d := &domSchema.Delivery{
+		ID:             v.UUID,
+		Event:          v.Event,
+		Payload:        json.RawMessage(v.Payload),
+		Status:         v.Status,
+		Attempts:       v.Attempts,
+		DeliveredAt:    v.DeliveredAt,
+		ResponseStatus: v.ResponseStatus,
+		LastError:      v.LastError,
+		ReplayOf:       v.ReplayOf,
+		CreatedAt:      v.CreatedAt,
+	}
+	if v.Status == string(domEntity.WebhookPendingStatus) {
+		nextAttemptAt := v.NextAttemptAt
+		d.NextAttemptAt = &nextAttemptAt
+	}
+	return d
+}
+
+// publishWebhookEvent create deliveries of an event for all subscribed webhook endpoints of the owner (username),
+// an empty owner publishes the event to all subscribed webhook endpoints (e.g: template.* events, templates are shared).
+// It is called inside the transaction of the change (e.g: message status), so an event is published if and only if the change is committed.
+func publishWebhookEvent(tx *gorm.DB, event domSchema.EventType, owner string, data interface{}) error {
+	query := subscribedWebhooks(tx, event)
+	if owner != "" {
+		query = query.Where(fmt.Sprintf("%s.owned_by = ?", (&domEntity.EmailWebhookEntity{}).TableName()), owner)
+	}
+	var whEtts []domEntity.EmailWebhookEntity
+	if err := query.Find(&whEtts).Error; err != nil {
+		return err
+	}
+
+	if len(whEtts) == 0 {
+		return nil
+	}
+
+	// one event (id) for all endpoints, each endpoint gets its own delivery
+	now := time.Now()
+	payload, err := json.Marshal(domSchema.Event{
+		ID:         utils.GenerateUUID(),
+		Type:       event,
+		OccurredAt: now,
+		Data:       data,
+	})
+	if err != nil {
+		return err
+	}
+	for _, v := range whEtts {
+		dlvEtt := newWebhookDelivery(v.ID, event, string(payload), now)
+		dlvEtt.CreatedBy = "system.webhook"
+		if err := tx.Create(dlvEtt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// subscribedWebhooks query webhook endpoints subscribed to the event (exact match)
+func subscribedWebhooks(dbCon *gorm.DB, event domSchema.EventType) *gorm.DB {
+	whTable := (&domEntity.EmailWebhookEntity{}).TableName()
+	whEvtTable := (&domEntity.EmailWebhookEventEntity{}).TableName()
+	return dbCon.Model(&domEntity.EmailWebhookEntity{}).
+		Joins(fmt.Sprintf("JOIN %s we ON we.webhook_id = %s.id", whEvtTable, whTable)).
+		Where("we.event = ?", string(event))
+}
+
+func newWebhookDelivery(webhookID uint64, event domSchema.EventType, payload string, now time.Time) *domEntity.EmailWebhookDeliveryEntity {
+	return &domEntity.EmailWebhookDeliveryEntity{
+		UUID:          utils.GenerateUUID(),
+		WebhookID:     webhookID,
+		Event:         string(event),
+		Payload:       payload,
+		Status:        string(domEntity.WebhookPendingStatus),
+		NextAttemptAt: now,
+	}
+}
@@ -0,0 +1,53 @@
+package blocklist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/message"
+)
+
+// LoadDomainsFile load domain blocklist file (e.g: disposable email domains), empty path: no blocklist
+func LoadDomainsFile(path string) (map[string]bool, error) {
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadDomains(f)
+}
+
+// ReadDomains read domain blocklist: one domain per line, empty lines and `#` comments are ignored,
+// the domains are normalized (lower case IDNA punycode)
+func ReadDomains(r io.Reader) (map[string]bool, error) {
+	domains := make(map[string]bool)
+
+	s := bufio.NewScanner(r)
+	line := 0
+	for s.Scan() {
+		line++
+		v := s.Text()
+		if idx := strings.Index(v, "#"); idx > -1 {
+			v = v[:idx]
+		}
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		domain, err := domSchema.NormalizeDomain(v)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid domain `%s` (line %d): %s", v, line, err.Error())
+		}
+		domains[domain] = true
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
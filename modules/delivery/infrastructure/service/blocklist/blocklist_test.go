@@ -0,0 +1,27 @@
+package blocklist
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadDomains(t *testing.T) {
+	domains, err := ReadDomains(strings.NewReader("# disposable domains\n\nMailinator.com\nyopmail.com # comment\nbücher.example\n"))
+	if assert.NoError(t, err) {
+		assert.Len(t, domains, 3)
+		assert.True(t, domains["mailinator.com"])
+		assert.True(t, domains["yopmail.com"])
+		assert.True(t, domains["xn--bcher-kva.example"])
+	}
+
+	_, err = ReadDomains(strings.NewReader("mailinator.com\nlocalhost\n"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "line 2")
+	}
+
+	domains, err = LoadDomainsFile("")
+	assert.NoError(t, err)
+	assert.Nil(t, domains)
+}
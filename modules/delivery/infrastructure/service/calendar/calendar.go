@@ -0,0 +1,135 @@
+package calendar
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ProdID product identifier of the generated iCalendar objects
+const ProdID = "-//D3TA Golang//ms-email-restapi//EN"
+
+const (
+	// RequestMethod iTIP method: invite to (or update) the event
+	RequestMethod = "REQUEST"
+	// CancelMethod iTIP method: cancel the event
+	CancelMethod = "CANCEL"
+)
+
+// utcLayout iCalendar UTC date-time
+const utcLayout = "20060102T150405Z"
+
+// maxLineOctets content lines longer than this are folded (RFC 5545 3.1)
+const maxLineOctets = 75
+
+// Address represent calendar user (organizer or attendee)
+type Address struct {
+	Email string
+	Name  string
+}
+
+// Event represent iCalendar (RFC 5545) event of an iTIP (RFC 5546) message, e.g: a meeting invite
+type Event struct {
+	UID         string
+	Method      string // REQUEST or CANCEL
+	Sequence    int
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	Stamp       time.Time // DTSTAMP, zero: now
+	Organizer   *Address
+	Attendees   []*Address
+}
+
+// Bytes render the event as iCalendar object (VCALENDAR with one VEVENT), the times are written in UTC
+func (e *Event) Bytes() []byte {
+	method := e.Method
+	if method == "" {
+		method = RequestMethod
+	}
+	stamp := e.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+	status := "CONFIRMED"
+	if method == CancelMethod {
+		status = "CANCELLED"
+	}
+
+	buf := new(bytes.Buffer)
+	line := func(name, value string) {
+		writeLine(buf, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("PRODID", ProdID)
+	line("VERSION", "2.0")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", method)
+	line("BEGIN", "VEVENT")
+	line("UID", escapeText(e.UID))
+	line("DTSTAMP", stamp.UTC().Format(utcLayout))
+	line("SEQUENCE", fmt.Sprintf("%d", e.Sequence))
+	line("DTSTART", e.Start.UTC().Format(utcLayout))
+	line("DTEND", e.End.UTC().Format(utcLayout))
+	line("SUMMARY", escapeText(e.Summary))
+	if e.Description != "" {
+		line("DESCRIPTION", escapeText(e.Description))
+	}
+	if e.Location != "" {
+		line("LOCATION", escapeText(e.Location))
+	}
+	if e.Organizer != nil {
+		line("ORGANIZER"+cnParam(e.Organizer.Name), "mailto:"+e.Organizer.Email)
+	}
+	for _, a := range e.Attendees {
+		line("ATTENDEE"+cnParam(a.Name)+";CUTYPE=INDIVIDUAL;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE", "mailto:"+a.Email)
+	}
+	line("STATUS", status)
+	line("TRANSP", "OPAQUE")
+	line("END", "VEVENT")
+	line("END", "VCALENDAR")
+
+	return buf.Bytes()
+}
+
+// escapeText escape TEXT property value (RFC 5545 3.3.11)
+func escapeText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+// cnParam common name parameter (quoted, DQUOTE is not allowed in parameter values)
+func cnParam(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '"' || r == '\r' || r == '\n' {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" {
+		return ""
+	}
+	return fmt.Sprintf(`;CN="%s"`, name)
+}
+
+// writeLine write content line, folded at 75 octets (without splitting UTF-8 characters)
+func writeLine(buf *bytes.Buffer, s string) {
+	max := maxLineOctets
+	for len(s) > max {
+		cut := max
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		buf.WriteString(s[:cut])
+		buf.WriteString("\r\n ")
+		s = s[cut:]
+		max = maxLineOctets - 1 // the leading space of the continuation line
+	}
+	buf.WriteString(s)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,52 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_Bytes(t *testing.T) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e := &Event{
+		UID:         "meeting-1001@domain.tld",
+		Summary:     "Sprint Review; Q4, 2026",
+		Description: "Agenda:\n1. Demo\n2. Retrospective",
+		Location:    "Room 1",
+		Start:       time.Date(2026, 10, 20, 10, 0, 0, 0, loc),
+		End:         time.Date(2026, 10, 20, 11, 0, 0, 0, loc),
+		Stamp:       time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC),
+		Organizer:   &Address{Email: "no-reply@domain.tld", Name: "D3TA Golang"},
+		Attendees: []*Address{
+			{Email: "john.doe@domain.tld", Name: "John \"JD\" Doe"},
+			{Email: "jane.doe@domain.tld"},
+		},
+	}
+
+	ics := string(e.Bytes())
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n"))
+	assert.Contains(t, ics, "\r\nMETHOD:REQUEST\r\n")
+	assert.Contains(t, ics, "\r\nDTSTART:20261020T030000Z\r\n")
+	assert.Contains(t, ics, "\r\nDTEND:20261020T040000Z\r\n")
+	assert.Contains(t, ics, "\r\nSUMMARY:Sprint Review\\; Q4\\, 2026\r\n")
+	assert.Contains(t, ics, "\r\nDESCRIPTION:Agenda:\\n1. Demo\\n2. Retrospective\r\n")
+	assert.Contains(t, ics, "\r\nORGANIZER;CN=\"D3TA Golang\":mailto:no-reply@domain.tld\r\n")
+	assert.Contains(t, ics, "\r\nATTENDEE;CN=\"John JD Doe\";CUTYPE=INDIVIDUAL;ROLE=REQ-PARTICIPANT;PARTSTAT=N\r\n EEDS-ACTION;RSVP=TRUE:mailto:john.doe@domain.tld\r\n")
+	assert.Contains(t, ics, "\r\nATTENDEE;CUTYPE=INDIVIDUAL;")
+	assert.Contains(t, ics, "\r\nSTATUS:CONFIRMED\r\n")
+	assert.True(t, strings.HasSuffix(ics, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+
+	e.Method = CancelMethod
+	ics = string(e.Bytes())
+	assert.Contains(t, ics, "\r\nMETHOD:CANCEL\r\n")
+	assert.Contains(t, ics, "\r\nSTATUS:CANCELLED\r\n")
+}
+
+func TestWriteLine_Fold(t *testing.T) {
+	e := &Event{Summary: strings.Repeat("é", 100)}
+	for _, l := range strings.Split(string(e.Bytes()), "\r\n") {
+		assert.True(t, len(l) <= maxLineOctets, l)
+	}
+}
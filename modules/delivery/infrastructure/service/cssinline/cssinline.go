@@ -0,0 +1,296 @@
+package cssinline
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// comments CSS comments
+var comments = regexp.MustCompile(`(?s)/\*.*?\*/`)
+
+// Inline inline the CSS rules of the <style> blocks of the HTML document into the style attributes of the matching elements.
+//
+// Supported selectors: type, universal, class and id selectors, compound (e.g: `td.total`), descendant and child combinators.
+// Rules which can not be inlined (at-rules, e.g: @media, and other selectors, e.g: `a:hover`) are kept in the <style> blocks,
+// a <style> block left empty is removed. Declarations of the style attributes win over the rules, except the `!important` ones.
+// Documents without <style> blocks are returned as is.
+func Inline(s string) (string, error) {
+	if !strings.Contains(strings.ToLower(s), "<style") {
+		return s, nil
+	}
+
+	doc, err := html.Parse(strings.NewReader(s))
+	if err != nil {
+		return "", err
+	}
+
+	var styles []*html.Node
+	walk(doc, func(n *html.Node) bool {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Style {
+			styles = append(styles, n)
+			return false
+		}
+		return true
+	})
+	if len(styles) == 0 {
+		return s, nil
+	}
+
+	var rules []*rule
+	for _, n := range styles {
+		var css strings.Builder
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.TextNode {
+				css.WriteString(c.Data)
+			}
+		}
+		inlined, kept := parseStylesheet(css.String(), len(rules))
+		rules = append(rules, inlined...)
+
+		for c := n.FirstChild; c != nil; {
+			next := c.NextSibling
+			n.RemoveChild(c)
+			c = next
+		}
+		if kept == "" {
+			n.Parent.RemoveChild(n)
+		} else {
+			n.AppendChild(&html.Node{Type: html.TextNode, Data: kept})
+		}
+	}
+
+	if len(rules) > 0 {
+		walk(doc, func(n *html.Node) bool {
+			if n.Type != html.ElementNode {
+				return true
+			}
+			switch n.DataAtom {
+			case atom.Head, atom.Script, atom.Style, atom.Template:
+				return false
+			}
+			applyRules(n, rules)
+			return true
+		})
+	}
+
+	buf := new(bytes.Buffer)
+	if err := html.Render(buf, doc); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// walk visit the nodes of the tree (depth-first), the children are skipped when fn returns false
+func walk(n *html.Node, fn func(*html.Node) bool) {
+	if !fn(n) {
+		return
+	}
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		walk(c, fn)
+		c = next
+	}
+}
+
+type declaration struct {
+	property  string
+	value     string
+	important bool
+}
+
+type rule struct {
+	selector     *selector
+	specificity  int
+	order        int
+	declarations []*declaration
+}
+
+// parseStylesheet parse the CSS rules of a stylesheet, returns the rules to inline and the (CSS) text of the other rules
+func parseStylesheet(css string, order int) ([]*rule, string) {
+	css = comments.ReplaceAllString(css, "")
+
+	var rules []*rule
+	var kept strings.Builder
+	for i := 0; i < len(css); {
+		j := indexTopLevel(css[i:], "{;")
+		if j < 0 {
+			break
+		}
+		prelude := strings.TrimSpace(css[i : i+j])
+		if css[i+j] == ';' {
+			// statement at-rule, e.g: @import
+			if prelude != "" {
+				kept.WriteString(prelude + ";\n")
+			}
+			i += j + 1
+			continue
+		}
+		end := matchingBrace(css, i+j)
+		block := css[i+j+1 : end]
+		i = end + 1
+
+		if strings.HasPrefix(prelude, "@") {
+			kept.WriteString(prelude + " {" + block + "}\n")
+			continue
+		}
+		decls := parseDeclarations(block)
+		if len(decls) == 0 {
+			continue
+		}
+		var unsupported []string
+		for _, sel := range strings.Split(prelude, ",") {
+			sel = strings.TrimSpace(sel)
+			s, ok := parseSelector(sel)
+			if !ok {
+				unsupported = append(unsupported, sel)
+				continue
+			}
+			order++
+			rules = append(rules, &rule{selector: s, specificity: s.specificity(), order: order, declarations: decls})
+		}
+		if len(unsupported) > 0 {
+			kept.WriteString(strings.Join(unsupported, ", ") + " {" + block + "}\n")
+		}
+	}
+	return rules, strings.TrimSpace(kept.String())
+}
+
+// indexTopLevel index of the first of chars, outside of quotes and parentheses
+func indexTopLevel(s, chars string) int {
+	var quote rune
+	depth := 0
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case depth == 0 && strings.ContainsRune(chars, r):
+			return i
+		}
+	}
+	return -1
+}
+
+// matchingBrace index of the brace closing the block opened at i (end of css when not closed)
+func matchingBrace(css string, i int) int {
+	depth := 0
+	for k := i; k < len(css); k++ {
+		switch css[k] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return k
+			}
+		}
+	}
+	return len(css)
+}
+
+// parseDeclarations parse declarations block (or style attribute), e.g: `color: red; margin: 0 !important`
+func parseDeclarations(block string) []*declaration {
+	var decls []*declaration
+	for len(block) > 0 {
+		j := indexTopLevel(block, ";")
+		if j < 0 {
+			j = len(block)
+		}
+		d := block[:j]
+		if j < len(block) {
+			j++
+		}
+		block = block[j:]
+
+		k := strings.Index(d, ":")
+		if k < 0 {
+			continue
+		}
+		decl := &declaration{
+			property: strings.ToLower(strings.TrimSpace(d[:k])),
+			value:    strings.TrimSpace(d[k+1:]),
+		}
+		if l := strings.LastIndex(decl.value, "!"); l >= 0 && strings.EqualFold(strings.TrimSpace(decl.value[l+1:]), "important") {
+			decl.value = strings.TrimSpace(decl.value[:l])
+			decl.important = true
+		}
+		if decl.property != "" && decl.value != "" {
+			decls = append(decls, decl)
+		}
+	}
+	return decls
+}
+
+// applyRules set style attribute of the element: declarations of the matching rules (by specificity and order),
+// the current style attribute, then the important declarations of the matching rules
+func applyRules(n *html.Node, rules []*rule) {
+	var matched []*rule
+	for _, r := range rules {
+		if r.selector.match(n) {
+			matched = append(matched, r)
+		}
+	}
+	if len(matched) == 0 {
+		return
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].specificity != matched[j].specificity {
+			return matched[i].specificity < matched[j].specificity
+		}
+		return matched[i].order < matched[j].order
+	})
+
+	var props []string
+	values := map[string]string{}
+	set := func(d *declaration) {
+		if _, ok := values[d.property]; !ok {
+			props = append(props, d.property)
+		}
+		values[d.property] = d.value
+	}
+	for _, r := range matched {
+		for _, d := range r.declarations {
+			if !d.important {
+				set(d)
+			}
+		}
+	}
+	attr := -1
+	for i, a := range n.Attr {
+		if a.Namespace == "" && a.Key == "style" {
+			attr = i
+			for _, d := range parseDeclarations(a.Val) {
+				set(d)
+			}
+		}
+	}
+	for _, r := range matched {
+		for _, d := range r.declarations {
+			if d.important {
+				set(d)
+			}
+		}
+	}
+
+	style := make([]string, len(props))
+	for i, p := range props {
+		style[i] = p + ": " + values[p]
+	}
+	if attr < 0 {
+		n.Attr = append(n.Attr, html.Attribute{Key: "style"})
+		attr = len(n.Attr) - 1
+	}
+	n.Attr[attr].Val = strings.Join(style, "; ")
+}
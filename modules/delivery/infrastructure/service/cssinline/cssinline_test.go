@@ -0,0 +1,64 @@
+package cssinline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInline(t *testing.T) {
+	s, err := Inline(`<!DOCTYPE html>
+<html><head><style>
+/* base */
+p { color: #333; margin: 0 }
+.note { color: red; }
+#footer p { font-size: 12px }
+td.total, th { font-weight: bold !important }
+table > tr > td { padding: 4px }
+a:hover { color: blue }
+@media (max-width: 600px) { p { margin: 4px } }
+</style></head>
+<body>
+<p class="note" style="margin: 2px">Hello</p>
+<table><tbody><tr><td class="total" style="font-weight: normal">10</td></tr></tbody></table>
+<div id="footer"><p>Bye</p></div>
+</body></html>`)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Contains(t, s, `<p class="note" style="color: red; margin: 2px">Hello</p>`)
+	assert.Contains(t, s, `<td class="total" style="font-weight: bold">10</td>`)
+	assert.Contains(t, s, `<p style="color: #333; margin: 0; font-size: 12px">Bye</p>`)
+	// kept rules: not supported selectors and at-rules
+	assert.Contains(t, s, "a:hover { color: blue }\n@media (max-width: 600px) { p { margin: 4px } }</style>")
+	assert.NotContains(t, s, ".note {")
+	assert.True(t, strings.HasPrefix(s, "<!DOCTYPE html>"))
+}
+
+func TestInline_RemoveStyle(t *testing.T) {
+	s, err := Inline(`<style>b { color: red }</style><b>Hi</b>`)
+	if assert.NoError(t, err) {
+		assert.Equal(t, `<html><head></head><body><b style="color: red">Hi</b></body></html>`, s)
+	}
+
+	// without <style> blocks: unchanged
+	s, err = Inline(`<p>Hi & bye</p>`)
+	if assert.NoError(t, err) {
+		assert.Equal(t, `<p>Hi & bye</p>`, s)
+	}
+}
+
+func TestParseSelector(t *testing.T) {
+	for sel, ok := range map[string]bool{
+		"p": true, "*": true, ".a.b": true, "div#id > p.x": true, "ul  li": true,
+		"a:hover": false, "p::before": false, "input[type=text]": false, "h1 + p": false, "> p": false, "p >": false, ".": false,
+	} {
+		_, got := parseSelector(sel)
+		assert.Equal(t, ok, got, sel)
+	}
+
+	s, _ := parseSelector("#footer p.x")
+	assert.Equal(t, 10101, s.specificity())
+}
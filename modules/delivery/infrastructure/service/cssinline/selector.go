@@ -0,0 +1,176 @@
+package cssinline
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// compound compound selector, e.g: `td.total#sum` (empty tag: any element)
+type compound struct {
+	tag     string
+	id      string
+	classes []string
+}
+
+// selector complex selector: compounds separated by combinators (' ': descendant, '>': child)
+type selector struct {
+	compounds   []*compound
+	combinators []byte // combinators[i] between compounds[i] and compounds[i+1]
+}
+
+// parseSelector parse (supported) selector, ok is false for the other selectors (e.g: pseudo-classes, attribute selectors)
+func parseSelector(s string) (*selector, bool) {
+	sel := new(selector)
+	var combinator byte
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			if combinator == 0 {
+				combinator = ' '
+			}
+			i++
+			continue
+		case c == '>':
+			combinator = '>'
+			i++
+			continue
+		}
+
+		if len(sel.compounds) > 0 {
+			if combinator == 0 {
+				return nil, false
+			}
+			sel.combinators = append(sel.combinators, combinator)
+		} else if combinator == '>' {
+			return nil, false
+		}
+		combinator = 0
+
+		comp, n := parseCompound(s[i:])
+		if comp == nil {
+			return nil, false
+		}
+		sel.compounds = append(sel.compounds, comp)
+		i += n
+	}
+	if len(sel.compounds) == 0 || combinator == '>' {
+		return nil, false
+	}
+	return sel, true
+}
+
+// parseCompound parse compound selector at the start of s, returns the compound and its length (nil: not supported)
+func parseCompound(s string) (*compound, int) {
+	comp := new(compound)
+	i := 0
+	if i < len(s) && s[i] == '*' {
+		i++
+	} else if n := identLen(s[i:]); n > 0 {
+		comp.tag = strings.ToLower(s[i : i+n])
+		i += n
+	}
+	for i < len(s) && (s[i] == '.' || s[i] == '#') {
+		n := identLen(s[i+1:])
+		if n == 0 {
+			return nil, 0
+		}
+		if s[i] == '.' {
+			comp.classes = append(comp.classes, s[i+1:i+1+n])
+		} else {
+			comp.id = s[i+1 : i+1+n]
+		}
+		i += n + 1
+	}
+	if i == 0 {
+		return nil, 0
+	}
+	if i < len(s) && !strings.ContainsRune(" \t\n\r\f>", rune(s[i])) {
+		return nil, 0
+	}
+	return comp, i
+}
+
+func identLen(s string) int {
+	n := 0
+	for n < len(s) {
+		c := s[n]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c >= 0x80) {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// specificity selector specificity: ids, then classes, then types
+func (s *selector) specificity() int {
+	spec := 0
+	for _, c := range s.compounds {
+		if c.id != "" {
+			spec += 10000
+		}
+		spec += 100 * len(c.classes)
+		if c.tag != "" {
+			spec++
+		}
+	}
+	return spec
+}
+
+func (s *selector) match(n *html.Node) bool {
+	return s.matchAt(n, len(s.compounds)-1)
+}
+
+func (s *selector) matchAt(n *html.Node, i int) bool {
+	if !s.compounds[i].match(n) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	for p := n.Parent; p != nil && p.Type == html.ElementNode; p = p.Parent {
+		if s.matchAt(p, i-1) {
+			return true
+		}
+		if s.combinators[i-1] == '>' {
+			return false
+		}
+	}
+	return false
+}
+
+func (c *compound) match(n *html.Node) bool {
+	if n.Type != html.ElementNode || (c.tag != "" && c.tag != n.Data) {
+		return false
+	}
+	if c.id == "" && len(c.classes) == 0 {
+		return true
+	}
+	var id string
+	var classes []string
+	for _, a := range n.Attr {
+		switch a.Key {
+		case "id":
+			id = a.Val
+		case "class":
+			classes = strings.Fields(a.Val)
+		}
+	}
+	if c.id != "" && c.id != id {
+		return false
+	}
+	for _, want := range c.classes {
+		found := false
+		for _, cl := range classes {
+			if cl == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
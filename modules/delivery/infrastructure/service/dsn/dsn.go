@@ -0,0 +1,194 @@
+package dsn
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+)
+
+// ReportType represent multipart/report report-type
+type ReportType string
+
+const (
+	// DeliveryStatusReport RFC 3464 delivery status notification (bounce)
+	DeliveryStatusReport ReportType = "delivery-status"
+	// FeedbackReport RFC 5965 abuse reporting format (complaint)
+	FeedbackReport ReportType = "feedback-report"
+)
+
+// ErrUnsupportedReport returned when the message is not a delivery status notification or feedback report
+var ErrUnsupportedReport = errors.New("Not a multipart/report delivery status notification or feedback report")
+
+// Report represent parsed delivery status notification or feedback report
+type Report struct {
+	Type ReportType
+	// OriginalMessageID Message-ID header of the original message
+	OriginalMessageID string
+	// FeedbackType feedback report only (e.g: abuse)
+	FeedbackType string
+	Recipients   []*Recipient
+}
+
+// Recipient represent per-recipient fields of the report
+type Recipient struct {
+	Email          string
+	Action         string // failed, delayed, delivered, relayed or expanded (delivery status only)
+	Status         string // e.g: 5.1.1
+	DiagnosticCode string
+}
+
+// IsPermanent check whether delivery to the recipient failed permanently (hard bounce)
+func (r *Recipient) IsPermanent() bool {
+	if r.Action != "failed" {
+		return false
+	}
+	return r.Status == "" || strings.HasPrefix(r.Status, "5")
+}
+
+// IsFailure check whether the recipient reports a (permanent or transient) delivery failure
+func (r *Recipient) IsFailure() bool {
+	return r.Action == "failed" || r.Action == "delayed"
+}
+
+// Parse parse raw (RFC 5322) multipart/report message
+func Parse(r io.Reader) (*Report, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/report" || params["boundary"] == "" {
+		return nil, ErrUnsupportedReport
+	}
+
+	report := &Report{Type: ReportType(strings.ToLower(params["report-type"]))}
+	if report.Type != DeliveryStatusReport && report.Type != FeedbackReport {
+		return nil, ErrUnsupportedReport
+	}
+
+	var originalTo string
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		ct, _, _ := mime.ParseMediaType(p.Header.Get("Content-Type"))
+		switch ct {
+		case "message/delivery-status", "message/global-delivery-status":
+			blocks, err := readBlocks(p)
+			if err != nil {
+				return nil, err
+			}
+			for _, b := range blocks {
+				if rcpt := toRecipient(b); rcpt != nil {
+					report.Recipients = append(report.Recipients, rcpt)
+				}
+			}
+		case "message/feedback-report":
+			blocks, err := readBlocks(p)
+			if err != nil {
+				return nil, err
+			}
+			for _, b := range blocks {
+				if v := b.Get("Feedback-Type"); v != "" {
+					report.FeedbackType = strings.ToLower(v)
+				}
+				for _, v := range b.Values("Original-Rcpt-To") {
+					report.Recipients = append(report.Recipients, &Recipient{Email: addressOf(v)})
+				}
+			}
+		case "message/rfc822", "text/rfc822-headers", "message/rfc822-headers", "message/global", "message/global-headers":
+			h, err := textproto.NewReader(bufio.NewReader(p)).ReadMIMEHeader()
+			if err != nil && err != io.EOF {
+				return nil, fmt.Errorf("Invalid original message headers: %s", err.Error())
+			}
+			report.OriginalMessageID = strings.TrimSpace(h.Get("Message-Id"))
+			originalTo = h.Get("To")
+		}
+	}
+
+	// feedback report without Original-Rcpt-To: the recipient of the original message
+	if report.Type == FeedbackReport && len(report.Recipients) == 0 && originalTo != "" {
+		report.Recipients = append(report.Recipients, &Recipient{Email: addressOf(originalTo)})
+	}
+
+	return report, nil
+}
+
+// readBlocks read header-like field blocks (separated by blank lines)
+func readBlocks(r io.Reader) ([]textproto.MIMEHeader, error) {
+	tp := textproto.NewReader(bufio.NewReader(r))
+
+	var blocks []textproto.MIMEHeader
+	for {
+		h, err := tp.ReadMIMEHeader()
+		if len(h) > 0 {
+			blocks = append(blocks, h)
+		}
+		if err == io.EOF {
+			return blocks, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Invalid report fields: %s", err.Error())
+		}
+	}
+}
+
+// toRecipient convert per-recipient fields block, nil when the block is not a per-recipient block
+func toRecipient(h textproto.MIMEHeader) *Recipient {
+	email := addressOf(h.Get("Final-Recipient"))
+	if email == "" {
+		email = addressOf(h.Get("Original-Recipient"))
+	}
+	if email == "" {
+		return nil
+	}
+	return &Recipient{
+		Email:          email,
+		Action:         strings.ToLower(strings.TrimSpace(h.Get("Action"))),
+		Status:         statusCode(h.Get("Status")),
+		DiagnosticCode: typedValue(h.Get("Diagnostic-Code")),
+	}
+}
+
+// addressOf get email address of a typed address field (e.g: `rfc822; john@domain.tld`) or an address header
+func addressOf(v string) string {
+	v = typedValue(v)
+	if v == "" {
+		return ""
+	}
+	if a, err := mail.ParseAddress(v); err == nil {
+		return strings.ToLower(a.Address)
+	}
+	return strings.ToLower(strings.Trim(v, "<> "))
+}
+
+// typedValue strip the type of a typed field value (e.g: `smtp; 550 5.1.1 ...`)
+func typedValue(v string) string {
+	v = strings.TrimSpace(v)
+	if i := strings.Index(v, ";"); i >= 0 {
+		v = strings.TrimSpace(v[i+1:])
+	}
+	return v
+}
+
+// statusCode get status code (e.g: `5.1.1`) without the comment
+func statusCode(v string) string {
+	fields := strings.Fields(v)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
@@ -0,0 +1,115 @@
+package dsn
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const hardBounce = "From: MAILER-DAEMON@mx.domain.tld (Mail Delivery System)\r\n" +
+	"To: no-reply@domain.tld\r\n" +
+	"Subject: Undelivered Mail Returned to Sender\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/report; report-type=delivery-status; boundary=\"B1\"\r\n" +
+	"\r\n" +
+	"--B1\r\n" +
+	"Content-Type: text/plain; charset=us-ascii\r\n" +
+	"\r\n" +
+	"I'm sorry to have to inform you that your message could not be delivered.\r\n" +
+	"\r\n" +
+	"--B1\r\n" +
+	"Content-Type: message/delivery-status\r\n" +
+	"\r\n" +
+	"Reporting-MTA: dns; mx.domain.tld\r\n" +
+	"Arrival-Date: Sun, 18 Oct 2026 08:00:00 +0700\r\n" +
+	"\r\n" +
+	"Final-Recipient: rfc822; John.Doe@example.com\r\n" +
+	"Original-Recipient: rfc822;john.doe@example.com\r\n" +
+	"Action: failed\r\n" +
+	"Status: 5.1.1\r\n" +
+	"Diagnostic-Code: smtp; 550 5.1.1 <john.doe@example.com>: Recipient address rejected: User unknown\r\n" +
+	"\r\n" +
+	"Final-Recipient: rfc822; jane.doe@example.com\r\n" +
+	"Action: delayed\r\n" +
+	"Status: 4.2.2 (mailbox full)\r\n" +
+	"\r\n" +
+	"--B1\r\n" +
+	"Content-Type: text/rfc822-headers\r\n" +
+	"\r\n" +
+	"From: D3TA Golang <no-reply@domain.tld>\r\n" +
+	"To: John Doe <john.doe@example.com>\r\n" +
+	"Subject: Activate your account\r\n" +
+	"Message-ID: <0b9e6f2a@domain.tld>\r\n" +
+	"\r\n" +
+	"--B1--\r\n"
+
+const complaint = "From: feedback@isp.example\r\n" +
+	"To: abuse@domain.tld\r\n" +
+	"Subject: FW: Activate your account\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/report; report-type=feedback-report; boundary=\"B2\"\r\n" +
+	"\r\n" +
+	"--B2\r\n" +
+	"Content-Type: text/plain\r\n" +
+	"\r\n" +
+	"This is an email abuse report.\r\n" +
+	"\r\n" +
+	"--B2\r\n" +
+	"Content-Type: message/feedback-report\r\n" +
+	"\r\n" +
+	"Feedback-Type: abuse\r\n" +
+	"User-Agent: SomeGenerator/1.0\r\n" +
+	"Version: 1\r\n" +
+	"\r\n" +
+	"--B2\r\n" +
+	"Content-Type: message/rfc822\r\n" +
+	"\r\n" +
+	"From: D3TA Golang <no-reply@domain.tld>\r\n" +
+	"To: Jane Doe <Jane.Doe@example.com>\r\n" +
+	"Subject: Activate your account\r\n" +
+	"Message-ID: <7c1d2e3f@domain.tld>\r\n" +
+	"\r\n" +
+	"Body\r\n" +
+	"--B2--\r\n"
+
+func TestParse_DeliveryStatus(t *testing.T) {
+	r, err := Parse(strings.NewReader(hardBounce))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, DeliveryStatusReport, r.Type)
+	assert.Equal(t, "<0b9e6f2a@domain.tld>", r.OriginalMessageID)
+	if assert.Len(t, r.Recipients, 2) {
+		assert.Equal(t, "john.doe@example.com", r.Recipients[0].Email)
+		assert.Equal(t, "failed", r.Recipients[0].Action)
+		assert.Equal(t, "5.1.1", r.Recipients[0].Status)
+		assert.Contains(t, r.Recipients[0].DiagnosticCode, "User unknown")
+		assert.True(t, r.Recipients[0].IsPermanent())
+
+		assert.Equal(t, "jane.doe@example.com", r.Recipients[1].Email)
+		assert.Equal(t, "4.2.2", r.Recipients[1].Status)
+		assert.False(t, r.Recipients[1].IsPermanent())
+		assert.True(t, r.Recipients[1].IsFailure())
+	}
+}
+
+func TestParse_FeedbackReport(t *testing.T) {
+	r, err := Parse(strings.NewReader(complaint))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, FeedbackReport, r.Type)
+	assert.Equal(t, "abuse", r.FeedbackType)
+	assert.Equal(t, "<7c1d2e3f@domain.tld>", r.OriginalMessageID)
+	if assert.Len(t, r.Recipients, 1) {
+		assert.Equal(t, "jane.doe@example.com", r.Recipients[0].Email)
+	}
+}
+
+func TestParse_Unsupported(t *testing.T) {
+	_, err := Parse(strings.NewReader("From: a@domain.tld\r\nContent-Type: text/plain\r\n\r\nHello\r\n"))
+	assert.Equal(t, ErrUnsupportedReport, err)
+}
@@ -0,0 +1,105 @@
+package links
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	appConfig "github.com/d3ta-go/ms-email-restapi/system/config"
+	"github.com/d3ta-go/system/system/handler"
+)
+
+const (
+	// UnsubscribePath public unsubscribe endpoint path (followed by the token)
+	UnsubscribePath = "/api/v1/public/email/unsubscribe/"
+	// OpenPath public open tracking pixel path (followed by the token)
+	OpenPath = "/api/v1/public/email/open/"
+	// ClickPath public click redirect path (followed by the token)
+	ClickPath = "/api/v1/public/email/click/"
+)
+
+var (
+	// ErrNoSigningKey the signing key is not configured (delivery.links.signingKey)
+	ErrNoSigningKey = errors.New("Signing key of links is not configured (delivery.links.signingKey)")
+	// ErrInvalidToken the token is malformed or its signature does not match
+	ErrInvalidToken = errors.New("Invalid or expired link")
+)
+
+// NewLinks new Links (using delivery links configuration)
+func NewLinks(h *handler.Handler) (*Links, error) {
+	cfg, err := appConfig.GetConfig(h)
+	if err != nil {
+		return nil, err
+	}
+	return New(cfg.Delivery.Links.GetBaseURL(), cfg.Delivery.Links.SigningKey), nil
+}
+
+// New new Links
+func New(baseURL, signingKey string) *Links {
+	return &Links{baseURL: strings.TrimRight(baseURL, "/"), key: []byte(signingKey)}
+}
+
+// Links type (signed public links in sent emails)
+type Links struct {
+	baseURL string
+	key     []byte
+}
+
+// URL build signed link: <baseURL><path><token>
+func (l *Links) URL(path string, payload interface{}) (string, error) {
+	token, err := l.Sign(payload)
+	if err != nil {
+		return "", err
+	}
+	return l.baseURL + path + token, nil
+}
+
+// IsLink check whether the url is a link of this service (e.g: the unsubscribe link)
+func (l *Links) IsLink(url string) bool {
+	return strings.HasPrefix(url, l.baseURL+"/")
+}
+
+// Sign encode payload as signed token: `<base64url(json payload)>.<base64url(HMAC-SHA256 of the encoded payload)>`
+func (l *Links) Sign(payload interface{}) (string, error) {
+	if len(l.key) == 0 {
+		return "", ErrNoSigningKey
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	data := base64.RawURLEncoding.EncodeToString(b)
+	return data + "." + base64.RawURLEncoding.EncodeToString(l.mac(data)), nil
+}
+
+// Verify verify signed token and decode its payload
+func (l *Links) Verify(token string, payload interface{}) error {
+	if len(l.key) == 0 {
+		return ErrNoSigningKey
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return ErrInvalidToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(sig, l.mac(parts[0])) {
+		return ErrInvalidToken
+	}
+	b, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return ErrInvalidToken
+	}
+	if err := json.Unmarshal(b, payload); err != nil {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+func (l *Links) mac(data string) []byte {
+	mac := hmac.New(sha256.New, l.key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,49 @@
+package links
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPayload struct {
+	MessageID string `json:"m"`
+	Email     string `json:"e"`
+}
+
+func TestLinks_SignVerify(t *testing.T) {
+	l := New("https://mail.domain.tld/", "test-key")
+
+	url, err := l.URL(UnsubscribePath, testPayload{MessageID: "m-1", Email: "john.doe@domain.tld"})
+	if assert.NoError(t, err) {
+		assert.True(t, strings.HasPrefix(url, "https://mail.domain.tld/api/v1/public/email/unsubscribe/"))
+	}
+	token := strings.TrimPrefix(url, "https://mail.domain.tld"+UnsubscribePath)
+
+	var p testPayload
+	if assert.NoError(t, l.Verify(token, &p)) {
+		assert.Equal(t, "m-1", p.MessageID)
+		assert.Equal(t, "john.doe@domain.tld", p.Email)
+	}
+
+	// tampered payload
+	forged, _ := New("", "other-key").Sign(testPayload{MessageID: "m-1", Email: "jane.doe@domain.tld"})
+	assert.Equal(t, ErrInvalidToken, l.Verify(strings.Split(forged, ".")[0]+"."+strings.Split(token, ".")[1], &p))
+	// other key
+	assert.Equal(t, ErrInvalidToken, l.Verify(forged, &p))
+	// malformed
+	assert.Equal(t, ErrInvalidToken, l.Verify("not-a-token", &p))
+
+	// no signing key
+	_, err = New("", "").Sign(p)
+	assert.Equal(t, ErrNoSigningKey, err)
+}
+
+func TestLinks_IsLink(t *testing.T) {
+	l := New("https://mail.domain.tld/", "test-key")
+
+	assert.True(t, l.IsLink("https://mail.domain.tld/api/v1/public/email/unsubscribe/abc.def"))
+	assert.False(t, l.IsLink("https://mail.domain.tld.evil.tld/"))
+	assert.False(t, l.IsLink("https://domain.tld/activate"))
+}
@@ -0,0 +1,274 @@
+package mailer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Format represent email body format
+type Format string
+
+const (
+	// HTMLFormat represent HTML email body
+	HTMLFormat Format = "HTML"
+	// TEXTFormat represent TEXT (plaintext) email body
+	TEXTFormat Format = "TEXT"
+)
+
+// Priority represent message priority
+type Priority string
+
+const (
+	// HighPriority high priority message
+	HighPriority Priority = "HIGH"
+	// NormalPriority normal priority message (default)
+	NormalPriority Priority = "NORMAL"
+	// LowPriority low priority message
+	LowPriority Priority = "LOW"
+)
+
+// priorityHeaders represent priority headers (X-Priority, X-MSMail-Priority and Importance) of the non-normal priorities
+var priorityHeaders = map[Priority][3]string{
+	HighPriority: {"1 (Highest)", "High", "high"},
+	LowPriority:  {"5 (Lowest)", "Low", "low"},
+}
+
+// Address represent mail address
+type Address struct {
+	Email string
+	Name  string
+}
+
+// String format address for mail header (RFC 5322)
+func (a *Address) String() string {
+	ma := mail.Address{Name: a.Name, Address: a.Email}
+	return ma.String()
+}
+
+// Attachment represent email attachment (content in memory or in a spooled file),
+// attachment with ContentID is sent inline (referenced as `cid:<ContentID>`)
+type Attachment struct {
+	Filename    string
+	ContentType string
+	ContentID   string
+	Content     []byte
+	FilePath    string
+}
+
+// Open open attachment content
+func (a *Attachment) Open() (io.ReadCloser, error) {
+	if a.FilePath != "" {
+		return os.Open(a.FilePath)
+	}
+	return ioutil.NopCloser(bytes.NewReader(a.Content)), nil
+}
+
+// CalendarFilename filename of the calendar invite attachment
+const CalendarFilename = "invite.ics"
+
+// Calendar represent calendar invite (iCalendar object) of the message, sent as text/calendar alternative of the body
+// (rendered by mail clients with accept/decline buttons) and as .ics attachment
+type Calendar struct {
+	Method  string // iTIP method: REQUEST or CANCEL
+	Content []byte
+}
+
+// Message represent email message (MIME)
+type Message struct {
+	MessageID   string
+	Date        time.Time
+	From        *Address
+	To          []*Address
+	CC          []*Address
+	BCC         []*Address
+	ReplyTo     *Address
+	Priority    Priority
+	Subject     string
+	Body        string
+	TextBody    string // optional: text/plain alternative of the HTML body
+	Format      Format
+	Attachments []*Attachment
+	Calendar    *Calendar            // optional: calendar invite
+	Headers     textproto.MIMEHeader // additional headers (e.g: List-Unsubscribe), the standard headers can not be overridden
+}
+
+// Recipients list all envelope recipients (to, cc and bcc)
+func (m *Message) Recipients() []string {
+	var rcpts []string
+	for _, list := range [][]*Address{m.To, m.CC, m.BCC} {
+		for _, a := range list {
+			rcpts = append(rcpts, a.Email)
+		}
+	}
+	return rcpts
+}
+
+// Bytes render message as bytes
+func (m *Message) Bytes() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := m.Render(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Render write rendered message (headers and body) into w.
+// Bcc recipients are never written as header, they are envelope recipients only.
+func (m *Message) Render(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	date := m.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	h := make(textproto.MIMEHeader)
+	if m.MessageID != "" {
+		h.Set("Message-ID", m.MessageID)
+	}
+	h.Set("Date", date.Format(time.RFC1123Z))
+	if m.From != nil {
+		h.Set("From", m.From.String())
+	}
+	if len(m.To) > 0 {
+		h.Set("To", m.joinAddresses(m.To))
+	}
+	if len(m.CC) > 0 {
+		h.Set("Cc", m.joinAddresses(m.CC))
+	}
+	if m.ReplyTo != nil {
+		h.Set("Reply-To", m.ReplyTo.String())
+	}
+	h.Set("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	if ph, ok := priorityHeaders[m.Priority]; ok {
+		h.Set("X-Priority", ph[0])
+		h.Set("X-MSMail-Priority", ph[1])
+		h.Set("Importance", ph[2])
+	}
+	h.Set("MIME-Version", "1.0")
+	for k, vs := range m.Headers {
+		k = textproto.CanonicalMIMEHeaderKey(k)
+		if _, ok := h[k]; !ok {
+			h[k] = vs
+		}
+	}
+
+	root := m.rootPart()
+	for k, v := range root.header {
+		h[k] = v
+	}
+	if err := m.writeHeader(bw, h); err != nil {
+		return err
+	}
+	if err := root.write(bw); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// rootPart build MIME structure of the message:
+//
+//	multipart/mixed (if any attachment, or calendar invite)
+//	 ├─ multipart/alternative (HTML with text body, or calendar invite)
+//	 │   ├─ text/plain (text body)
+//	 │   ├─ multipart/related (HTML with inline attachments)
+//	 │   │   ├─ text/html
+//	 │   │   └─ inline attachments (Content-ID)
+//	 │   └─ text/calendar
+//	 └─ attachments (and the .ics calendar invite)
+func (m *Message) rootPart() *part {
+	var inlines, attachments []*Attachment
+	for _, a := range m.Attachments {
+		if a.ContentID != "" && m.Format == HTMLFormat {
+			inlines = append(inlines, a)
+		} else {
+			attachments = append(attachments, a)
+		}
+	}
+
+	content := newTextPart(m.Format, m.Body)
+	if len(inlines) > 0 {
+		parts := []*part{content}
+		for _, a := range inlines {
+			parts = append(parts, newAttachmentPart(a))
+		}
+		content = newMultipartPart("related", map[string]string{"type": "text/html"}, parts)
+	}
+	// alternatives: least preferred first (RFC 2046 5.1.4)
+	var alternatives []*part
+	if m.TextBody != "" && m.Format == HTMLFormat {
+		alternatives = append(alternatives, newTextPart(TEXTFormat, m.TextBody))
+	}
+	alternatives = append(alternatives, content)
+	if m.Calendar != nil {
+		alternatives = append(alternatives, newCalendarPart(m.Calendar))
+	}
+	if len(alternatives) > 1 {
+		content = newMultipartPart("alternative", nil, alternatives)
+	}
+	if m.Calendar != nil {
+		attachments = append(attachments, &Attachment{
+			Filename:    CalendarFilename,
+			ContentType: "application/ics",
+			Content:     m.Calendar.Content,
+		})
+	}
+	if len(attachments) == 0 {
+		return content
+	}
+
+	parts := []*part{content}
+	for _, a := range attachments {
+		parts = append(parts, newAttachmentPart(a))
+	}
+	return newMultipartPart("mixed", nil, parts)
+}
+
+func (m *Message) writeHeader(w io.Writer, h textproto.MIMEHeader) error {
+	// keep a stable and readable header order
+	order := []string{"Message-ID", "Date", "From", "To", "Cc", "Subject", "MIME-Version", "Content-Type", "Content-Transfer-Encoding"}
+	written := make(map[string]bool)
+	for _, k := range order {
+		if v := h.Get(k); v != "" {
+			if _, err := fmt.Fprintf(w, "%s: %s\r\n", k, v); err != nil {
+				return err
+			}
+		}
+		written[textproto.CanonicalMIMEHeaderKey(k)] = true
+	}
+	var keys []string
+	for k := range h {
+		if !written[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range h[k] {
+			if _, err := fmt.Fprintf(w, "%s: %s\r\n", k, v); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := io.WriteString(w, "\r\n")
+	return err
+}
+
+func (m *Message) joinAddresses(list []*Address) string {
+	var s []string
+	for _, a := range list {
+		s = append(s, a.String())
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,331 @@
+package mailer
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage_Render(t *testing.T) {
+	msg := &Message{
+		MessageID: "<1234@domain.tld>",
+		From:      &Address{Email: "no-reply@domain.tld", Name: "No Reply"},
+		To:        []*Address{{Email: "john.doe@domain.tld", Name: "John Doe"}},
+		BCC:       []*Address{{Email: "audit@domain.tld", Name: "Audit"}},
+		Subject:   "Aktivasi Akun ✓",
+		Body:      "<p>Hello John</p>",
+		Format:    HTMLFormat,
+	}
+
+	raw, err := msg.Bytes()
+	if !assert.NoError(t, err) {
+		return
+	}
+	m, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "<1234@domain.tld>", m.Header.Get("Message-ID"))
+	assert.Equal(t, "", m.Header.Get("Bcc"))
+	subject, _ := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+	assert.Equal(t, "Aktivasi Akun ✓", subject)
+	assert.Equal(t, []string{"john.doe@domain.tld", "audit@domain.tld"}, msg.Recipients())
+
+	mt, _, _ := mime.ParseMediaType(m.Header.Get("Content-Type"))
+	assert.Equal(t, "text/html", mt)
+}
+
+func TestMessage_Render_Headers(t *testing.T) {
+	msg := &Message{
+		From:    &Address{Email: "no-reply@domain.tld"},
+		To:      []*Address{{Email: "john.doe@domain.tld"}},
+		Subject: "Newsletter",
+		Body:    "<p>News</p>",
+		Format:  HTMLFormat,
+		Headers: textproto.MIMEHeader{
+			"List-Unsubscribe":      {"<https://mail.domain.tld/api/v1/public/email/unsubscribe/token>"},
+			"List-Unsubscribe-Post": {"List-Unsubscribe=One-Click"},
+			"Subject":               {"Overridden"},
+		},
+	}
+
+	raw, err := msg.Bytes()
+	if !assert.NoError(t, err) {
+		return
+	}
+	m, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "<https://mail.domain.tld/api/v1/public/email/unsubscribe/token>", m.Header.Get("List-Unsubscribe"))
+	assert.Equal(t, "List-Unsubscribe=One-Click", m.Header.Get("List-Unsubscribe-Post"))
+	subject, _ := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+	assert.Equal(t, "Newsletter", subject)
+}
+
+func TestMessage_Render_ReplyToPriority(t *testing.T) {
+	msg := &Message{
+		From:     &Address{Email: "no-reply@domain.tld"},
+		To:       []*Address{{Email: "john.doe@domain.tld"}},
+		ReplyTo:  &Address{Email: "ticket-1234@helpdesk.domain.tld", Name: "Helpdesk"},
+		Priority: HighPriority,
+		Subject:  "Ticket #1234",
+		Body:     "Hello",
+		Format:   TEXTFormat,
+		Headers: textproto.MIMEHeader{
+			"X-Correlation-Id": {"order-1001"},
+			"X-Priority":       {"5"},
+		},
+	}
+
+	raw, err := msg.Bytes()
+	if !assert.NoError(t, err) {
+		return
+	}
+	m, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, `"Helpdesk" <ticket-1234@helpdesk.domain.tld>`, m.Header.Get("Reply-To"))
+	assert.Equal(t, "1 (Highest)", m.Header.Get("X-Priority"))
+	assert.Equal(t, "High", m.Header.Get("X-MSMail-Priority"))
+	assert.Equal(t, "high", m.Header.Get("Importance"))
+	assert.Equal(t, "order-1001", m.Header.Get("X-Correlation-Id"))
+
+	// normal priority: no priority headers
+	msg.Priority = NormalPriority
+	msg.Headers = nil
+	raw, _ = msg.Bytes()
+	m, _ = mail.ReadMessage(strings.NewReader(string(raw)))
+	assert.Equal(t, "", m.Header.Get("X-Priority"))
+	assert.Equal(t, "", m.Header.Get("Importance"))
+}
+
+func TestMessage_Render_Attachments(t *testing.T) {
+	f, err := ioutil.TempFile("", "attachment-*")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.Remove(f.Name())
+	spooled := strings.Repeat("spooled report line\n", 100)
+	f.WriteString(spooled)
+	f.Close()
+
+	msg := &Message{
+		From:    &Address{Email: "no-reply@domain.tld", Name: "No Reply"},
+		To:      []*Address{{Email: "john.doe@domain.tld", Name: "John Doe"}},
+		Subject: "Invoice",
+		Body:    "Invoice attached",
+		Format:  TEXTFormat,
+		Attachments: []*Attachment{
+			{Filename: "invoice.pdf", ContentType: "application/pdf", Content: []byte("%PDF-1.4")},
+			{Filename: "report.csv", ContentType: "text/csv", FilePath: f.Name()},
+		},
+	}
+
+	raw, err := msg.Bytes()
+	if !assert.NoError(t, err) {
+		return
+	}
+	m, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	mt, params, err := mime.ParseMediaType(m.Header.Get("Content-Type"))
+	if !assert.NoError(t, err) || !assert.Equal(t, "multipart/mixed", mt) {
+		return
+	}
+
+	var parts []*multipart.Part
+	var contents []string
+	mr := multipart.NewReader(m.Body, params["boundary"])
+	for {
+		p, err := mr.NextPart()
+		if err != nil {
+			break
+		}
+		b, _ := ioutil.ReadAll(p)
+		parts = append(parts, p)
+		contents = append(contents, string(b))
+	}
+
+	if assert.Len(t, parts, 3) {
+		assert.Equal(t, "Invoice attached", contents[0])
+		assert.Equal(t, "invoice.pdf", parts[1].FileName())
+		assert.Equal(t, "%PDF-1.4", decodeBase64(contents[1]))
+		assert.Equal(t, "report.csv", parts[2].FileName())
+		assert.Equal(t, spooled, decodeBase64(contents[2]))
+	}
+}
+
+func decodeBase64(s string) string {
+	b, _ := base64.StdEncoding.DecodeString(s)
+	return string(b)
+}
+
+func TestMessage_Render_Inline(t *testing.T) {
+	msg := &Message{
+		From:    &Address{Email: "no-reply@domain.tld", Name: "No Reply"},
+		To:      []*Address{{Email: "john.doe@domain.tld", Name: "John Doe"}},
+		Subject: "Welcome",
+		Body:    `<img src="cid:logo">`,
+		Format:  HTMLFormat,
+		Attachments: []*Attachment{
+			{Filename: "logo.png", ContentType: "image/png", ContentID: "logo", Content: []byte("PNG")},
+			{Filename: "terms.pdf", ContentType: "application/pdf", Content: []byte("%PDF-1.4")},
+		},
+	}
+
+	raw, err := msg.Bytes()
+	if !assert.NoError(t, err) {
+		return
+	}
+	m, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// multipart/mixed: [ multipart/related: [ text/html, logo.png ], terms.pdf ]
+	mt, params, _ := mime.ParseMediaType(m.Header.Get("Content-Type"))
+	if !assert.Equal(t, "multipart/mixed", mt) {
+		return
+	}
+	mr := multipart.NewReader(m.Body, params["boundary"])
+
+	related, err := mr.NextPart()
+	if !assert.NoError(t, err) {
+		return
+	}
+	mt, params, _ = mime.ParseMediaType(related.Header.Get("Content-Type"))
+	if !assert.Equal(t, "multipart/related", mt) {
+		return
+	}
+	rr := multipart.NewReader(related, params["boundary"])
+	body, _ := rr.NextPart()
+	b, _ := ioutil.ReadAll(body)
+	assert.Equal(t, `<img src="cid:logo">`, string(b))
+	logo, _ := rr.NextPart()
+	assert.Equal(t, "<logo>", logo.Header.Get("Content-ID"))
+	assert.True(t, strings.HasPrefix(logo.Header.Get("Content-Disposition"), "inline"))
+
+	terms, err := mr.NextPart()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "terms.pdf", terms.FileName())
+		assert.Equal(t, "", terms.Header.Get("Content-ID"))
+	}
+}
+
+func TestMessage_Render_Calendar(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\r\nMETHOD:REQUEST\r\nEND:VCALENDAR\r\n"
+	msg := &Message{
+		From:     &Address{Email: "no-reply@domain.tld", Name: "No Reply"},
+		To:       []*Address{{Email: "john.doe@domain.tld", Name: "John Doe"}},
+		Subject:  "Sprint Review",
+		Body:     "<p>You are invited</p>",
+		Format:   HTMLFormat,
+		Calendar: &Calendar{Method: "REQUEST", Content: []byte(ics)},
+	}
+
+	raw, err := msg.Bytes()
+	if !assert.NoError(t, err) {
+		return
+	}
+	m, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// multipart/mixed: [ multipart/alternative: [ text/html, text/calendar ], invite.ics ]
+	mt, params, _ := mime.ParseMediaType(m.Header.Get("Content-Type"))
+	if !assert.Equal(t, "multipart/mixed", mt) {
+		return
+	}
+	mr := multipart.NewReader(m.Body, params["boundary"])
+
+	alternative, err := mr.NextPart()
+	if !assert.NoError(t, err) {
+		return
+	}
+	mt, params, _ = mime.ParseMediaType(alternative.Header.Get("Content-Type"))
+	if !assert.Equal(t, "multipart/alternative", mt) {
+		return
+	}
+	ar := multipart.NewReader(alternative, params["boundary"])
+	body, _ := ar.NextPart()
+	assert.True(t, strings.HasPrefix(body.Header.Get("Content-Type"), "text/html"))
+	calendar, err := ar.NextPart()
+	if assert.NoError(t, err) {
+		mt, params, _ = mime.ParseMediaType(calendar.Header.Get("Content-Type"))
+		assert.Equal(t, "text/calendar", mt)
+		assert.Equal(t, "REQUEST", params["method"])
+		b, _ := ioutil.ReadAll(calendar)
+		assert.Equal(t, ics, string(b))
+	}
+
+	invite, err := mr.NextPart()
+	if assert.NoError(t, err) {
+		assert.Equal(t, CalendarFilename, invite.FileName())
+		b, _ := ioutil.ReadAll(invite)
+		content, _ := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(b), "\r\n", ""))
+		assert.Equal(t, ics, string(content))
+	}
+}
+
+func TestMessage_Render_TextBody(t *testing.T) {
+	msg := &Message{
+		From:     &Address{Email: "no-reply@domain.tld", Name: "No Reply"},
+		To:       []*Address{{Email: "john.doe@domain.tld", Name: "John Doe"}},
+		Subject:  "Welcome",
+		Body:     "<p>Hello <b>John</b></p>",
+		TextBody: "Hello John",
+		Format:   HTMLFormat,
+	}
+
+	raw, err := msg.Bytes()
+	if !assert.NoError(t, err) {
+		return
+	}
+	m, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// multipart/alternative: [ text/plain, text/html ]
+	mt, params, _ := mime.ParseMediaType(m.Header.Get("Content-Type"))
+	if !assert.Equal(t, "multipart/alternative", mt) {
+		return
+	}
+	ar := multipart.NewReader(m.Body, params["boundary"])
+	text, err := ar.NextPart()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, strings.HasPrefix(text.Header.Get("Content-Type"), "text/plain"))
+	b, _ := ioutil.ReadAll(text)
+	assert.Equal(t, "Hello John", string(b))
+	html, err := ar.NextPart()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, strings.HasPrefix(html.Header.Get("Content-Type"), "text/html"))
+	b, _ = ioutil.ReadAll(html)
+	assert.Equal(t, "<p>Hello <b>John</b></p>", string(b))
+
+	// TEXT format: the text body is ignored
+	msg.Format = TEXTFormat
+	raw, _ = msg.Bytes()
+	m, _ = mail.ReadMessage(strings.NewReader(string(raw)))
+	assert.True(t, strings.HasPrefix(m.Header.Get("Content-Type"), "text/plain"))
+}
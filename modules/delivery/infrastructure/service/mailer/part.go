@@ -0,0 +1,160 @@
+package mailer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+)
+
+// part represent MIME part (entity): header and body writer
+type part struct {
+	header textproto.MIMEHeader
+	write  func(w io.Writer) error
+}
+
+// newTextPart new text/plain or text/html part (quoted-printable)
+func newTextPart(format Format, body string) *part {
+	ct := "text/plain"
+	if format == HTMLFormat {
+		ct = "text/html"
+	}
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Type", mime.FormatMediaType(ct, map[string]string{"charset": "UTF-8"}))
+	h.Set("Content-Transfer-Encoding", "quoted-printable")
+
+	return &part{
+		header: h,
+		write: func(w io.Writer) error {
+			qw := quotedprintable.NewWriter(w)
+			if _, err := io.WriteString(qw, body); err != nil {
+				return err
+			}
+			return qw.Close()
+		},
+	}
+}
+
+// newCalendarPart new text/calendar part (quoted-printable) of a calendar invite
+func newCalendarPart(c *Calendar) *part {
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Type", mime.FormatMediaType("text/calendar", map[string]string{"method": c.Method, "charset": "UTF-8"}))
+	h.Set("Content-Transfer-Encoding", "quoted-printable")
+
+	return &part{
+		header: h,
+		write: func(w io.Writer) error {
+			qw := quotedprintable.NewWriter(w)
+			if _, err := qw.Write(c.Content); err != nil {
+				return err
+			}
+			return qw.Close()
+		},
+	}
+}
+
+// newMultipartPart new multipart/<subtype> part
+func newMultipartPart(subtype string, params map[string]string, parts []*part) *part {
+	boundary := multipart.NewWriter(ioutil.Discard).Boundary()
+
+	ps := map[string]string{"boundary": boundary}
+	for k, v := range params {
+		ps[k] = v
+	}
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Type", mime.FormatMediaType("multipart/"+subtype, ps))
+
+	return &part{
+		header: h,
+		write: func(w io.Writer) error {
+			mw := multipart.NewWriter(w)
+			if err := mw.SetBoundary(boundary); err != nil {
+				return err
+			}
+			for _, p := range parts {
+				pw, err := mw.CreatePart(p.header)
+				if err != nil {
+					return err
+				}
+				if err := p.write(pw); err != nil {
+					return err
+				}
+			}
+			return mw.Close()
+		},
+	}
+}
+
+// newAttachmentPart new attachment part (base64), attachment with ContentID is inline
+func newAttachmentPart(a *Attachment) *part {
+	ct := a.ContentType
+	if ct == "" {
+		ct = "application/octet-stream"
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Type", mime.FormatMediaType(ct, map[string]string{"name": a.Filename}))
+	disposition := "attachment"
+	if a.ContentID != "" {
+		disposition = "inline"
+		h.Set("Content-ID", fmt.Sprintf("<%s>", a.ContentID))
+	}
+	h.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": a.Filename}))
+	h.Set("Content-Transfer-Encoding", "base64")
+
+	return &part{
+		header: h,
+		write: func(w io.Writer) error {
+			r, err := a.Open()
+			if err != nil {
+				return fmt.Errorf("Cannot open attachment `%s`: %s", a.Filename, err.Error())
+			}
+			defer r.Close()
+
+			enc := base64.NewEncoder(base64.StdEncoding, &lineWrapper{w: w, max: 76})
+			if _, err := io.Copy(enc, r); err != nil {
+				return err
+			}
+			if err := enc.Close(); err != nil {
+				return err
+			}
+			_, err = io.WriteString(w, "\r\n")
+			return err
+		},
+	}
+}
+
+// lineWrapper split base64 content into lines (RFC 2045: max 76 chars)
+type lineWrapper struct {
+	w   io.Writer
+	max int
+	n   int
+}
+
+func (l *lineWrapper) Write(p []byte) (int, error) {
+	total := 0
+	for len(p) > 0 {
+		chunk := l.max - l.n
+		if chunk > len(p) {
+			chunk = len(p)
+		}
+		n, err := l.w.Write(p[:chunk])
+		total += n
+		if err != nil {
+			return total, err
+		}
+		l.n += n
+		p = p[chunk:]
+		if l.n == l.max {
+			if _, err := l.w.Write([]byte("\r\n")); err != nil {
+				return total, err
+			}
+			l.n = 0
+		}
+	}
+	return total, nil
+}
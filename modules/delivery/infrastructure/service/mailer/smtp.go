@@ -0,0 +1,141 @@
+package mailer
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+
+	"github.com/d3ta-go/system/system/handler"
+)
+
+// NewSMTPSender new SMTPSender (using default SMTP server configuration)
+func NewSMTPSender(h *handler.Handler) (*SMTPSender, error) {
+	cfg, err := h.GetDefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	s := new(SMTPSender)
+	s.handler = h
+
+	s.server = cfg.SMTPServers.DefaultSMTP.Server
+	s.port = cfg.SMTPServers.DefaultSMTP.Port
+	s.username = cfg.SMTPServers.DefaultSMTP.Username
+	s.password = cfg.SMTPServers.DefaultSMTP.Password
+	s.senderEmail = cfg.SMTPServers.DefaultSMTP.SenderEmail
+
+	return s, nil
+}
+
+// SMTPSender type
+type SMTPSender struct {
+	handler     *handler.Handler
+	server      string
+	port        string
+	username    string
+	password    string
+	senderEmail string
+}
+
+// SenderEmail get envelope sender email
+func (s *SMTPSender) SenderEmail() string {
+	return s.senderEmail
+}
+
+// Send send message using SMTP, the message is streamed (attachments are not loaded in memory at once).
+// It returns the final SMTP server response of the message (e.g: `250 2.0.0 OK queued as 1A2B3C`).
+func (s *SMTPSender) Send(m *Message) (string, error) {
+	resp, err := s.send(m)
+	if err != nil {
+		return "", fmt.Errorf("Error from SMTP Server: %w", err)
+	}
+	return resp, nil
+}
+
+// MessageID format RFC 5322 Message-ID (`<id@domain>`), using the domain of the sender email
+func (s *SMTPSender) MessageID(id string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(s.senderEmail, "@"); at > -1 && at < len(s.senderEmail)-1 {
+		domain = s.senderEmail[at+1:]
+	}
+	return fmt.Sprintf("<%s@%s>", id, domain)
+}
+
+// IsPermanentError check whether the error is a permanent SMTP error (5xx reply), that should not be retried
+func IsPermanentError(err error) bool {
+	var tpErr *textproto.Error
+	if errors.As(err, &tpErr) {
+		return tpErr.Code >= 500 && tpErr.Code < 600
+	}
+	return false
+}
+
+func (s *SMTPSender) send(m *Message) (string, error) {
+	c, err := smtp.Dial(net.JoinHostPort(s.server, s.port))
+	if err != nil {
+		return "", err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: s.server}); err != nil {
+			return "", err
+		}
+	}
+	if ok, _ := c.Extension("AUTH"); ok && s.username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.username, s.password, s.server)); err != nil {
+			return "", err
+		}
+	}
+
+	if err := c.Mail(s.senderEmail); err != nil {
+		return "", err
+	}
+	for _, rcpt := range m.Recipients() {
+		if err := c.Rcpt(rcpt); err != nil {
+			return "", err
+		}
+	}
+
+	resp, err := s.data(c.Text, m)
+	if err != nil {
+		return "", err
+	}
+
+	// the message is accepted, a failed QUIT must not cause the message to be sent again
+	c.Quit()
+	return resp, nil
+}
+
+// data send DATA command and the message, smtp.Client.Data() does not expose the final server response
+func (s *SMTPSender) data(t *textproto.Conn, m *Message) (string, error) {
+	id, err := t.Cmd("DATA")
+	if err != nil {
+		return "", err
+	}
+	t.StartResponse(id)
+	_, _, err = t.ReadResponse(354)
+	t.EndResponse(id)
+	if err != nil {
+		return "", err
+	}
+
+	w := t.DotWriter()
+	if err := m.Render(w); err != nil {
+		w.Close()
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	code, msg, err := t.ReadResponse(250)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d %s", code, msg), nil
+}
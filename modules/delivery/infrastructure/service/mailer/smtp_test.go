@@ -0,0 +1,88 @@
+package mailer
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPermanentError(t *testing.T) {
+	perm := fmt.Errorf("Send email failed: %w", &textproto.Error{Code: 550, Msg: "mailbox unavailable"})
+	temp := fmt.Errorf("Send email failed: %w", &textproto.Error{Code: 451, Msg: "try again later"})
+
+	assert.True(t, IsPermanentError(perm))
+	assert.False(t, IsPermanentError(temp))
+	assert.False(t, IsPermanentError(errors.New("connection refused")))
+	assert.False(t, IsPermanentError(nil))
+}
+
+func TestSMTPSender_MessageID(t *testing.T) {
+	s := &SMTPSender{senderEmail: "no-reply@domain.tld"}
+	assert.Equal(t, "<0b9e6f2a@domain.tld>", s.MessageID("0b9e6f2a"))
+
+	s.senderEmail = ""
+	assert.Equal(t, "<0b9e6f2a@localhost>", s.MessageID("0b9e6f2a"))
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("net.Listen: %s", err.Error())
+	}
+	defer ln.Close()
+
+	// minimal SMTP server
+	received := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			switch strings.ToUpper(strings.Fields(line)[0]) {
+			case "EHLO", "HELO":
+				tp.PrintfLine("250 localhost")
+			case "DATA":
+				tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+				data, _ := tp.ReadDotBytes()
+				received <- string(data)
+				tp.PrintfLine("250 2.0.0 Ok: queued as 1A2B3C")
+			case "QUIT":
+				tp.PrintfLine("221 2.0.0 Bye")
+				return
+			default:
+				tp.PrintfLine("250 2.1.0 Ok")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	s := &SMTPSender{server: host, port: port, senderEmail: "no-reply@domain.tld"}
+	m := &Message{
+		MessageID: s.MessageID("0b9e6f2a"),
+		From:      &Address{Email: "d3tago.from@domain.tld"},
+		To:        []*Address{{Email: "d3tago.to@domain.tld"}},
+		Subject:   "Hello",
+		Body:      "Hello World",
+		Format:    TEXTFormat,
+	}
+
+	resp, err := s.Send(m)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "250 2.0.0 Ok: queued as 1A2B3C", resp)
+		assert.Contains(t, <-received, "Message-ID: <0b9e6f2a@domain.tld>")
+	}
+}
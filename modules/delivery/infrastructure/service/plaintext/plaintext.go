@@ -0,0 +1,322 @@
+package plaintext
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// skipped elements: their content is not part of the text
+var skipped = map[atom.Atom]bool{
+	atom.Head: true, atom.Title: true, atom.Style: true, atom.Script: true, atom.Noscript: true, atom.Template: true,
+}
+
+// paragraphs elements: separated by a blank line
+var paragraphs = map[atom.Atom]bool{
+	atom.P: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Blockquote: true, atom.Dl: true, atom.Pre: true, atom.Table: true,
+}
+
+// blocks elements: start on a new line
+var blocks = map[atom.Atom]bool{
+	atom.Div: true, atom.Section: true, atom.Article: true, atom.Header: true, atom.Footer: true, atom.Nav: true,
+	atom.Main: true, atom.Aside: true, atom.Address: true, atom.Figure: true, atom.Figcaption: true, atom.Center: true,
+	atom.Form: true, atom.Dt: true, atom.Dd: true, atom.Li: true, atom.Caption: true,
+}
+
+// FromHTML generate plain text version of the HTML document (e.g: text/plain alternative of an HTML email):
+// links are kept as numbered footnotes, tables are flattened (a line per row, cells separated by " | ")
+// and the content of head, style and script elements is dropped.
+func FromHTML(s string) string {
+	c := new(converter)
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		switch tt {
+		case html.TextToken:
+			c.text(string(z.Text()))
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			attrs := map[string]string{}
+			for hasAttr {
+				var k, v []byte
+				k, v, hasAttr = z.TagAttr()
+				attrs[string(k)] = string(v)
+			}
+			c.start(atom.Lookup(name), attrs, tt == html.SelfClosingTagToken)
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			c.end(atom.Lookup(name))
+		}
+	}
+	return c.String()
+}
+
+type link struct {
+	href string
+	text strings.Builder
+}
+
+type converter struct {
+	out   strings.Builder
+	cell  *strings.Builder // current table cell (nil: not in a cell)
+	cells []string         // cells of the current table row
+	link  *link            // current (open) link
+	links []string         // footnotes
+	lists []int            // nested lists: item counter of ol, -1 for ul
+	skip  int
+	pre   int
+}
+
+func (c *converter) target() *strings.Builder {
+	if c.cell != nil {
+		return c.cell
+	}
+	return &c.out
+}
+
+func (c *converter) write(s string) {
+	c.target().WriteString(s)
+	if c.link != nil {
+		c.link.text.WriteString(s)
+	}
+}
+
+func (c *converter) text(s string) {
+	if c.skip > 0 {
+		return
+	}
+	if c.pre > 0 && c.cell == nil {
+		c.write(s)
+		return
+	}
+	s = collapse(s)
+	cur := c.target().String()
+	if cur == "" || strings.HasSuffix(cur, "\n") || strings.HasSuffix(cur, " ") {
+		s = strings.TrimLeft(s, " ")
+	}
+	if s != "" {
+		c.write(s)
+	}
+}
+
+// block end the current line, followed by n-1 blank lines (a space in a table cell)
+func (c *converter) block(n int) {
+	if c.cell != nil {
+		c.cell.WriteString(" ")
+		return
+	}
+	cur := c.out.String()
+	if cur == "" {
+		return
+	}
+	for k := len(cur) - len(strings.TrimRight(cur, "\n")); k < n; k++ {
+		c.out.WriteString("\n")
+	}
+}
+
+func (c *converter) start(a atom.Atom, attrs map[string]string, selfClosing bool) {
+	if skipped[a] {
+		if !selfClosing {
+			c.skip++
+		}
+		return
+	}
+	if c.skip > 0 {
+		return
+	}
+
+	switch {
+	case a == atom.Br:
+		if c.cell != nil {
+			c.cell.WriteString(" ")
+		} else {
+			c.out.WriteString("\n")
+		}
+	case a == atom.Hr:
+		c.block(2)
+		c.write("----")
+		c.block(2)
+	case a == atom.Ul || a == atom.Ol:
+		if len(c.lists) == 0 {
+			c.block(2)
+		} else {
+			c.block(1)
+		}
+		if a == atom.Ol {
+			c.lists = append(c.lists, 0)
+		} else {
+			c.lists = append(c.lists, -1)
+		}
+	case a == atom.Li:
+		c.block(1)
+		if c.cell != nil || len(c.lists) == 0 {
+			return
+		}
+		prefix := "- "
+		if n := len(c.lists) - 1; c.lists[n] >= 0 {
+			c.lists[n]++
+			prefix = fmt.Sprintf("%d. ", c.lists[n])
+		}
+		c.write(strings.Repeat("  ", len(c.lists)-1) + prefix)
+	case a == atom.Tr:
+		c.endRow()
+	case a == atom.Td || a == atom.Th:
+		c.endCell()
+		c.cell = new(strings.Builder)
+	case a == atom.A:
+		c.endLink()
+		c.link = &link{href: strings.TrimSpace(attrs["href"])}
+	case a == atom.Img:
+		if alt := strings.TrimSpace(attrs["alt"]); alt != "" {
+			c.text(" " + alt + " ")
+		}
+	case paragraphs[a]:
+		c.block(2)
+		if a == atom.Pre {
+			c.pre++
+		}
+	case blocks[a]:
+		c.block(1)
+	}
+}
+
+func (c *converter) end(a atom.Atom) {
+	if skipped[a] {
+		if c.skip > 0 {
+			c.skip--
+		}
+		return
+	}
+	if c.skip > 0 {
+		return
+	}
+
+	switch {
+	case a == atom.Ul || a == atom.Ol:
+		if len(c.lists) > 0 {
+			c.lists = c.lists[:len(c.lists)-1]
+		}
+		if len(c.lists) == 0 {
+			c.block(2)
+		} else {
+			c.block(1)
+		}
+	case a == atom.Td || a == atom.Th:
+		c.endCell()
+	case a == atom.Tr:
+		c.endRow()
+	case a == atom.Table:
+		c.endRow()
+		c.block(2)
+	case a == atom.A:
+		c.endLink()
+	case paragraphs[a]:
+		if a == atom.Pre && c.pre > 0 {
+			c.pre--
+		}
+		c.block(2)
+	case blocks[a]:
+		c.block(1)
+	}
+}
+
+func (c *converter) endCell() {
+	if c.cell == nil {
+		return
+	}
+	if t := strings.TrimSpace(c.cell.String()); t != "" {
+		c.cells = append(c.cells, t)
+	}
+	c.cell = nil
+}
+
+func (c *converter) endRow() {
+	c.endCell()
+	if len(c.cells) == 0 {
+		return
+	}
+	c.block(1)
+	c.write(strings.Join(c.cells, " | "))
+	c.block(1)
+	c.cells = nil
+}
+
+// endLink write footnote reference of the link, except: anchors, scripts and links showing their own URL
+func (c *converter) endLink() {
+	l := c.link
+	c.link = nil
+	if l == nil {
+		return
+	}
+	text := strings.TrimSpace(l.text.String())
+	lower := strings.ToLower(l.href)
+	if l.href == "" || strings.HasPrefix(l.href, "#") || strings.HasPrefix(lower, "javascript:") ||
+		l.href == text || strings.TrimPrefix(lower, "mailto:") == strings.ToLower(text) {
+		return
+	}
+	if text == "" {
+		c.text(" " + l.href + " ")
+		return
+	}
+	c.links = append(c.links, l.href)
+	ref := fmt.Sprintf("[%d]", len(c.links))
+	if !strings.HasSuffix(c.target().String(), " ") {
+		ref = " " + ref
+	}
+	c.write(ref)
+}
+
+// String final text: trailing spaces removed, at most one blank line, followed by the links footnotes
+func (c *converter) String() string {
+	c.endLink()
+	c.endRow()
+
+	lines := strings.Split(c.out.String(), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRightFunc(l, unicode.IsSpace)
+	}
+	s := strings.Join(lines, "\n")
+	for strings.Contains(s, "\n\n\n") {
+		s = strings.ReplaceAll(s, "\n\n\n", "\n\n")
+	}
+	s = strings.Trim(s, "\n")
+
+	if len(c.links) > 0 {
+		var b strings.Builder
+		b.WriteString(s)
+		b.WriteString("\n\n")
+		for i, href := range c.links {
+			b.WriteString(fmt.Sprintf("[%d] %s\n", i+1, href))
+		}
+		s = strings.TrimRight(b.String(), "\n")
+	}
+	return s
+}
+
+// collapse replace whitespace sequences with a single space
+func collapse(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	if space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
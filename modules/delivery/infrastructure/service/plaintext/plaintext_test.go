@@ -0,0 +1,58 @@
+package plaintext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromHTML(t *testing.T) {
+	s := FromHTML(`<!DOCTYPE html>
+<html>
+<head><title>Invoice</title><style>p { color: red; }</style></head>
+<body>
+	<h1>Hello   John,</h1>
+	<p>Thank you for your <b>order</b>.<br>Please <a href="https://shop.domain.tld/orders/1">check   the status</a> of your order.</p>
+	<table>
+		<tr><th>Item</th><th>Qty</th><th></th></tr>
+		<tr><td>Book</td><td>2</td><td><img src="cid:book" alt="book"></td></tr>
+	</table>
+	<ul><li>Fast shipping</li><li>Free returns<ol><li>Step one</li></ol></li></ul>
+	<script>alert("x")</script>
+	<p><a href="https://domain.tld">https://domain.tld</a> | <a href="#top">Top</a> | <a href="mailto:cs@domain.tld">cs@domain.tld</a></p>
+	<hr>
+	<pre>  a
+    b</pre>
+	<p><a href="https://domain.tld/unsubscribe">Unsubscribe</a></p>
+</body>
+</html>`)
+
+	assert.Equal(t, `Hello John,
+
+Thank you for your order.
+Please check the status [1] of your order.
+
+Item | Qty
+Book | 2 | book
+
+- Fast shipping
+- Free returns
+  1. Step one
+
+https://domain.tld | Top | cs@domain.tld
+
+----
+
+  a
+    b
+
+Unsubscribe [2]
+
+[1] https://shop.domain.tld/orders/1
+[2] https://domain.tld/unsubscribe`, s)
+}
+
+func TestFromHTML_Fragment(t *testing.T) {
+	assert.Equal(t, "Hello & welcome", FromHTML("Hello &amp;\n welcome"))
+	assert.Equal(t, "", FromHTML(""))
+}
@@ -0,0 +1,37 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appConfig "github.com/d3ta-go/ms-email-restapi/system/config"
+	"github.com/d3ta-go/system/system/handler"
+)
+
+// Queue represent delivery queue (of outbox message ids)
+type Queue interface {
+	Push(ctx context.Context, id string) error
+	// Pop wait (up to timeout) for a queued id, returns empty id when the queue stays empty
+	Pop(ctx context.Context, timeout time.Duration) (string, error)
+}
+
+// NewQueue new Queue (using delivery queue configuration), returns nil Queue when the queue is not enabled
+func NewQueue(h *handler.Handler) (Queue, error) {
+	cfg, err := appConfig.GetConfig(h)
+	if err != nil {
+		return nil, err
+	}
+
+	qCfg := cfg.Delivery.Queue
+	if !qCfg.IsEnabled() {
+		return nil, nil
+	}
+
+	switch qCfg.Engine {
+	case "redis":
+		return NewRedisQueue(h, qCfg.ConnectionName, qCfg.GetKey())
+	default:
+		return nil, fmt.Errorf("Invalid delivery queue engine [%s]", qCfg.Engine)
+	}
+}
@@ -0,0 +1,52 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/d3ta-go/system/system/handler"
+	"github.com/go-redis/redis/v8"
+)
+
+// NewRedisQueue new RedisQueue (Redis list), using redis cache connection
+func NewRedisQueue(h *handler.Handler, connectionName, key string) (*RedisQueue, error) {
+	c, err := h.GetCacher(connectionName)
+	if err != nil {
+		return nil, err
+	}
+	client, ok := c.GetEngine().(*redis.Client)
+	if !ok {
+		return nil, fmt.Errorf("Cache connection [%s] is not a redis connection", connectionName)
+	}
+
+	q := new(RedisQueue)
+	q.client = client
+	q.key = key
+
+	return q, nil
+}
+
+// RedisQueue type Implement Queue
+type RedisQueue struct {
+	client *redis.Client
+	key    string
+}
+
+// Push push id to the queue
+func (q *RedisQueue) Push(ctx context.Context, id string) error {
+	return q.client.LPush(ctx, q.key, id).Err()
+}
+
+// Pop pop (FIFO) id from the queue
+func (q *RedisQueue) Pop(ctx context.Context, timeout time.Duration) (string, error) {
+	res, err := q.client.BRPop(ctx, timeout, q.key).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	// [key, value]
+	return res[1], nil
+}
@@ -0,0 +1,51 @@
+package tracking
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// linkHref match href attribute (value) of anchor tags
+var linkHref = regexp.MustCompile(`(?is)<a\s[^>]*?\bhref\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+
+// InjectPixel inject the open tracking pixel (1x1 image) into an HTML body, before `</body>` (or at the end)
+func InjectPixel(body, pixelURL string) string {
+	img := fmt.Sprintf(`<img src="%s" width="1" height="1" alt="" style="display:block;width:1px;height:1px;border:0;" />`, html.EscapeString(pixelURL))
+
+	if idx := strings.LastIndex(strings.ToLower(body), "</body"); idx > -1 {
+		return body[:idx] + img + body[idx:]
+	}
+	return body + img
+}
+
+// RewriteLinks rewrite http(s) links (anchor href) of an HTML body,
+// rewrite is called with the link index (order of the http(s) links in the body) and the (unescaped) link url,
+// returns the new url, or an empty string to keep the link
+func RewriteLinks(body string, rewrite func(index int, url string) string) string {
+	var sb strings.Builder
+	last, index := 0, 0
+	for _, m := range linkHref.FindAllStringSubmatchIndex(body, -1) {
+		// value of the double (2,3) or single (4,5) quoted href
+		start, end := m[2], m[3]
+		if start < 0 {
+			start, end = m[4], m[5]
+		}
+		url := html.UnescapeString(strings.TrimSpace(body[start:end]))
+		lower := strings.ToLower(url)
+		if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+			continue
+		}
+		newURL := rewrite(index, url)
+		index++
+		if newURL == "" {
+			continue
+		}
+		sb.WriteString(body[last:start])
+		sb.WriteString(html.EscapeString(newURL))
+		last = end
+	}
+	sb.WriteString(body[last:])
+	return sb.String()
+}
@@ -0,0 +1,40 @@
+package tracking
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjectPixel(t *testing.T) {
+	url := "http://127.0.0.1:20201/api/v1/public/email/open/abc.def"
+
+	body := InjectPixel("<html><BODY><p>Hello</p></BODY></html>", url)
+	assert.Equal(t, `<html><BODY><p>Hello</p><img src="`+url+`" width="1" height="1" alt="" style="display:block;width:1px;height:1px;border:0;" /></BODY></html>`, body)
+
+	body = InjectPixel("<p>Hello</p>", url)
+	assert.Equal(t, `<p>Hello</p><img src="`+url+`" width="1" height="1" alt="" style="display:block;width:1px;height:1px;border:0;" />`, body)
+}
+
+func TestRewriteLinks(t *testing.T) {
+	body := `<p><a href="https://domain.tld/activate?id=1&amp;code=2">Activate</a>
+<a class="btn" HREF='http://domain.tld/help'>Help</a>
+<a href="mailto:cs@domain.tld">Mail</a> <a href="#top">Top</a>
+<a href="https://domain.tld/unsubscribe">Unsubscribe</a></p>`
+
+	var urls []string
+	res := RewriteLinks(body, func(index int, url string) string {
+		urls = append(urls, url)
+		if url == "https://domain.tld/unsubscribe" {
+			return ""
+		}
+		return fmt.Sprintf("https://t.domain.tld/click/%d?a=1&b=2", index)
+	})
+
+	assert.Equal(t, []string{"https://domain.tld/activate?id=1&code=2", "http://domain.tld/help", "https://domain.tld/unsubscribe"}, urls)
+	assert.Equal(t, `<p><a href="https://t.domain.tld/click/0?a=1&amp;b=2">Activate</a>
+<a class="btn" HREF='https://t.domain.tld/click/1?a=1&amp;b=2'>Help</a>
+<a href="mailto:cs@domain.tld">Mail</a> <a href="#top">Top</a>
+<a href="https://domain.tld/unsubscribe">Unsubscribe</a></p>`, res)
+}
@@ -0,0 +1,111 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	domSchema "github.com/d3ta-go/ms-email-restapi/modules/delivery/domain/schema/webhook"
+)
+
+const (
+	// SignatureHeader signature header: `t=<unix timestamp>,v1=<hex HMAC-SHA256 of "<timestamp>.<body>">`
+	SignatureHeader = "X-Webhook-Signature"
+	// EventHeader event type header
+	EventHeader = "X-Webhook-Event"
+	// DeliveryHeader delivery id header (the same for retries of one delivery)
+	DeliveryHeader = "X-Webhook-Delivery"
+
+	maxResponseBody = 1024 // bytes read (drained) of the endpoint response
+)
+
+// Sign compute signature header value of a webhook request body
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// Result represent webhook endpoint response (the status code only, the response body is not kept)
+type Result struct {
+	StatusCode int
+}
+
+// NewSender new Sender: the endpoint is dialed only on a public IP address (SSRF),
+// a redirect is not followed (a non 2xx response)
+func NewSender(timeout time.Duration) *Sender {
+	return newSender(timeout, domSchema.IsPublicIP)
+}
+
+func newSender(timeout time.Duration, allowIP func(ip net.IP) bool) *Sender {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		// checked on the resolved address (DNS rebinding)
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !allowIP(ip) {
+				return fmt.Errorf("Webhook endpoint address %s is not allowed", host)
+			}
+			return nil
+		},
+	}
+	return &Sender{client: &http.Client{
+		Timeout: timeout,
+		// no proxy: the endpoint address is the dialed address
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+// Sender type (signed webhook POST)
+type Sender struct {
+	client *http.Client
+}
+
+// Post post signed event body to the webhook endpoint, a non 2xx response is returned as error (with the result)
+func (s *Sender) Post(url, secret, event, deliveryID string, body []byte) (*Result, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "ms-email-restapi-webhook")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(DeliveryHeader, deliveryID)
+	req.Header.Set(SignatureHeader, Sign(secret, time.Now().Unix(), body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// drained (connection reuse), not kept
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxResponseBody))
+	res := &Result{StatusCode: resp.StatusCode}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return res, fmt.Errorf("Webhook endpoint responded with status %d", resp.StatusCode)
+	}
+	return res, nil
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	sig := Sign("whsec_test", 1760745600, []byte(`{"id":"1"}`))
+	assert.Equal(t, sig, Sign("whsec_test", 1760745600, []byte(`{"id":"1"}`)))
+	assert.True(t, strings.HasPrefix(sig, "t=1760745600,v1="))
+	assert.NotEqual(t, sig, Sign("whsec_other", 1760745600, []byte(`{"id":"1"}`)))
+	assert.NotEqual(t, sig, Sign("whsec_test", 1760745601, []byte(`{"id":"1"}`)))
+}
+
+func TestSender_Post(t *testing.T) {
+	body := []byte(`{"id":"1","type":"message.sent"}`)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, body, got)
+		assert.Equal(t, "message.sent", r.Header.Get(EventHeader))
+		assert.Equal(t, "d-1", r.Header.Get(DeliveryHeader))
+
+		// verify signature as a receiver does
+		sig := r.Header.Get(SignatureHeader)
+		ts, err := strconv.ParseInt(strings.TrimPrefix(strings.SplitN(sig, ",", 2)[0], "t="), 10, 64)
+		assert.NoError(t, err)
+		assert.Equal(t, Sign("whsec_test", ts, got), sig)
+
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/hook", http.StatusTemporaryRedirect)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	// the test server listens on loopback
+	s := newSender(5*time.Second, func(ip net.IP) bool { return true })
+
+	res, err := s.Post(srv.URL+"/hook", "whsec_test", "message.sent", "d-1", body)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	}
+
+	res, err = s.Post(srv.URL+"/gone", "whsec_test", "message.sent", "d-1", body)
+	assert.Error(t, err)
+	if assert.NotNil(t, res) {
+		assert.Equal(t, http.StatusGone, res.StatusCode)
+	}
+
+	// redirect is not followed
+	res, err = s.Post(srv.URL+"/redirect", "whsec_test", "message.sent", "d-1", body)
+	assert.Error(t, err)
+	if assert.NotNil(t, res) {
+		assert.Equal(t, http.StatusTemporaryRedirect, res.StatusCode)
+	}
+
+	// loopback endpoint is not dialed (SSRF)
+	_, err = NewSender(5*time.Second).Post(srv.URL+"/hook", "whsec_test", "message.sent", "d-1", body)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "is not allowed")
+	}
+}
@@ -5,9 +5,13 @@ import (
 	"fmt"
 
 	fwConfig "github.com/d3ta-go/system/system/config"
+	"github.com/d3ta-go/system/system/handler"
 	"github.com/spf13/viper"
 )
 
+// SpecificConfigName represent Config name on handler specific configs
+const SpecificConfigName = "ms-email-restapi"
+
 // NewConfig create new Config
 func NewConfig(path string) (*Config, *viper.Viper, error) {
 
@@ -42,6 +46,38 @@ type Config struct {
 	DirLocations fwConfig.DirLocations `json:"dirLocations" yaml:"dirLocations"`
 
 	// Add your custome Config
+
+	// Delivery refers to local module: delivery
+	Delivery Delivery `json:"delivery" yaml:"delivery"`
+}
+
+// LoadConfig load Config from handler "config" viper, and set it as handler specific config
+func LoadConfig(h *handler.Handler) (*Config, error) {
+	v, err := h.GetViper("config")
+	if err != nil {
+		return nil, err
+	}
+
+	c := new(Config)
+	if err := v.Unmarshal(&c); err != nil {
+		return nil, err
+	}
+	h.SetSpecificConfig(SpecificConfigName, c)
+
+	return c, nil
+}
+
+// GetConfig get Config from handler specific config (load it if not exist)
+func GetConfig(h *handler.Handler) (*Config, error) {
+	c, err := h.GetSpecificConfig(SpecificConfigName)
+	if err != nil {
+		return LoadConfig(h)
+	}
+	cfg, ok := c.(*Config)
+	if !ok {
+		return nil, fmt.Errorf("Invalid specific config `%s`", SpecificConfigName)
+	}
+	return cfg, nil
 }
 
 // ToJSON convert Config to JSON
@@ -0,0 +1,301 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// Delivery represent Delivery (local module) Config
+type Delivery struct {
+	Attachments Attachments `json:"attachments" yaml:"attachments"`
+	Scheduler   Scheduler   `json:"scheduler" yaml:"scheduler"`
+	Outbox      Outbox      `json:"outbox" yaml:"outbox"`
+	Queue       Queue       `json:"queue" yaml:"queue"`
+	Worker      Worker      `json:"worker" yaml:"worker"`
+	Idempotency Idempotency `json:"idempotency" yaml:"idempotency"`
+	Webhook     Webhook     `json:"webhook" yaml:"webhook"`
+	Links       Links       `json:"links" yaml:"links"`
+	Sandbox     Sandbox     `json:"sandbox" yaml:"sandbox"`
+	Addresses   Addresses   `json:"addresses" yaml:"addresses"`
+}
+
+const (
+	defaultAttachmentMaxFileSize    int64 = 10 << 20 // 10 MB
+	defaultAttachmentMaxTotalSize   int64 = 25 << 20 // 25 MB
+	defaultAttachmentSpoolThreshold int64 = 1 << 20  // 1 MB
+
+	defaultSchedulerPollInterval = 10  // seconds
+	defaultSchedulerBatchSize    = 50  // messages
+	defaultSchedulerLeaseTimeout = 600 // seconds
+
+	defaultOutboxMaxAttempts    = 5    // attempts
+	defaultOutboxInitialBackoff = 30   // seconds
+	defaultOutboxMaxBackoff     = 3600 // seconds
+
+	defaultQueueKey          = "ms-email:delivery:queue"
+	defaultQueueBlockTimeout = 5 // seconds
+
+	defaultWorkerConsumers = 1 // queue consumers
+
+	defaultIdempotencyWindow            = 86400 // seconds (24 hours)
+	defaultIdempotencyProcessingTimeout = 300   // seconds (5 minutes)
+
+	defaultWebhookTimeout        = 10    // seconds
+	defaultWebhookMaxAttempts    = 8     // attempts
+	defaultWebhookInitialBackoff = 30    // seconds
+	defaultWebhookMaxBackoff     = 21600 // seconds (6 hours)
+
+	defaultLinksBaseURL = "http://127.0.0.1:20201"
+)
+
+// Attachments represent email attachment limits
+type Attachments struct {
+	// MaxFileSize maximum size (bytes) of one attachment
+	MaxFileSize int64 `json:"maxFileSize" yaml:"maxFileSize"`
+	// MaxTotalSize maximum size (bytes) of all attachments in one email
+	MaxTotalSize int64 `json:"maxTotalSize" yaml:"maxTotalSize"`
+	// AllowedContentTypes allowed attachment content types (empty: allow all)
+	AllowedContentTypes []string `json:"allowedContentTypes" yaml:"allowedContentTypes"`
+	// SpoolThreshold uploaded attachment bigger than this size (bytes) is spooled to dirLocations.temp
+	SpoolThreshold int64 `json:"spoolThreshold" yaml:"spoolThreshold"`
+}
+
+// GetMaxFileSize get MaxFileSize (or default value)
+func (a *Attachments) GetMaxFileSize() int64 {
+	if a.MaxFileSize <= 0 {
+		return defaultAttachmentMaxFileSize
+	}
+	return a.MaxFileSize
+}
+
+// GetMaxTotalSize get MaxTotalSize (or default value)
+func (a *Attachments) GetMaxTotalSize() int64 {
+	if a.MaxTotalSize <= 0 {
+		return defaultAttachmentMaxTotalSize
+	}
+	return a.MaxTotalSize
+}
+
+// GetSpoolThreshold get SpoolThreshold (or default value)
+func (a *Attachments) GetSpoolThreshold() int64 {
+	if a.SpoolThreshold <= 0 {
+		return defaultAttachmentSpoolThreshold
+	}
+	return a.SpoolThreshold
+}
+
+// Scheduler represent scheduled delivery (sendAt) dispatcher config
+type Scheduler struct {
+	// PollInterval interval (seconds) between polls for due messages
+	PollInterval int `json:"pollInterval" yaml:"pollInterval"`
+	// BatchSize maximum number of due messages dispatched in one poll
+	BatchSize int `json:"batchSize" yaml:"batchSize"`
+	// LeaseTimeout claimed message not finished within this time (seconds) is dispatched again (e.g: after a crash)
+	LeaseTimeout int `json:"leaseTimeout" yaml:"leaseTimeout"`
+}
+
+// GetPollInterval get PollInterval (or default value)
+func (s *Scheduler) GetPollInterval() time.Duration {
+	if s.PollInterval <= 0 {
+		return defaultSchedulerPollInterval * time.Second
+	}
+	return time.Duration(s.PollInterval) * time.Second
+}
+
+// GetBatchSize get BatchSize (or default value)
+func (s *Scheduler) GetBatchSize() int {
+	if s.BatchSize <= 0 {
+		return defaultSchedulerBatchSize
+	}
+	return s.BatchSize
+}
+
+// GetLeaseTimeout get LeaseTimeout (or default value)
+func (s *Scheduler) GetLeaseTimeout() time.Duration {
+	if s.LeaseTimeout <= 0 {
+		return defaultSchedulerLeaseTimeout * time.Second
+	}
+	return time.Duration(s.LeaseTimeout) * time.Second
+}
+
+// Outbox represent outbox (ASYNC and scheduled sends) delivery retry config
+type Outbox struct {
+	// MaxAttempts maximum delivery attempts, the message is dead-lettered (FAILED) after the last attempt
+	MaxAttempts int `json:"maxAttempts" yaml:"maxAttempts"`
+	// InitialBackoff delay (seconds) before the first retry, doubled on each next retry
+	InitialBackoff int `json:"initialBackoff" yaml:"initialBackoff"`
+	// MaxBackoff maximum delay (seconds) between retries
+	MaxBackoff int `json:"maxBackoff" yaml:"maxBackoff"`
+}
+
+// GetMaxAttempts get MaxAttempts (or default value)
+func (o *Outbox) GetMaxAttempts() int {
+	if o.MaxAttempts <= 0 {
+		return defaultOutboxMaxAttempts
+	}
+	return o.MaxAttempts
+}
+
+// GetInitialBackoff get InitialBackoff (or default value)
+func (o *Outbox) GetInitialBackoff() time.Duration {
+	if o.InitialBackoff <= 0 {
+		return defaultOutboxInitialBackoff * time.Second
+	}
+	return time.Duration(o.InitialBackoff) * time.Second
+}
+
+// GetMaxBackoff get MaxBackoff (or default value)
+func (o *Outbox) GetMaxBackoff() time.Duration {
+	if o.MaxBackoff <= 0 {
+		return defaultOutboxMaxBackoff * time.Second
+	}
+	return time.Duration(o.MaxBackoff) * time.Second
+}
+
+// Queue represent delivery queue (ASYNC sends) config
+type Queue struct {
+	// Engine queue engine: "redis", or empty (outbox polling only)
+	Engine string `json:"engine" yaml:"engine"`
+	// ConnectionName cache connection name (caches.*.connectionName) of the queue engine
+	ConnectionName string `json:"connectionName" yaml:"connectionName"`
+	// Key redis list key
+	Key string `json:"key" yaml:"key"`
+	// BlockTimeout maximum time (seconds) a consumer waits for a queued message
+	BlockTimeout int `json:"blockTimeout" yaml:"blockTimeout"`
+}
+
+// IsEnabled check whether the delivery queue is enabled
+func (q *Queue) IsEnabled() bool {
+	return q.Engine != ""
+}
+
+// GetKey get Key (or default value)
+func (q *Queue) GetKey() string {
+	if q.Key == "" {
+		return defaultQueueKey
+	}
+	return q.Key
+}
+
+// GetBlockTimeout get BlockTimeout (or default value)
+func (q *Queue) GetBlockTimeout() time.Duration {
+	if q.BlockTimeout <= 0 {
+		return defaultQueueBlockTimeout * time.Second
+	}
+	return time.Duration(q.BlockTimeout) * time.Second
+}
+
+// Worker represent delivery worker config
+type Worker struct {
+	// Standalone deliver messages in `server worker` processes only (`server restapi` does not dispatch)
+	Standalone bool `json:"standalone" yaml:"standalone"`
+	// Consumers number of queue consumers in one `server worker` process
+	Consumers int `json:"consumers" yaml:"consumers"`
+}
+
+// GetConsumers get Consumers (or default value)
+func (w *Worker) GetConsumers() int {
+	if w.Consumers <= 0 {
+		return defaultWorkerConsumers
+	}
+	return w.Consumers
+}
+
+// Idempotency represent Idempotency-Key (send request) config
+type Idempotency struct {
+	// Window time (seconds) an Idempotency-Key is kept, the same key within the window returns the original response
+	Window int `json:"window" yaml:"window"`
+	// ProcessingTimeout time (seconds) a key is held by a request being processed,
+	// a key still PROCESSING after the timeout is abandoned (e.g: crash) and can be used again
+	ProcessingTimeout int `json:"processingTimeout" yaml:"processingTimeout"`
+}
+
+// GetWindow get Window (or default value)
+func (i *Idempotency) GetWindow() time.Duration {
+	if i.Window <= 0 {
+		return defaultIdempotencyWindow * time.Second
+	}
+	return time.Duration(i.Window) * time.Second
+}
+
+// GetProcessingTimeout get ProcessingTimeout (or default value)
+func (i *Idempotency) GetProcessingTimeout() time.Duration {
+	if i.ProcessingTimeout <= 0 {
+		return defaultIdempotencyProcessingTimeout * time.Second
+	}
+	return time.Duration(i.ProcessingTimeout) * time.Second
+}
+
+// Webhook represent outbound webhook delivery config
+type Webhook struct {
+	// Timeout maximum time (seconds) to wait for the webhook endpoint response
+	Timeout int `json:"timeout" yaml:"timeout"`
+	// MaxAttempts maximum delivery attempts, the delivery is FAILED after the last attempt (and can be replayed)
+	MaxAttempts int `json:"maxAttempts" yaml:"maxAttempts"`
+	// InitialBackoff delay (seconds) before the first retry, doubled on each next retry
+	InitialBackoff int `json:"initialBackoff" yaml:"initialBackoff"`
+	// MaxBackoff maximum delay (seconds) between retries
+	MaxBackoff int `json:"maxBackoff" yaml:"maxBackoff"`
+}
+
+// GetTimeout get Timeout (or default value)
+func (w *Webhook) GetTimeout() time.Duration {
+	if w.Timeout <= 0 {
+		return defaultWebhookTimeout * time.Second
+	}
+	return time.Duration(w.Timeout) * time.Second
+}
+
+// GetMaxAttempts get MaxAttempts (or default value)
+func (w *Webhook) GetMaxAttempts() int {
+	if w.MaxAttempts <= 0 {
+		return defaultWebhookMaxAttempts
+	}
+	return w.MaxAttempts
+}
+
+// GetInitialBackoff get InitialBackoff (or default value)
+func (w *Webhook) GetInitialBackoff() time.Duration {
+	if w.InitialBackoff <= 0 {
+		return defaultWebhookInitialBackoff * time.Second
+	}
+	return time.Duration(w.InitialBackoff) * time.Second
+}
+
+// GetMaxBackoff get MaxBackoff (or default value)
+func (w *Webhook) GetMaxBackoff() time.Duration {
+	if w.MaxBackoff <= 0 {
+		return defaultWebhookMaxBackoff * time.Second
+	}
+	return time.Duration(w.MaxBackoff) * time.Second
+}
+
+// Links represent public (signed) links in sent emails config, e.g: unsubscribe
+type Links struct {
+	// BaseURL public base URL of this service (scheme://host[:port]), as reached by the email recipients
+	BaseURL string `json:"baseURL" yaml:"baseURL"`
+	// SigningKey HMAC-SHA256 key of signed links, links can not be generated without it
+	SigningKey string `json:"signingKey" yaml:"signingKey"`
+}
+
+// GetBaseURL get BaseURL (or default value), without trailing slash
+func (l *Links) GetBaseURL() string {
+	if l.BaseURL == "" {
+		return defaultLinksBaseURL
+	}
+	return strings.TrimRight(l.BaseURL, "/")
+}
+
+// Sandbox represent recipient policy of non-PRODUCTION stages (environment.stage), real recipients are redirected or dropped
+type Sandbox struct {
+	// CatchAll email address the recipients not in the allowlist are redirected to (empty: they are dropped)
+	CatchAll string `json:"catchAll" yaml:"catchAll"`
+	// Allowlist email addresses (or `@domain`) that still receive their emails
+	Allowlist []string `json:"allowlist" yaml:"allowlist"`
+}
+
+// Addresses represent email address validation (send request) config
+type Addresses struct {
+	// DisposableDomainsFile blocklist file of disposable email domains, one domain per line (empty: no blocklist)
+	DisposableDomainsFile string `json:"disposableDomainsFile" yaml:"disposableDomainsFile"`
+}